@@ -32,8 +32,11 @@ type Ins struct {
 	workdir   string
 	stream    bool
 	outStream chan string
+	onStart   func(pid int)
 	ctx       context.Context
 	cmdIns    *exec.Cmd
+	stdout    string
+	stderr    string
 }
 type CmdOption func(info *Ins)
 
@@ -99,6 +102,13 @@ func WithStream(outStream chan string) CmdOption {
 	}
 }
 
+// WithOnStart f will be called with pid of process once it started
+func WithOnStart(f func(pid int)) CmdOption {
+	return func(i *Ins) {
+		i.onStart = f
+	}
+}
+
 func WithContext(ctx context.Context) CmdOption {
 	return func(i *Ins) {
 		i.ctx = ctx
@@ -202,6 +212,9 @@ func (i *Ins) cmd() (out string, err error) {
 		fmt.Printf("err: %s\n", err.Error())
 		return "", err
 	}
+	if i.onStart != nil {
+		i.onStart(cmd.Process.Pid)
+	}
 
 	waitChan := make(chan struct{}, 1)
 	defer close(waitChan)
@@ -223,7 +236,9 @@ func (i *Ins) cmd() (out string, err error) {
 		}
 	}()
 	i.cmdIns = cmd
-	if err := cmd.Wait(); err != nil {
+	err = cmd.Wait()
+	i.stdout, i.stderr = stdout.String(), stderr.String()
+	if err != nil {
 		//fmt.Printf("timeout kill job ppid:%s\n%s\n", b.String(), err.Error())
 		em := err.Error()
 		// 超时退出，返回调用失败
@@ -246,6 +261,24 @@ func (i *Ins) cmd() (out string, err error) {
 	return
 }
 
+// ExitCode exit code of the last run, -1 if not exited or killed by signal
+func (i *Ins) ExitCode() int {
+	if i.cmdIns == nil || i.cmdIns.ProcessState == nil {
+		return -1
+	}
+	return i.cmdIns.ProcessState.ExitCode()
+}
+
+// Stdout stdout of the last run which is not in stream mode
+func (i *Ins) Stdout() string {
+	return i.stdout
+}
+
+// Stderr stderr of the last run which is not in stream mode
+func (i *Ins) Stderr() string {
+	return i.stderr
+}
+
 func addEnv(cmd *exec.Cmd, envs map[string]string) {
 	if len(cmd.Env) == 0 {
 		cmd.Env = []string{}
@@ -339,6 +372,9 @@ func (i *Ins) cmdWithStream() error {
 	if err := cmd.Start(); err != nil {
 		return errors.Wrap(err, "cmd start")
 	}
+	if i.onStart != nil {
+		i.onStart(cmd.Process.Pid)
+	}
 
 	waitChan := make(chan struct{}, 1)
 	defer close(waitChan)
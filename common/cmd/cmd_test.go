@@ -0,0 +1,40 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIns_ExitCode(t *testing.T) {
+	i := NewDefaultCMD("echo out; echo err >&2; exit 3", nil)
+	out, err := i.Run()
+	assert.NotNil(t, err)
+	assert.Equal(t, "out\n", out)
+	assert.Equal(t, 3, i.ExitCode())
+	assert.Equal(t, "out\n", i.Stdout())
+	assert.Equal(t, "err\n", i.Stderr())
+
+	i = NewDefaultCMD("echo ok", nil, WithOnStart(func(pid int) {
+		assert.NotZero(t, pid)
+	}))
+	out, err = i.Run()
+	assert.Nil(t, err)
+	assert.Equal(t, "ok\n", out)
+	assert.Equal(t, 0, i.ExitCode())
+}
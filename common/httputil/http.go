@@ -18,6 +18,11 @@ var (
 
 // HTTPDoPost http post
 func HTTPDoPost(body interface{}, url string) ([]byte, error) {
+	return HTTPDoPostWithHeader(body, url, nil)
+}
+
+// HTTPDoPostWithHeader http post json body with header
+func HTTPDoPostWithHeader(body interface{}, url string, header map[string]string) ([]byte, error) {
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, errors.Wrap(err, "marshal body")
@@ -26,6 +31,9 @@ func HTTPDoPost(body interface{}, url string) ([]byte, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "new http request, url [%s]", url)
 	}
+	for k, v := range header {
+		request.Header.Set(k, v)
+	}
 	res, err := client.Do(request)
 	if err != nil {
 		return nil, errors.Wrap(err, "do http post")
@@ -47,10 +55,18 @@ func HTTPDoPost(body interface{}, url string) ([]byte, error) {
 
 // HTTPDoGet http get
 func HTTPDoGet(url string) ([]byte, error) {
+	return HTTPDoGetWithHeader(url, nil)
+}
+
+// HTTPDoGetWithHeader http get with headers
+func HTTPDoGetWithHeader(url string, header map[string]string) ([]byte, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "new get request, url [%s]", url)
 	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
 	response, err := client.Do(req)
 	if err != nil {
 		return nil, errors.Wrap(err, "do http get")
@@ -0,0 +1,128 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"context"
+	"go.uber.org/zap"
+)
+
+// field keys carried by context and written to every log line
+const (
+	FieldRequestID   = "request_id"
+	FieldJobID       = "job_id"
+	FieldMachineUUID = "machine_uuid"
+	FieldMachineID   = "machine_id"
+	FieldChainUUID   = "chain_uuid"
+	FieldAppUUID     = "app_uuid"
+	FieldModule      = "module"
+)
+
+type fieldsKey struct{}
+
+type ctxField struct {
+	key string
+	val string
+}
+
+// WithField return a copy of ctx which carry the log field, same key will be overwritten
+func WithField(ctx context.Context, key, val string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	old, _ := ctx.Value(fieldsKey{}).([]ctxField)
+	fs := make([]ctxField, 0, len(old)+1)
+	for _, f := range old {
+		if f.key != key {
+			fs = append(fs, f)
+		}
+	}
+	if len(val) != 0 {
+		fs = append(fs, ctxField{key: key, val: val})
+	}
+	return context.WithValue(ctx, fieldsKey{}, fs)
+}
+
+// FieldValue get log field value from ctx
+func FieldValue(ctx context.Context, key string) string {
+	if ctx == nil {
+		return ""
+	}
+	fs, _ := ctx.Value(fieldsKey{}).([]ctxField)
+	for _, f := range fs {
+		if f.key == key {
+			return f.val
+		}
+	}
+	return ""
+}
+
+// WithRequestID set request id to ctx
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return WithField(ctx, FieldRequestID, id)
+}
+
+// WithJobID set job id to ctx
+func WithJobID(ctx context.Context, id string) context.Context {
+	return WithField(ctx, FieldJobID, id)
+}
+
+// WithMachineUUID set machine uuid to ctx
+func WithMachineUUID(ctx context.Context, uuid string) context.Context {
+	return WithField(ctx, FieldMachineUUID, uuid)
+}
+
+// WithMachineID set machine id in core to ctx, used by agent which only knows the id
+func WithMachineID(ctx context.Context, id string) context.Context {
+	return WithField(ctx, FieldMachineID, id)
+}
+
+// WithChainUUID set chain uuid to ctx
+func WithChainUUID(ctx context.Context, uuid string) context.Context {
+	return WithField(ctx, FieldChainUUID, uuid)
+}
+
+// WithAppUUID set app uuid to ctx
+func WithAppUUID(ctx context.Context, uuid string) context.Context {
+	return WithField(ctx, FieldAppUUID, uuid)
+}
+
+// WithModule set module name to ctx, module level will be used if configured
+func WithModule(ctx context.Context, module string) context.Context {
+	return WithField(ctx, FieldModule, module)
+}
+
+// RequestID get request id from ctx
+func RequestID(ctx context.Context) string {
+	return FieldValue(ctx, FieldRequestID)
+}
+
+// fields convert ctx fields to zap fields
+func fields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	fs, _ := ctx.Value(fieldsKey{}).([]ctxField)
+	if len(fs) == 0 {
+		return nil
+	}
+	zfs := make([]zap.Field, 0, len(fs))
+	for _, f := range fs {
+		zfs = append(zfs, zap.String(f.key, f.val))
+	}
+	return zfs
+}
@@ -2,23 +2,64 @@ package log
 
 import (
 	"context"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+	"io"
 	"os"
+	"strings"
+	"sync/atomic"
 )
 
 var logger *zap.Logger
 
+// lv current level settings, type is *levels
+var lv atomic.Value
+
+// levels default level and per module level
+type levels struct {
+	def     zapcore.Level
+	modules map[string]zapcore.Level
+}
+
+// Config log config, normally unmarshal from viper key 'log'
+type Config struct {
+	// Level default level, debug/info/warn/error, default info
+	Level string `mapstructure:"level"`
+	// Format json or console, default console
+	Format string `mapstructure:"format"`
+	// Output stdout, stderr or file path, default stdout
+	Output string `mapstructure:"output"`
+	// Modules per module level, key is module name set by WithModule
+	Modules map[string]string `mapstructure:"modules"`
+	// Rotate file rotation, only used when output is a file
+	Rotate RotateConfig `mapstructure:"rotate"`
+}
+
+// RotateConfig log file rotate config
+type RotateConfig struct {
+	// MaxSize megabytes of file before rotated, default 100
+	MaxSize int `mapstructure:"max_size"`
+	// MaxAge days to retain old files, 0 means not remove by age
+	MaxAge int `mapstructure:"max_age"`
+	// MaxBackups max number of old files, 0 means retain all
+	MaxBackups int `mapstructure:"max_backups"`
+	// Compress whether compress old files with gzip
+	Compress bool `mapstructure:"compress"`
+}
+
 func init() {
 	l, err := zap.NewProduction()
 	if err != nil {
 		panic(err)
 	}
 	logger = l
+	lv.Store(&levels{def: zapcore.InfoLevel})
 }
 
-func InitCus() {
-	consoleConfig := zapcore.EncoderConfig{
+func encoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
 		LevelKey:       "level",
 		TimeKey:        "time",
 		NameKey:        "logger",
@@ -32,6 +73,10 @@ func InitCus() {
 		EncodeCaller:   zapcore.ShortCallerEncoder,
 		EncodeName:     zapcore.FullNameEncoder,
 	}
+}
+
+func InitCus() {
+	consoleConfig := encoderConfig()
 	// // 开启开发模式，堆栈跟踪
 	caller := zap.AddCaller()
 	skip := zap.AddCallerSkip(1)
@@ -46,46 +91,155 @@ func InitCus() {
 	atomicLevel.SetLevel(l)
 
 	cc := zapcore.NewCore(zapcore.NewConsoleEncoder(consoleConfig), zapcore.AddSync(os.Stdout), atomicLevel)
-	logger = zap.New(cc, caller,skip, development)
+	logger = zap.New(cc, caller, skip, development)
+	lv.Store(&levels{def: l})
+}
+
+// Init init logger by config, replace the logger built by InitCus
+func Init(cfg Config) error {
+	def, err := parseLevel(cfg.Level, zapcore.InfoLevel)
+	if err != nil {
+		return errors.Wrap(err, "parse default level")
+	}
+	ls := &levels{def: def, modules: make(map[string]zapcore.Level)}
+	for module, level := range cfg.Modules {
+		ml, err := parseLevel(level, def)
+		if err != nil {
+			return errors.Wrapf(err, "parse level of module [%s]", module)
+		}
+		ls.modules[strings.ToLower(module)] = ml
+	}
+
+	var encoder zapcore.Encoder
+	switch strings.ToLower(cfg.Format) {
+	case "json":
+		encoder = zapcore.NewJSONEncoder(encoderConfig())
+	case "", "console":
+		encoder = zapcore.NewConsoleEncoder(encoderConfig())
+	default:
+		return errors.Errorf("unsupported log format [%s]", cfg.Format)
+	}
+
+	var out io.Writer
+	switch cfg.Output {
+	case "", "stdout":
+		out = os.Stdout
+	case "stderr":
+		out = os.Stderr
+	default:
+		maxSize := cfg.Rotate.MaxSize
+		if maxSize <= 0 {
+			maxSize = 100
+		}
+		out = &lumberjack.Logger{
+			Filename:   cfg.Output,
+			MaxSize:    maxSize,
+			MaxAge:     cfg.Rotate.MaxAge,
+			MaxBackups: cfg.Rotate.MaxBackups,
+			LocalTime:  true,
+			Compress:   cfg.Rotate.Compress,
+		}
+	}
+
+	// core accept all levels, filter by enabled which know the module of ctx
+	cc := zapcore.NewCore(encoder, zapcore.AddSync(out), zapcore.DebugLevel)
+	logger = zap.New(cc, zap.AddCaller(), zap.AddCallerSkip(1))
+	lv.Store(ls)
+	return nil
+}
+
+func parseLevel(text string, def zapcore.Level) (zapcore.Level, error) {
+	if len(text) == 0 {
+		return def, nil
+	}
+	var l zapcore.Level
+	err := l.UnmarshalText([]byte(strings.ToLower(text)))
+	if err != nil {
+		return def, err
+	}
+	return l, nil
+}
+
+// enabled check level by module in ctx
+func enabled(ctx context.Context, l zapcore.Level) bool {
+	ls := lv.Load().(*levels)
+	if module := FieldValue(ctx, FieldModule); len(module) != 0 {
+		if ml, ok := ls.modules[strings.ToLower(module)]; ok {
+			return ml.Enabled(l)
+		}
+	}
+	return ls.def.Enabled(l)
+}
+
+// with logger carry fields in ctx
+func with(ctx context.Context) *zap.Logger {
+	fs := fields(ctx)
+	if len(fs) == 0 {
+		return logger
+	}
+	return logger.With(fs...)
 }
 
 func Debug(ctx context.Context, msg string, fields ...zap.Field) {
-	logger.Debug(msg, fields...)
+	if !enabled(ctx, zapcore.DebugLevel) {
+		return
+	}
+	with(ctx).Debug(msg, fields...)
 }
 
 func Debugf(ctx context.Context, template string, args ...interface{}) {
-	logger.Sugar().Debugf(template, args...)
+	if !enabled(ctx, zapcore.DebugLevel) {
+		return
+	}
+	with(ctx).Sugar().Debugf(template, args...)
 }
 
 func Info(ctx context.Context, msg string, fields ...zap.Field) {
-	logger.Info(msg, fields...)
+	if !enabled(ctx, zapcore.InfoLevel) {
+		return
+	}
+	with(ctx).Info(msg, fields...)
 }
 
 func Infof(ctx context.Context, template string, args ...interface{}) {
-	logger.Sugar().Infof(template, args...)
+	if !enabled(ctx, zapcore.InfoLevel) {
+		return
+	}
+	with(ctx).Sugar().Infof(template, args...)
 }
 
 func Error(ctx context.Context, msg string, fields ...zap.Field) {
-	logger.Error(msg, fields...)
+	if !enabled(ctx, zapcore.ErrorLevel) {
+		return
+	}
+	with(ctx).Error(msg, fields...)
 }
 
 func Errorf(ctx context.Context, template string, args ...interface{}) {
-	logger.Sugar().Errorf(template, args...)
+	if !enabled(ctx, zapcore.ErrorLevel) {
+		return
+	}
+	with(ctx).Sugar().Errorf(template, args...)
 }
 
 func Warn(ctx context.Context, msg string, fields ...zap.Field) {
-	logger.Warn(msg, fields...)
+	if !enabled(ctx, zapcore.WarnLevel) {
+		return
+	}
+	with(ctx).Warn(msg, fields...)
 }
 
 func Warnf(ctx context.Context, template string, args ...interface{}) {
-	logger.Sugar().Warnf(template, args...)
+	if !enabled(ctx, zapcore.WarnLevel) {
+		return
+	}
+	with(ctx).Sugar().Warnf(template, args...)
 }
 
-
 func Fatal(ctx context.Context, msg string, fields ...zap.Field) {
-	logger.Fatal(msg, fields...)
+	with(ctx).Fatal(msg, fields...)
 }
 
 func Fatalf(ctx context.Context, template string, args ...interface{}) {
-	logger.Sugar().Fatalf(template, args...)
+	with(ctx).Sugar().Fatalf(template, args...)
 }
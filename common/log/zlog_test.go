@@ -0,0 +1,50 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+	"testing"
+)
+
+func TestWithField(t *testing.T) {
+	t.Run("test ctx fields", func(t *testing.T) {
+		ctx := WithRequestID(context.Background(), "r1")
+		ctx = WithAppUUID(ctx, "app-1")
+		ctx = WithRequestID(ctx, "r2")
+		assert.Equal(t, "r2", RequestID(ctx))
+		assert.Equal(t, "app-1", FieldValue(ctx, FieldAppUUID))
+		assert.Len(t, fields(ctx), 2)
+		assert.Nil(t, fields(nil))
+	})
+}
+
+func TestInit(t *testing.T) {
+	t.Run("test module level", func(t *testing.T) {
+		err := Init(Config{Level: "warn", Format: "json", Modules: map[string]string{"api_g": "debug"}})
+		assert.Nil(t, err)
+		assert.False(t, enabled(context.Background(), zapcore.InfoLevel))
+		assert.True(t, enabled(WithModule(context.Background(), "api_g"), zapcore.DebugLevel))
+		assert.False(t, enabled(WithModule(context.Background(), "api_c"), zapcore.InfoLevel))
+	})
+	t.Run("test bad config", func(t *testing.T) {
+		assert.NotNil(t, Init(Config{Format: "xml"}))
+		assert.NotNil(t, Init(Config{Level: "loud"}))
+	})
+}
@@ -18,6 +18,7 @@ package app
 import (
 	"fmt"
 	"github.com/spf13/cobra"
+	"github.com/zibuyu28/cmapp/common/log"
 	"os"
 
 	homedir "github.com/mitchellh/go-homedir"
@@ -84,4 +85,11 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+
+	// init logger if log config is set, otherwise keep the default one
+	if viper.IsSet("log") {
+		var lc log.Config
+		cobra.CheckErr(viper.UnmarshalKey("log", &lc))
+		cobra.CheckErr(log.Init(lc))
+	}
 }
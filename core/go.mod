@@ -7,10 +7,12 @@ require (
 	github.com/go-playground/validator/v10 v10.4.1
 	github.com/go-sql-driver/mysql v1.5.0
 	github.com/google/uuid v1.2.0
+	github.com/gorilla/websocket v1.4.2
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/pkg/errors v0.8.1
 	github.com/spf13/cobra v1.1.3
 	github.com/spf13/viper v1.8.0
+	github.com/stretchr/testify v1.7.0
 	github.com/zibuyu28/cmapp/common v0.0.0-incompatible
 	github.com/zibuyu28/cmapp/plugin v0.0.0-incompatible
 	go.uber.org/zap v1.17.0
@@ -0,0 +1,38 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api_c
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/audit"
+)
+
+// auditListExec query audits, time format of start and end is RFC3339
+func auditListExec(g *gin.Context) {
+	var req audit.QueryReq
+	err := g.BindQuery(&req)
+	if err != nil {
+		fail(g, errors.Wrap(err, "bind query"))
+		return
+	}
+	audits, err := audit.Query(g.Request.Context(), &req)
+	if err != nil {
+		fail(g, errors.Wrap(err, "query audits"))
+		return
+	}
+	ok(g, audits)
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api_c
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/internal/server/mid"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/audit"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/machine"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"time"
+)
+
+// appExecIn exec command in app and return the result
+func appExecIn(g *gin.Context) {
+	if err := writePermitted(g); err != nil {
+		fail(g, err)
+		return
+	}
+	var req ag.ExecReq
+	err := g.BindJSON(&req)
+	if err != nil {
+		fail(g, err)
+		return
+	}
+	res, err := machine.RMDIns.ExecInApp(g.Request.Context(), g.Param("uuid"), &req)
+	if err != nil {
+		fail(g, err)
+		return
+	}
+	ok(g, res)
+}
+
+// appTTYExec exec command in app with tty over websocket. The first message of client is
+// the json of ag.ExecReq, the following messages are json of ag.TTYInput. Output of tty is
+// sent as binary message, and a text message of ag.TTYExit is sent when command exit.
+func appTTYExec(g *gin.Context) {
+	if err := writePermitted(g); err != nil {
+		fail(g, err)
+		return
+	}
+	appUUID := g.Param("uuid")
+	conn, err := logUpgrader.Upgrade(g.Writer, g.Request, nil)
+	if err != nil {
+		log.Errorf(g.Request.Context(), "Manage err when upgrade http protocol to websocket, Err: [%v]", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(log.WithAppUUID(g.Request.Context(), appUUID))
+	defer cancel()
+
+	var start ag.ExecReq
+	_, msg, err := conn.ReadMessage()
+	if err == nil {
+		err = json.Unmarshal(msg, &start)
+	}
+	if err != nil {
+		ttyClose(ctx, conn, errors.Wrap(err, "read start message"))
+		return
+	}
+	a := &model.Audit{
+		Actor:    g.GetHeader(mid.ActorHeader),
+		Protocol: audit.ProtocolHTTP,
+		Action:   fmt.Sprintf("%s %s", g.Request.Method, g.FullPath()),
+		Entity:   "app",
+		TargetID: appUUID,
+		Params:   audit.RedactValue(start),
+		Result:   audit.ResultSuccess,
+	}
+	if len(a.Actor) == 0 {
+		a.Actor = g.ClientIP()
+	}
+
+	input := make(chan *ag.TTYInput)
+	go func() {
+		defer close(input)
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				cancel()
+				return
+			}
+			in := &ag.TTYInput{}
+			if err = json.Unmarshal(msg, in); err != nil {
+				log.Warnf(ctx, "Currently ignore invalid tty input, Err: [%v]", err)
+				continue
+			}
+			select {
+			case input <- in:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	exit, err := machine.RMDIns.ExecInAppTTY(ctx, appUUID, &start, input, func(b []byte) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(logWriteWait))
+		return conn.WriteMessage(websocket.BinaryMessage, b)
+	})
+	if err != nil {
+		a.Result = audit.ResultFail
+		a.Message = err.Error()
+	}
+	audit.Record(ctx, a)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Errorf(ctx, "Manage err when exec in app with tty. Err: [%v]", err)
+		}
+		ttyClose(ctx, conn, err)
+		return
+	}
+	_ = conn.SetWriteDeadline(time.Now().Add(logWriteWait))
+	_ = conn.WriteJSON(exit)
+	ttyClose(ctx, conn, nil)
+}
+
+// ttyClose send close message to websocket client
+func ttyClose(ctx context.Context, conn *websocket.Conn, err error) {
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	if err != nil && ctx.Err() == nil {
+		reason := err.Error()
+		if len(reason) > maxCloseReason {
+			reason = reason[:maxCloseReason]
+		}
+		closeMsg = websocket.FormatCloseMessage(websocket.CloseInternalServerErr, reason)
+	}
+	_ = conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(logWriteWait))
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api_c
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/machine"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"net/http"
+	"time"
+)
+
+const (
+	// time allowed to write a message to the peer
+	logWriteWait = 10 * time.Second
+	// send pings to peer with this period
+	logPingPeriod = 30 * time.Second
+	// max length of close reason in websocket close frame
+	maxCloseReason = 123
+)
+
+var logUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// appLogsExec relay logs of app to websocket client, every line is a json message of ag.LogLine
+func appLogsExec(g *gin.Context) {
+	var req ag.LogReq
+	err := g.BindQuery(&req)
+	if err != nil {
+		fail(g, err)
+		return
+	}
+	appUUID := g.Param("uuid")
+	conn, err := logUpgrader.Upgrade(g.Writer, g.Request, nil)
+	if err != nil {
+		log.Errorf(g.Request.Context(), "Manage err when upgrade http protocol to websocket, Err: [%v]", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(log.WithAppUUID(g.Request.Context(), appUUID))
+	defer cancel()
+	// stop streaming once client close the connection
+	go func() {
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+	go func() {
+		ticker := time.NewTicker(logPingPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(logWriteWait)); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	err = machine.RMDIns.StreamLogs(ctx, appUUID, &req, func(l *ag.LogLine) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(logWriteWait))
+		return conn.WriteJSON(l)
+	})
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	if err != nil && ctx.Err() == nil {
+		log.Errorf(ctx, "Manage err when stream logs of app. Err: [%v]", err)
+		reason := err.Error()
+		if len(reason) > maxCloseReason {
+			reason = reason[:maxCloseReason]
+		}
+		closeMsg = websocket.FormatCloseMessage(websocket.CloseInternalServerErr, reason)
+	}
+	_ = conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(logWriteWait))
+}
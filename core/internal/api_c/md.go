@@ -1,10 +1,12 @@
 package api_c
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
 	"github.com/zibuyu28/cmapp/core/internal/service_c/machine"
 	"github.com/zibuyu28/cmapp/core/pkg/ag"
 )
@@ -22,6 +24,12 @@ func mdExec(g *gin.Context) {
 		fail(g, err)
 		return
 	}
+	if _, need := writeFunctions[parse(p.Fnc)]; need {
+		if err = writePermitted(g); err != nil {
+			fail(g, err)
+			return
+		}
+	}
 	r, err := mdExecHandler(g.Request.Context(), &p)
 	if err != nil {
 		fail(g, err)
@@ -39,6 +47,8 @@ const (
 	startApp      function = "StartApp"
 	stopApp       function = "StopApp"
 	destroyApp    function = "DestroyApp"
+	updateApp     function = "UpdateApp"
+	scaleApp      function = "ScaleApp"
 	tagEx         function = "TagEx"
 	fileMountEx   function = "FileMountEx"
 	envEx         function = "EnvEx"
@@ -47,10 +57,32 @@ const (
 	limitEx       function = "LimitEx"
 	healthEx      function = "HealthEx"
 	logEx         function = "LogEx"
+	restartEx     function = "RestartEx"
+	volumeEx      function = "VolumeEx"
+	checkPort     function = "CheckPort"
+	getApp        function = "GetApp"
+	listApps      function = "ListApps"
+	renderApp     function = "RenderApp"
+	listFiles     function = "ListFiles"
+	readFile      function = "ReadFile"
+	writeFile     function = "WriteFile"
+	deleteFile    function = "DeleteFile"
+	archiveDir    function = "ArchiveDir"
 )
 
+// writeFunctions functions touch files in workspace of app, which require write permission.
+// Reading is gated too since workspace may contain key material
+var writeFunctions = map[function]struct{}{
+	listFiles:  {},
+	readFile:   {},
+	writeFile:  {},
+	deleteFile: {},
+	archiveDir: {},
+}
+
 // mdExecHandler machine driver exec handler
 func mdExecHandler(ctx context.Context, req *MDReq) (interface{}, error) {
+	ctx = log.WithAppUUID(ctx, req.AppUUID)
 	pb, err := json.Marshal(req.Param)
 	if err != nil {
 		return nil, errors.Wrap(err, "marshal request's param")
@@ -100,6 +132,28 @@ func mdExecHandler(ctx context.Context, req *MDReq) (interface{}, error) {
 			return nil, errors.Wrap(err, "destroy app")
 		}
 		return nar, nil
+	case updateApp:
+		var nar ag.UpdateAppReq
+		err = json.Unmarshal(pb, &nar)
+		if err != nil {
+			return nil, err
+		}
+		app, err := machine.RMDIns.UpdateApp(ctx, req.AppUUID, &nar)
+		if err != nil {
+			return nil, errors.Wrap(err, "update app")
+		}
+		return *app, nil
+	case scaleApp:
+		var nar ag.ScaleReq
+		err = json.Unmarshal(pb, &nar)
+		if err != nil {
+			return nil, err
+		}
+		app, err := machine.RMDIns.ScaleApp(ctx, req.AppUUID, &nar)
+		if err != nil {
+			return nil, errors.Wrap(err, "scale app")
+		}
+		return *app, nil
 	case tagEx:
 		var nar ag.Tag
 		err = json.Unmarshal(pb, &nar)
@@ -189,6 +243,119 @@ func mdExecHandler(ctx context.Context, req *MDReq) (interface{}, error) {
 			return nil, errors.Wrap(err, "set app log")
 		}
 		return nar, nil
+	case restartEx:
+		var nar ag.Restart
+		err = json.Unmarshal(pb, &nar)
+		if err != nil {
+			return nil, err
+		}
+		err = machine.RMDIns.RestartEx(ctx, req.AppUUID, &nar)
+		if err != nil {
+			return nil, errors.Wrap(err, "set app restart")
+		}
+		return nar, nil
+	case volumeEx:
+		var nar ag.Volume
+		err = json.Unmarshal(pb, &nar)
+		if err != nil {
+			return nil, err
+		}
+		err = machine.RMDIns.VolumeEx(ctx, req.AppUUID, &nar)
+		if err != nil {
+			return nil, errors.Wrap(err, "set app volume")
+		}
+		return nar, nil
+	case checkPort:
+		var nar ag.PortReq
+		err = json.Unmarshal(pb, &nar)
+		if err != nil {
+			return nil, err
+		}
+		res, err := machine.RMDIns.CheckPort(ctx, req.AppUUID, &nar)
+		if err != nil {
+			return nil, errors.Wrap(err, "check port")
+		}
+		return res, nil
+	case getApp:
+		res, err := machine.RMDIns.GetApp(ctx, req.AppUUID)
+		if err != nil {
+			return nil, errors.Wrap(err, "get app")
+		}
+		return res, nil
+	case listApps:
+		var nar ag.ListAppsReq
+		err = json.Unmarshal(pb, &nar)
+		if err != nil {
+			return nil, err
+		}
+		res, err := machine.RMDIns.ListApps(ctx, &nar)
+		if err != nil {
+			return nil, errors.Wrap(err, "list apps")
+		}
+		return res, nil
+	case renderApp:
+		res, err := machine.RMDIns.RenderApp(ctx, req.AppUUID)
+		if err != nil {
+			return nil, errors.Wrap(err, "render app")
+		}
+		return res, nil
+	case listFiles:
+		var nar ag.FileReq
+		err = json.Unmarshal(pb, &nar)
+		if err != nil {
+			return nil, err
+		}
+		res, err := machine.RMDIns.ListFiles(ctx, req.AppUUID, &nar)
+		if err != nil {
+			return nil, errors.Wrap(err, "list files")
+		}
+		return res, nil
+	case readFile:
+		var nar ag.FileReq
+		err = json.Unmarshal(pb, &nar)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		err = machine.RMDIns.ReadFile(ctx, req.AppUUID, &nar, &buf)
+		if err != nil {
+			return nil, errors.Wrap(err, "read file")
+		}
+		return buf.Bytes(), nil
+	case writeFile:
+		var nar ag.WriteFileReq
+		err = json.Unmarshal(pb, &nar)
+		if err != nil {
+			return nil, err
+		}
+		res, err := machine.RMDIns.WriteFile(ctx, req.AppUUID, &nar)
+		if err != nil {
+			return nil, errors.Wrap(err, "write file")
+		}
+		return res, nil
+	case deleteFile:
+		var nar ag.FileReq
+		err = json.Unmarshal(pb, &nar)
+		if err != nil {
+			return nil, err
+		}
+		err = machine.RMDIns.DeleteFile(ctx, req.AppUUID, &nar)
+		if err != nil {
+			return nil, errors.Wrap(err, "delete file")
+		}
+		return nar, nil
+	case archiveDir:
+		var nar ag.FileReq
+		err = json.Unmarshal(pb, &nar)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		err = machine.RMDIns.ArchiveDir(ctx, req.AppUUID, &nar, &buf)
+		if err != nil {
+			return nil, errors.Wrap(err, "archive dir")
+		}
+		return buf.Bytes(), nil
 	default:
 		return nil, errors.Errorf("function [%s] not correct", req.Fnc)
 	}
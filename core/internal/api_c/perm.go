@@ -0,0 +1,60 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api_c
+
+import (
+	"crypto/subtle"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"strings"
+)
+
+// writePermitted check the request carry the write token of api, the token can be set by
+// 'Authorization: Bearer <token>' header, or 'token' query for websocket client.
+// Api which require write permission is disabled when the token is not configured.
+func writePermitted(g *gin.Context) error {
+	token := viper.GetString("http.write_token")
+	if len(token) == 0 {
+		return errors.New("api require write permission, set 'http.write_token' to enable")
+	}
+	if !tokenMatched(g, token) {
+		return errors.New("write permission denied, token is invalid")
+	}
+	return nil
+}
+
+// agentPermitted check the request carry the agent token, which is passed to agents started
+// by core. Api only for agents is disabled when the token is not configured.
+func agentPermitted(g *gin.Context) error {
+	token := viper.GetString("http.agent_token")
+	if len(token) == 0 {
+		return errors.New("api require agent permission, set 'http.agent_token' to enable")
+	}
+	if !tokenMatched(g, token) {
+		return errors.New("agent permission denied, token is invalid")
+	}
+	return nil
+}
+
+func tokenMatched(g *gin.Context, token string) bool {
+	reqToken := strings.TrimPrefix(g.GetHeader("Authorization"), "Bearer ")
+	if len(reqToken) == 0 {
+		reqToken = g.Query("token")
+	}
+	return subtle.ConstantTimeCompare([]byte(reqToken), []byte(token)) == 1
+}
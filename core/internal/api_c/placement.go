@@ -0,0 +1,38 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api_c
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/placement"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+)
+
+func placementScheduleExec(g *gin.Context) {
+	var req ag.PlacementReq
+	err := g.BindJSON(&req)
+	if err != nil {
+		fail(g, err)
+		return
+	}
+	res, err := placement.Schedule(g.Request.Context(), &req)
+	if err != nil {
+		fail(g, errors.Wrap(err, "schedule nodes"))
+		return
+	}
+	ok(g, res)
+}
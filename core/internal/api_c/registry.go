@@ -0,0 +1,104 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api_c
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/registry"
+	"strconv"
+)
+
+func registryRegisterExec(g *gin.Context) {
+	if err := writePermitted(g); err != nil {
+		fail(g, err)
+		return
+	}
+	var req registry.RegisterReq
+	err := g.BindJSON(&req)
+	if err != nil {
+		fail(g, err)
+		return
+	}
+	r, err := registry.Register(g.Request.Context(), &req)
+	if err != nil {
+		fail(g, errors.Wrap(err, "register registry"))
+		return
+	}
+	ok(g, r)
+}
+
+func registryDeleteExec(g *gin.Context) {
+	if err := writePermitted(g); err != nil {
+		fail(g, err)
+		return
+	}
+	id, err := strconv.Atoi(g.Param("id"))
+	if err != nil {
+		fail(g, errors.Wrapf(err, "parse registry id [%s]", g.Param("id")))
+		return
+	}
+	err = registry.Remove(g.Request.Context(), id)
+	if err != nil {
+		fail(g, errors.Wrap(err, "remove registry"))
+		return
+	}
+	ok(g, id)
+}
+
+func registryListExec(g *gin.Context) {
+	rs, err := registry.List(g.Request.Context())
+	if err != nil {
+		fail(g, errors.Wrap(err, "list registries"))
+		return
+	}
+	ok(g, rs)
+}
+
+// imageCredential credential of registry which image belongs to, it is replaced in test
+var imageCredential = registry.ImageCredential
+
+// registryCredentialExec credential for pulling image, requested by workers with agent token.
+// Nil is returned to anyone if the registry is not registered, so image is pulled anonymously
+// even if agent token is not configured. Client with write token can only see which credential
+// is used, password is never returned.
+func registryCredentialExec(g *gin.Context) {
+	image := g.Query("image")
+	if len(image) == 0 {
+		fail(g, errors.New("image is nil"))
+		return
+	}
+	c, err := imageCredential(g.Request.Context(), image)
+	if err != nil {
+		fail(g, errors.Wrap(err, "get credential of image"))
+		return
+	}
+	if c == nil {
+		ok(g, nil)
+		return
+	}
+	err = agentPermitted(g)
+	if err == nil {
+		ok(g, c)
+		return
+	}
+	if writePermitted(g) != nil {
+		fail(g, errors.Wrapf(err, "get credential of registry [%s]", c.Server))
+		return
+	}
+	c.Password = ""
+	ok(g, c)
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api_c
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/registry"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegistryCredentialExec(t *testing.T) {
+	imageCredential = func(ctx context.Context, image string) (*registry.Credential, error) {
+		if image != "harbor.example.com/cmapp/app:1.0" {
+			return nil, nil
+		}
+		return &registry.Credential{Server: "harbor.example.com", Username: "u", Password: "p"}, nil
+	}
+	defer func() { imageCredential = registry.ImageCredential }()
+	defer viper.Reset()
+
+	gin.SetMode(gin.TestMode)
+	e := gin.New()
+	e.GET("/api/v1/registry/credential", registryCredentialExec)
+	srv := httptest.NewServer(e)
+	defer srv.Close()
+
+	get := func(image, token string) Resp {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/registry/credential?image="+image, nil)
+		assert.Nil(t, err)
+		if len(token) != 0 {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		defer resp.Body.Close()
+		var r Resp
+		assert.Nil(t, json.NewDecoder(resp.Body).Decode(&r))
+		return r
+	}
+
+	t.Run("test unregistered registry without agent token", func(t *testing.T) {
+		viper.Set("http.agent_token", "")
+		r := get("busybox:latest", "")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Nil(t, r.Data)
+	})
+	t.Run("test registered registry without agent token", func(t *testing.T) {
+		viper.Set("http.agent_token", "")
+		r := get("harbor.example.com/cmapp/app:1.0", "")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Contains(t, r.Message, "harbor.example.com")
+	})
+	t.Run("test registered registry with agent token", func(t *testing.T) {
+		viper.Set("http.agent_token", "agent")
+		r := get("harbor.example.com/cmapp/app:1.0", "agent")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "p", r.Data.(map[string]interface{})["password"])
+
+		r = get("harbor.example.com/cmapp/app:1.0", "wrong")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("test registered registry with write token", func(t *testing.T) {
+		viper.Set("http.agent_token", "agent")
+		viper.Set("http.write_token", "write")
+		r := get("harbor.example.com/cmapp/app:1.0", "write")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "u", r.Data.(map[string]interface{})["username"])
+		assert.Equal(t, "", r.Data.(map[string]interface{})["password"])
+	})
+}
@@ -22,7 +22,10 @@ type MethodPath string
 
 var GMR = map[RouterGroup]map[MethodPath]func(g *gin.Context){
 	RouterGroup(fmt.Sprintf("%s/md", V1.string())): {
-		mpf(http.MethodPost, "/exec"): mdExec,
+		mpf(http.MethodPost, "/exec"):          mdExec,
+		mpf(http.MethodGet, "/logs/:uuid"):     appLogsExec,
+		mpf(http.MethodPost, "/exec_in/:uuid"): appExecIn,
+		mpf(http.MethodGet, "/tty/:uuid"):      appTTYExec,
 	},
 	RouterGroup(fmt.Sprintf("%s/cw", V1.string())): {
 		mpf(http.MethodPost, "/exec"): cwExec,
@@ -39,6 +42,24 @@ var GMR = map[RouterGroup]map[MethodPath]func(g *gin.Context){
 		mpf(http.MethodGet, "/:name/:version"):       packageInfoExec,
 		mpf(http.MethodGet, "/:name/:version/:file"): packageDownloadExec,
 	},
+	RouterGroup(fmt.Sprintf("%s/registry", V1.string())): {
+		mpf(http.MethodPost, "/register"):  registryRegisterExec,
+		mpf(http.MethodDelete, "/:id"):     registryDeleteExec,
+		mpf(http.MethodGet, "/list"):       registryListExec,
+		mpf(http.MethodGet, "/credential"): registryCredentialExec,
+	},
+	RouterGroup(fmt.Sprintf("%s/audit", V1.string())): {
+		mpf(http.MethodGet, "/list"): auditListExec,
+	},
+	RouterGroup(fmt.Sprintf("%s/placement", V1.string())): {
+		mpf(http.MethodPost, "/schedule"): placementScheduleExec,
+	},
+	RouterGroup(fmt.Sprintf("%s/webhook", V1.string())): {
+		mpf(http.MethodPost, "/register"):      webhookRegisterExec,
+		mpf(http.MethodDelete, "/:id"):         webhookDeleteExec,
+		mpf(http.MethodGet, "/list"):           webhookListExec,
+		mpf(http.MethodGet, "/deliveries/:id"): webhookDeliveriesExec,
+	},
 }
 
 func mpf(httpMethod, relativePath string) MethodPath {
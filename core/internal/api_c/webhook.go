@@ -0,0 +1,76 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package api_c
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/webhook"
+	"strconv"
+)
+
+func webhookRegisterExec(g *gin.Context) {
+	var req webhook.RegisterReq
+	err := g.BindJSON(&req)
+	if err != nil {
+		fail(g, err)
+		return
+	}
+	w, err := webhook.Register(g.Request.Context(), &req)
+	if err != nil {
+		fail(g, errors.Wrap(err, "register webhook"))
+		return
+	}
+	ok(g, w)
+}
+
+func webhookDeleteExec(g *gin.Context) {
+	id, err := strconv.Atoi(g.Param("id"))
+	if err != nil {
+		fail(g, errors.Wrapf(err, "parse webhook id [%s]", g.Param("id")))
+		return
+	}
+	err = webhook.Remove(g.Request.Context(), id)
+	if err != nil {
+		fail(g, errors.Wrap(err, "remove webhook"))
+		return
+	}
+	ok(g, id)
+}
+
+func webhookListExec(g *gin.Context) {
+	ws, err := webhook.List(g.Request.Context())
+	if err != nil {
+		fail(g, errors.Wrap(err, "list webhooks"))
+		return
+	}
+	ok(g, ws)
+}
+
+func webhookDeliveriesExec(g *gin.Context) {
+	id, err := strconv.Atoi(g.Param("id"))
+	if err != nil {
+		fail(g, errors.Wrapf(err, "parse webhook id [%s]", g.Param("id")))
+		return
+	}
+	limit, _ := strconv.Atoi(g.Query("limit"))
+	ds, err := webhook.Deliveries(g.Request.Context(), id, limit)
+	if err != nil {
+		fail(g, errors.Wrap(err, "get webhook deliveries"))
+		return
+	}
+	ok(g, ds)
+}
@@ -37,6 +37,30 @@ func (m *CoreMachineManager) UpdateMachine(ctx context.Context, machine *ma_mana
 	return &ma_manager.UpdateMachineRes{Res: true}, nil
 }
 
+// ReportResource report allocatable and used resource of machine
+func (m *CoreMachineManager) ReportResource(ctx context.Context, res *ma_manager.MachineResource) (*ma_manager.UpdateMachineRes, error) {
+	if res.MachineID == 0 {
+		return nil, errors.New("machine id is nil")
+	}
+	err := service_g.UpdateMachineResource(ctx, res)
+	if err != nil {
+		return nil, errors.Wrap(err, "update machine resource")
+	}
+	return &ma_manager.UpdateMachineRes{Res: true}, nil
+}
+
+// ReportAppHealth report health transition of app, state of its node is updated
+func (m *CoreMachineManager) ReportAppHealth(ctx context.Context, h *ma_manager.AppHealth) (*ma_manager.UpdateMachineRes, error) {
+	if len(h.AppUUID) == 0 {
+		return nil, errors.New("app uuid is nil")
+	}
+	err := service_g.UpdateAppHealth(ctx, h)
+	if err != nil {
+		return nil, errors.Wrap(err, "update app health")
+	}
+	return &ma_manager.UpdateMachineRes{Res: true}, nil
+}
+
 // RegisterMachine register machine to center
 func (m *CoreMachineManager) RegisterMachine(ctx context.Context, machine *ma_manager.TypedMachine) (*ma_manager.RegisterMachineRes, error) {
 	err := service_g.RegisterMachine(ctx, machine)
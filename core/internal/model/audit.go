@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package model
+
+import (
+	"github.com/pkg/errors"
+	"time"
+)
+
+// Audit audit record of mutating operation
+type Audit struct {
+	ID         int       `xorm:"int(11) pk autoincr 'id'"`
+	CreateTime time.Time `xorm:"datetime created index 'create_time'"`
+	RequestID  string    `xorm:"varchar(64) 'request_id'"`
+	Actor      string    `xorm:"varchar(256) 'actor'"`
+	Protocol   string    `xorm:"varchar(16) 'protocol'"`
+	Action     string    `xorm:"varchar(256) 'action'"`
+	Entity     string    `xorm:"varchar(64) index(idx_audit_target) 'entity'"`
+	TargetID   string    `xorm:"varchar(128) index(idx_audit_target) 'target_id'"`
+	Params     string    `xorm:"text 'params'"`
+	Result     string    `xorm:"varchar(16) 'result'"`
+	Message    string    `xorm:"text 'message'"`
+}
+
+// InsertAudit insert audit to db
+func InsertAudit(audit *Audit) error {
+	_, err := ormEngine.Insert(audit)
+	if err != nil {
+		return errors.Wrap(err, "audit insert to db")
+	}
+	return nil
+}
+
+// QueryAudits query audits by entity, target id and time range, empty condition will be ignored
+func QueryAudits(entity, targetID string, start, end time.Time, limit int) ([]Audit, error) {
+	var as []Audit
+	session := ormEngine.Table(&Audit{})
+	if len(entity) != 0 {
+		session = session.Where("entity = ?", entity)
+	}
+	if len(targetID) != 0 {
+		session = session.And("target_id = ?", targetID)
+	}
+	if !start.IsZero() {
+		session = session.And("create_time >= ?", start)
+	}
+	if !end.IsZero() {
+		session = session.And("create_time <= ?", end)
+	}
+	if limit > 0 {
+		session = session.Limit(limit)
+	}
+	err := session.Desc("id").Find(&as)
+	if err != nil {
+		return nil, errors.Wrap(err, "query audits from db")
+	}
+	return as, nil
+}
@@ -47,5 +47,5 @@ func InitORMEngine() error {
 }
 
 func InitTable() error {
-	return ormEngine.Sync2(new(Machine), new(Driver), new(Chain), new(Node), new(Package))
+	return ormEngine.Sync2(new(Machine), new(Driver), new(Chain), new(Node), new(Package), new(Audit), new(Webhook), new(WebhookDelivery), new(PortReservation), new(Registry))
 }
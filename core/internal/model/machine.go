@@ -33,6 +33,15 @@ type Machine struct {
 	AGGRPCAddr string            `xorm:"varchar(128) 'ag_grpc_addr'"`
 	Tags       []string          `xorm:"text 'tags'"`
 	CustomInfo map[string]string `xorm:"text 'custom_info'"`
+
+	// resource reported by agent, cpu unit is 'm', memory and disk unit is 'MB'
+	CPUAllocatable    int       `xorm:"int(11) DEFAULT 0 'cpu_allocatable'"`
+	MemoryAllocatable int       `xorm:"int(11) DEFAULT 0 'memory_allocatable'"`
+	DiskAllocatable   int       `xorm:"int(11) DEFAULT 0 'disk_allocatable'"`
+	CPUUsed           int       `xorm:"int(11) DEFAULT 0 'cpu_used'"`
+	MemoryUsed        int       `xorm:"int(11) DEFAULT 0 'memory_used'"`
+	DiskUsed          int       `xorm:"int(11) DEFAULT 0 'disk_used'"`
+	ResourceTime      time.Time `xorm:"datetime 'resource_time'"`
 }
 
 // InsertMachine insert machine to db
@@ -65,3 +74,13 @@ func GetMachineByID(id int) (*Machine, error) {
 	}
 	return drv, nil
 }
+
+// GetMachinesByState get machines by state
+func GetMachinesByState(state int) ([]Machine, error) {
+	var ms []Machine
+	err := ormEngine.Table(&Machine{}).Where("state = ?", state).Asc("id").Find(&ms)
+	if err != nil {
+		return nil, errors.Wrapf(err, "query machines by state [%d] from db", state)
+	}
+	return ms, nil
+}
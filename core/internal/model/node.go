@@ -69,3 +69,29 @@ func DeleteNode(node *Node) error {
 	}
 	return nil
 }
+
+// UpdateNodeStateByUUID update state of node by uuid, count of updated nodes is returned
+func UpdateNodeStateByUUID(uuid string, state int) (int64, error) {
+	n, err := ormEngine.Cols("state").Where("uuid = ?", uuid).Update(&Node{State: state})
+	if err != nil {
+		return 0, errors.Wrapf(err, "update state of node [%s]", uuid)
+	}
+	return n, nil
+}
+
+// CountNodesByMachine count nodes on each machine, return machine id -> count
+func CountNodesByMachine() (map[int]int, error) {
+	var rows []struct {
+		MachineID int `xorm:"'machine_id'"`
+		Count     int `xorm:"'cnt'"`
+	}
+	err := ormEngine.Table(&Node{}).Select("machine_id, count(*) as cnt").GroupBy("machine_id").Find(&rows)
+	if err != nil {
+		return nil, errors.Wrap(err, "count nodes by machine")
+	}
+	var counts = make(map[int]int)
+	for _, r := range rows {
+		counts[r.MachineID] = r.Count
+	}
+	return counts, nil
+}
@@ -37,6 +37,7 @@ type Package struct {
 	ImageTag                  string    `xorm:"varchar(1024) 'image_tag'"`
 	ImageWorkDir              string    `xorm:"varchar(1024) 'image_work_dir'"`
 	ImageStartCommands        []string  `xorm:"varchar(2048) 'image_start_commands'"`
+	ImagePullPolicy           string    `xorm:"varchar(32) 'image_pull_policy'"`
 }
 
 // InsertPackage insert package to db
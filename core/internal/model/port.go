@@ -0,0 +1,90 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package model
+
+import (
+	"github.com/pkg/errors"
+	"time"
+	"xorm.io/xorm"
+)
+
+// kind of port reservation
+const (
+	PortKindContainer = "port"
+	PortKindHost      = "host_port"
+)
+
+// PortReservation port ledger of machine, one port of one kind can only be reserved by one app
+type PortReservation struct {
+	ID         int       `xorm:"int(11) pk autoincr 'id'"`
+	CreateTime time.Time `xorm:"datetime created 'create_time'"`
+	MachineID  int       `xorm:"int(11) unique(uq_machine_port) 'machine_id'"`
+	Kind       string    `xorm:"varchar(16) unique(uq_machine_port) 'kind'"`
+	Port       int       `xorm:"int(11) unique(uq_machine_port) 'port'"`
+	AppUUID    string    `xorm:"varchar(64) index 'app_uuid'"`
+}
+
+// InsertPortReservations insert port reservations in one transaction
+func InsertPortReservations(rs []*PortReservation) error {
+	_, err := ormEngine.Transaction(func(session *xorm.Session) (interface{}, error) {
+		for i := range rs {
+			_, err := session.Table(&PortReservation{}).InsertOne(rs[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "insert port reservations")
+	}
+	return nil
+}
+
+// GetPortReservations get port reservations of machine
+func GetPortReservations(machineID int) ([]PortReservation, error) {
+	var rs []PortReservation
+	err := ormEngine.Table(&PortReservation{}).Where("machine_id = ?", machineID).Find(&rs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "query port reservations of machine [%d] from db", machineID)
+	}
+	return rs, nil
+}
+
+// DeletePortReservations delete port reservations by id
+func DeletePortReservations(rs []*PortReservation) error {
+	var ids []int
+	for _, r := range rs {
+		ids = append(ids, r.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := ormEngine.In("id", ids).Delete(&PortReservation{})
+	if err != nil {
+		return errors.Wrapf(err, "delete port reservations [%v]", ids)
+	}
+	return nil
+}
+
+// DeletePortReservationsByApp delete all port reservations of app
+func DeletePortReservationsByApp(appUUID string) error {
+	_, err := ormEngine.Where("app_uuid = ?", appUUID).Delete(&PortReservation{})
+	if err != nil {
+		return errors.Wrapf(err, "delete port reservations of app [%s]", appUUID)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package model
+
+import (
+	"github.com/pkg/errors"
+	"time"
+)
+
+// Registry credential of image registry in db, one record per registry server
+type Registry struct {
+	ID         int       `xorm:"int(11) pk autoincr 'id'"`
+	CreateTime time.Time `xorm:"datetime created 'create_time'"`
+	UpdateTime time.Time `xorm:"datetime updated 'update_time'"`
+	DeleteTime time.Time `xorm:"datetime deleted 'delete_time'"`
+	// Server host of registry, like 'harbor.example.com' or 'harbor.example.com:5000'
+	Server   string `xorm:"varchar(512) 'server'"`
+	Username string `xorm:"varchar(256) 'username'"`
+	Password string `xorm:"varchar(1024) 'password'" json:"-"`
+	Email    string `xorm:"varchar(256) 'email'"`
+}
+
+// InsertRegistry insert registry to db
+func InsertRegistry(registry *Registry) error {
+	_, err := ormEngine.Insert(registry)
+	if err != nil {
+		return errors.Wrap(err, "registry insert to db")
+	}
+	return nil
+}
+
+// UpdateRegistry update credential of registry
+func UpdateRegistry(registry *Registry) error {
+	_, err := ormEngine.ID(registry.ID).Cols("username", "password", "email").Update(registry)
+	if err != nil {
+		return errors.Wrapf(err, "registry [%d] update", registry.ID)
+	}
+	return nil
+}
+
+// DeleteRegistry delete registry
+func DeleteRegistry(id int) error {
+	_, err := ormEngine.Delete(&Registry{ID: id})
+	if err != nil {
+		return errors.Wrapf(err, "registry [%d] delete", id)
+	}
+	return nil
+}
+
+// GetRegistries get all registries
+func GetRegistries() ([]Registry, error) {
+	var rs []Registry
+	err := ormEngine.Table(&Registry{}).Find(&rs)
+	if err != nil {
+		return nil, errors.Wrap(err, "query registries from db")
+	}
+	return rs, nil
+}
+
+// GetRegistryByServer get registry by server, nil is returned if not exist
+func GetRegistryByServer(server string) (*Registry, error) {
+	var r = &Registry{}
+	_, err := ormEngine.Table(&Registry{}).Where("server = ?", server).Get(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "query registry [%s] from db", server)
+	}
+	if r.ID != 0 {
+		return r, nil
+	}
+	return nil, nil
+}
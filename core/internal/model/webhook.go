@@ -0,0 +1,105 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package model
+
+import (
+	"github.com/pkg/errors"
+	"time"
+)
+
+// Webhook webhook subscription definition in db
+type Webhook struct {
+	ID         int       `xorm:"int(11) pk autoincr 'id'"`
+	CreateTime time.Time `xorm:"datetime created 'create_time'"`
+	UpdateTime time.Time `xorm:"datetime updated 'update_time'"`
+	DeleteTime time.Time `xorm:"datetime deleted 'delete_time'"`
+	URL        string    `xorm:"varchar(1024) 'url'"`
+	Secret     string    `xorm:"varchar(256) 'secret'" json:"-"`
+	// Events event type filter, empty means all events
+	Events  []string `xorm:"text 'events'"`
+	Enabled bool     `xorm:"tinyint(1) DEFAULT 1 'enabled'"`
+}
+
+// WebhookDelivery delivery log of webhook, one record per attempt
+type WebhookDelivery struct {
+	ID         int       `xorm:"int(11) pk autoincr 'id'"`
+	CreateTime time.Time `xorm:"datetime created 'create_time'"`
+	WebhookID  int       `xorm:"int(11) index 'webhook_id'"`
+	EventID    string    `xorm:"varchar(64) 'event_id'"`
+	EventType  string    `xorm:"varchar(128) 'event_type'"`
+	Payload    string    `xorm:"text 'payload'"`
+	Attempt    int       `xorm:"int(8) 'attempt'"`
+	StatusCode int       `xorm:"int(8) 'status_code'"`
+	Result     string    `xorm:"varchar(16) 'result'"`
+	Message    string    `xorm:"text 'message'"`
+}
+
+// InsertWebhook insert webhook to db
+func InsertWebhook(webhook *Webhook) error {
+	_, err := ormEngine.Insert(webhook)
+	if err != nil {
+		return errors.Wrap(err, "webhook insert to db")
+	}
+	return nil
+}
+
+// DeleteWebhook delete webhook
+func DeleteWebhook(id int) error {
+	_, err := ormEngine.Delete(&Webhook{ID: id})
+	if err != nil {
+		return errors.Wrapf(err, "webhook [%d] delete", id)
+	}
+	return nil
+}
+
+// GetWebhooks get all webhooks
+func GetWebhooks() ([]Webhook, error) {
+	var ws []Webhook
+	err := ormEngine.Table(&Webhook{}).Find(&ws)
+	if err != nil {
+		return nil, errors.Wrap(err, "query webhooks from db")
+	}
+	return ws, nil
+}
+
+// GetEnabledWebhooks get enabled webhooks
+func GetEnabledWebhooks() ([]Webhook, error) {
+	var ws []Webhook
+	err := ormEngine.Table(&Webhook{}).Where("enabled = ?", true).Find(&ws)
+	if err != nil {
+		return nil, errors.Wrap(err, "query enabled webhooks from db")
+	}
+	return ws, nil
+}
+
+// InsertWebhookDelivery insert webhook delivery to db
+func InsertWebhookDelivery(delivery *WebhookDelivery) error {
+	_, err := ormEngine.Insert(delivery)
+	if err != nil {
+		return errors.Wrap(err, "webhook delivery insert to db")
+	}
+	return nil
+}
+
+// GetWebhookDeliveries get latest deliveries of webhook
+func GetWebhookDeliveries(webhookID, limit int) ([]WebhookDelivery, error) {
+	var ds []WebhookDelivery
+	err := ormEngine.Table(&WebhookDelivery{}).Where("webhook_id = ?", webhookID).Desc("id").Limit(limit).Find(&ds)
+	if err != nil {
+		return nil, errors.Wrapf(err, "query deliveries of webhook [%d] from db", webhookID)
+	}
+	return ds, nil
+}
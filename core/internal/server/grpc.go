@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/zibuyu28/cmapp/common/log"
 	"github.com/zibuyu28/cmapp/core/internal/api_g"
+	"github.com/zibuyu28/cmapp/core/internal/server/mid"
 	"github.com/zibuyu28/cmapp/core/proto/ch_manager"
 	"github.com/zibuyu28/cmapp/core/proto/ma_manager"
 	"google.golang.org/grpc"
@@ -42,7 +43,7 @@ func grpcServerStart(ctx context.Context) {
 	if err != nil {
 		log.Fatalf(ctx, "failed to listen: %v", err)
 	}
-	grpcserver = grpc.NewServer()
+	grpcserver = grpc.NewServer(grpc.ChainUnaryInterceptor(mid.GrpcRequestID("api_g"), mid.GrpcAudit()))
 	ma_manager.RegisterMachineManageServer(grpcserver, &api_g.CoreMachineManager{})
 	ch_manager.RegisterChainManageServer(grpcserver, &api_g.CoreChainManager{})
 	log.Infof(ctx, "server listening at %v", lis.Addr())
@@ -32,8 +32,10 @@ var defaultHttpPort = 9008
 
 var httpserver = func() *gin.Engine {
 	engine := gin.New()
+	engine.Use(mid.RequestID("api_c"))
 	engine.Use(mid.GinLogger(false))
 	engine.Use(mid.RecoveryWithLogger(false))
+	engine.Use(mid.GinAudit())
 	return engine
 }()
 
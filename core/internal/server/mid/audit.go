@@ -0,0 +1,204 @@
+package mid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/audit"
+	"github.com/zibuyu28/cmapp/core/proto/ch_manager"
+	"github.com/zibuyu28/cmapp/core/proto/ma_manager"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// ActorHeader header and metadata key of actor
+const ActorHeader = "X-Actor"
+
+// auditEntities entity of http api group
+var auditEntities = map[string]string{
+	"/api/v1/md/":        "app",
+	"/api/v1/cw/":        "chain",
+	"/api/v1/mw/":        "machine",
+	"/api/v1/file/":      "file",
+	"/api/v1/package/":   "package",
+	"/api/v1/webhook/":   "webhook",
+	"/api/v1/placement/": "placement",
+	"/api/v1/registry/":  "registry",
+}
+
+// auditTargetKeys key of target id in the json body by entity
+var auditTargetKeys = map[string]string{
+	"app":      "app_uuid",
+	"chain":    "chain_id",
+	"machine":  "machine_id",
+	"registry": "server",
+}
+
+type auditWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *auditWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// GinAudit returns a gin.HandlerFunc (middleware) that record audit of every mutating request.
+// Use it after RequestID.
+func GinAudit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			c.Next()
+			return
+		}
+		var body []byte
+		if strings.HasPrefix(c.ContentType(), "application/json") && c.Request.Body != nil {
+			body, _ = ioutil.ReadAll(c.Request.Body)
+			c.Request.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+		}
+		w := &auditWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = w
+
+		c.Next()
+
+		a := &model.Audit{
+			Actor:    c.GetHeader(ActorHeader),
+			Protocol: audit.ProtocolHTTP,
+			Action:   fmt.Sprintf("%s %s", c.Request.Method, c.FullPath()),
+		}
+		if len(a.Actor) == 0 {
+			a.Actor = c.ClientIP()
+		}
+		for prefix, entity := range auditEntities {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				a.Entity = entity
+				break
+			}
+		}
+
+		var bm map[string]interface{}
+		if len(body) != 0 && json.Unmarshal(body, &bm) == nil {
+			a.Params = audit.Redact(body)
+			if key, ok := auditTargetKeys[a.Entity]; ok && bm[key] != nil {
+				a.TargetID = fmt.Sprintf("%v", bm[key])
+			}
+			// md exec use 'fnc', cw and mw exec use 'action'
+			for _, k := range []string{"fnc", "action"} {
+				if v, ok := bm[k]; ok {
+					a.Action = fmt.Sprintf("%s [%v]", a.Action, v)
+					break
+				}
+			}
+		} else {
+			ps := make(map[string]interface{})
+			for _, p := range c.Params {
+				ps[p.Key] = p.Value
+			}
+			if c.Request.MultipartForm != nil {
+				for k, v := range c.Request.MultipartForm.Value {
+					ps[k] = v
+				}
+				for k, fs := range c.Request.MultipartForm.File {
+					var names []string
+					for _, f := range fs {
+						names = append(names, f.Filename)
+					}
+					ps[k] = names
+				}
+			}
+			a.Params = audit.RedactValue(ps)
+		}
+		if len(a.TargetID) == 0 && len(c.Params) != 0 {
+			a.TargetID = c.Params[0].Value
+		}
+
+		var r struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(w.body.Bytes(), &r) == nil && r.Code != 0 {
+			if r.Code == http.StatusOK {
+				a.Result = audit.ResultSuccess
+			} else {
+				a.Result = audit.ResultFail
+				a.Message = r.Message
+			}
+		} else if c.Writer.Status() < http.StatusBadRequest {
+			a.Result = audit.ResultSuccess
+		} else {
+			a.Result = audit.ResultFail
+			a.Message = c.Errors.String()
+		}
+		audit.Record(c.Request.Context(), a)
+	}
+}
+
+// grpcUnaudited periodic reports of agents, they only refresh status and are not audited,
+// otherwise every machine adds audits forever
+var grpcUnaudited = map[string]struct{}{
+	"/MachineManage/ReportResource":  {},
+	"/MachineManage/ReportAppHealth": {},
+}
+
+// GrpcAudit returns a grpc.UnaryServerInterceptor that record audit of every mutating call,
+// periodic reports in grpcUnaudited are skipped. Use it after GrpcRequestID.
+func GrpcAudit() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if _, ok := grpcUnaudited[info.FullMethod]; ok {
+			return resp, err
+		}
+
+		a := &model.Audit{
+			Protocol: audit.ProtocolGRPC,
+			Action:   info.FullMethod,
+			Params:   audit.RedactValue(req),
+			Result:   audit.ResultSuccess,
+		}
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get(ActorHeader); len(v) != 0 {
+				a.Actor = v[0]
+			}
+		}
+		if p, ok := peer.FromContext(ctx); ok && len(a.Actor) == 0 {
+			a.Actor = p.Addr.String()
+		}
+		a.Entity, a.TargetID = grpcTarget(req)
+		if len(a.TargetID) == 0 || a.TargetID == "0" {
+			_, a.TargetID = grpcTarget(resp)
+		}
+		if err != nil {
+			a.Result = audit.ResultFail
+			a.Message = err.Error()
+		}
+		audit.Record(ctx, a)
+		return resp, err
+	}
+}
+
+// grpcTarget get entity and target id of core grpc request or response
+func grpcTarget(v interface{}) (string, string) {
+	switch t := v.(type) {
+	case *ma_manager.TypedMachine:
+		return "machine", fmt.Sprintf("%d", t.ID)
+	case *ch_manager.TypedChain:
+		return "chain", fmt.Sprintf("%d", t.ID)
+	case *ch_manager.TypedNodes:
+		if len(t.Nodes) != 0 {
+			return "chain", fmt.Sprintf("%d", t.Nodes[0].ChainID)
+		}
+		return "chain", ""
+	default:
+		return "", ""
+	}
+}
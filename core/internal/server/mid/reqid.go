@@ -0,0 +1,63 @@
+package mid
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zibuyu28/cmapp/common/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDHeader header and metadata key of request id
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID returns a gin.HandlerFunc (middleware) that set request id to the request context,
+// the request id is taken from header 'X-Request-ID' or generated. Use it before GinLogger.
+func RequestID(module string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid := c.GetHeader(RequestIDHeader)
+		if len(rid) == 0 {
+			rid = uuid.New().String()
+		}
+		c.Header(RequestIDHeader, rid)
+		ctx := log.WithModule(log.WithRequestID(c.Request.Context(), rid), module)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// GrpcRequestID returns a grpc.UnaryServerInterceptor that set request id, job id, machine uuid,
+// machine id and chain uuid in metadata to the context.
+func GrpcRequestID(module string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		rid := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get(log.FieldRequestID); len(v) != 0 {
+				rid = v[0]
+			}
+			if v := md.Get(log.FieldJobID); len(v) != 0 {
+				ctx = log.WithJobID(ctx, v[0])
+			}
+			if v := md.Get(log.FieldMachineUUID); len(v) != 0 {
+				ctx = log.WithMachineUUID(ctx, v[0])
+			}
+			if v := md.Get(log.FieldMachineID); len(v) != 0 {
+				ctx = log.WithMachineID(ctx, v[0])
+			}
+			if v := md.Get(log.FieldChainUUID); len(v) != 0 {
+				ctx = log.WithChainUUID(ctx, v[0])
+			}
+		}
+		if len(rid) == 0 {
+			rid = uuid.New().String()
+		}
+		ctx = log.WithModule(log.WithRequestID(ctx, rid), module)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(log.FieldRequestID, rid))
+		resp, err := handler(ctx, req)
+		if err != nil {
+			log.Errorf(ctx, "Manage err when handle grpc request [%s]. Err: [%v]", info.FullMethod, err)
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/core/internal/model"
+	"strings"
+	"time"
+)
+
+const (
+	ResultSuccess = "success"
+	ResultFail    = "fail"
+
+	ProtocolHTTP = "http"
+	ProtocolGRPC = "grpc"
+)
+
+// maxParamsLen max length of params stored
+const maxParamsLen = 4096
+
+const redacted = "******"
+
+// sensitiveKeys key contains one of these words will be redacted
+var sensitiveKeys = []string{"password", "passwd", "secret", "token", "kubeconfig", "certificate", "private", "credential"}
+
+// Record record audit, failure will only be logged, never break the request
+func Record(ctx context.Context, a *model.Audit) {
+	if len(a.RequestID) == 0 {
+		a.RequestID = log.RequestID(ctx)
+	}
+	if len(a.Params) > maxParamsLen {
+		a.Params = a.Params[:maxParamsLen]
+	}
+	err := model.InsertAudit(a)
+	if err != nil {
+		log.Errorf(ctx, "Manage err when record audit of action [%s]. Err: [%v]", a.Action, err)
+	}
+}
+
+// Redact redact secrets in json params, the params which is not json will be returned as it is
+func Redact(params []byte) string {
+	if len(params) == 0 {
+		return ""
+	}
+	var v interface{}
+	err := json.Unmarshal(params, &v)
+	if err != nil {
+		return string(params)
+	}
+	marshal, err := json.Marshal(redact(v))
+	if err != nil {
+		return string(params)
+	}
+	return string(marshal)
+}
+
+// RedactValue redact secrets in value, value will be marshal to json first
+func RedactValue(v interface{}) string {
+	marshal, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return Redact(marshal)
+}
+
+func redact(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		if sensitivePair(vv) {
+			for k := range vv {
+				if strings.EqualFold(k, "value") {
+					vv[k] = redacted
+				}
+			}
+		}
+		for k, iv := range vv {
+			if sensitive(k) {
+				vv[k] = redacted
+				continue
+			}
+			vv[k] = redact(iv)
+		}
+		return vv
+	case []interface{}:
+		for i := range vv {
+			vv[i] = redact(vv[i])
+		}
+		return vv
+	default:
+		return v
+	}
+}
+
+// sensitivePair whether v is a key value pair like env whose key is sensitive,
+// eg: {"key":"DB_PASSWORD","value":"p"}
+func sensitivePair(v map[string]interface{}) bool {
+	for k, iv := range v {
+		if !strings.EqualFold(k, "key") {
+			continue
+		}
+		if s, ok := iv.(string); ok && sensitive(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func sensitive(key string) bool {
+	k := strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// QueryReq audit query request
+type QueryReq struct {
+	Entity   string    `form:"entity"`
+	TargetID string    `form:"target_id"`
+	Start    time.Time `form:"start" time_format:"2006-01-02T15:04:05Z07:00"`
+	End      time.Time `form:"end" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit    int       `form:"limit"`
+}
+
+// Query query audits
+func Query(ctx context.Context, req *QueryReq) ([]model.Audit, error) {
+	if !req.Start.IsZero() && !req.End.IsZero() && req.End.Before(req.Start) {
+		return nil, errors.Errorf("end [%s] is before start [%s]", req.End, req.Start)
+	}
+	limit := req.Limit
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+	audits, err := model.QueryAudits(req.Entity, req.TargetID, req.Start, req.End, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "query audits")
+	}
+	log.Debugf(ctx, "Currently query [%d] audits of entity [%s]", len(audits), req.Entity)
+	return audits, nil
+}
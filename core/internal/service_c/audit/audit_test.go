@@ -0,0 +1,36 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package audit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	t.Run("test redact json", func(t *testing.T) {
+		r := Redact([]byte(`{"app_uuid":"app-1","param":{"HostPassword":"p","envs":[{"token":"t","name":"n"}]}}`))
+		assert.Equal(t, `{"app_uuid":"app-1","param":{"HostPassword":"******","envs":[{"name":"n","token":"******"}]}}`, r)
+	})
+	t.Run("test redact env pair", func(t *testing.T) {
+		r := Redact([]byte(`{"fnc":"EnvEx","param":{"key":"DB_PASSWORD","value":"p"},"envs":[{"Key":"PORT","Value":"80"}]}`))
+		assert.Equal(t, `{"envs":[{"Key":"PORT","Value":"80"}],"fnc":"EnvEx","param":{"key":"DB_PASSWORD","value":"******"}}`, r)
+	})
+	t.Run("test not json", func(t *testing.T) {
+		assert.Equal(t, "abc", Redact([]byte("abc")))
+		assert.Equal(t, "", Redact(nil))
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/zibuyu28/cmapp/common/cmd"
@@ -65,7 +66,9 @@ func Create(ctx context.Context, driverid int, param interface{}) error {
 	if err != nil {
 		return errors.Wrap(err, "marshal param")
 	}
-	err = CreateAction(ctx, DefaultDriverPath, drv, chainUuid(drv.Name), string(marshal))
+	cuid := chainUuid(drv.Name)
+	ctx = log.WithChainUUID(log.WithJobID(ctx, uuid.New().String()), cuid)
+	err = CreateAction(ctx, DefaultDriverPath, drv, cuid, string(marshal))
 	if err != nil {
 		return errors.Wrap(err, "create action")
 	}
@@ -94,8 +97,8 @@ func CreateAction(ctx context.Context, driverRootPath string, drv *model.Driver,
 
 	outCh := make(chan string, 10)
 	defer close(outCh)
-	command := fmt.Sprintf("%s create -u %s -p '%s' --driver-name=%s --driver-version=%s --driver-id=%d --core-grpc-addr=%s --core-http-addr=%s",
-		binaryPath, uuid, param, drv.Name, drv.Version, drv.ID, grpcAddr, httpAddr)
+	command := fmt.Sprintf("%s create -u %s -p '%s' --driver-name=%s --driver-version=%s --driver-id=%d --core-grpc-addr=%s --core-http-addr=%s --job-id=%s",
+		binaryPath, uuid, param, drv.Name, drv.Version, drv.ID, grpcAddr, httpAddr, log.FieldValue(ctx, log.FieldJobID))
 	newCmd := cmd.NewDefaultCMD(command, []string{}, cmd.WithTimeout(600), cmd.WithStream(outCh))
 
 	timeout, cancelFunc := context.WithTimeout(ctx, 600*time.Second)
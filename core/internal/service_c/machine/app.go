@@ -21,22 +21,31 @@ func wappstruct(a *ag.App) *worker0.App {
 			Memory: int32(a.LimitInfo.Memory),
 		},
 		HealthInfo: &worker0.App_Health{
-			Liveness: &worker0.App_Health_Basic{
-				MethodType: worker0.App_Health_Basic_Method(int(a.HealthInfo.Liveness.MethodType)),
-				Path:       a.HealthInfo.Liveness.Path,
-				Port:       int32(a.HealthInfo.Liveness.Port),
-			},
-			Readness: &worker0.App_Health_Basic{
-				MethodType: worker0.App_Health_Basic_Method(int(a.HealthInfo.Readness.MethodType)),
-				Path:       a.HealthInfo.Readness.Path,
-				Port:       int32(a.HealthInfo.Readness.Port),
-			},
+			Liveness: wbasic(a.HealthInfo.Liveness),
+			Readness: wbasic(a.HealthInfo.Readness),
 		},
 		LogInfo: &worker0.App_Log{
 			RealTimeFile: a.LogInfo.RealTimeFile,
 			FilePath:     a.LogInfo.FilePath,
 		},
 		Tags: []*worker0.App_Tag{},
+		RestartInfo: &worker0.App_Restart{
+			PolicyType: worker0.App_Restart_Policy(a.RestartInfo.Policy),
+			Backoff:    int32(a.RestartInfo.Backoff),
+		},
+		KeepData:     a.KeepData,
+		WorkloadType: worker0.App_Workload(a.Workload),
+		DryRun:       a.DryRun,
+		Replicas:     int32(a.Replicas),
+	}
+	for _, v := range a.Volumes {
+		ap.Volumes = append(ap.Volumes, &worker0.App_Volume{
+			Name:           v.Name,
+			Size:           v.Size,
+			AccessModeType: worker0.App_Volume_AccessMode(v.AccessMode),
+			StorageClass:   v.StorageClass,
+			MountPath:      v.MountPath,
+		})
 	}
 	if a.Tags != nil {
 		for _, tag := range a.Tags {
@@ -47,11 +56,15 @@ func wappstruct(a *ag.App) *worker0.App {
 		}
 	}
 	if a.FilePremise != nil {
-		for _, file := range ap.FilePremise {
+		for _, file := range a.FilePremise {
 			ap.FilePremise = append(ap.FilePremise, &worker0.App_File{
-				Name:        file.Name,
-				AcquireAddr: file.AcquireAddr,
-				Shell:       file.Shell,
+				Name:            file.Name,
+				AcquireAddr:     file.AcquireAddr,
+				Shell:           file.Shell,
+				Sensitive:       file.Sensitive,
+				CheckSum:        file.CheckSum,
+				ExtractTo:       file.ExtractTo,
+				StripComponents: int32(file.StripComponents),
 			})
 		}
 	}
@@ -84,7 +97,8 @@ func wappstruct(a *ag.App) *worker0.App {
 					Name:         net.PortInfo.Name,
 					ProtocolType: worker0.App_Network_PortInf_Protocol(net.PortInfo.ProtocolType),
 				},
-				RouteInfo: []*worker0.App_Network_RouteInf{},
+				RouteInfo:  []*worker0.App_Network_RouteInf{},
+				ExposeType: worker0.App_Network_Expose(net.ExposeType),
 			}
 
 			if net.RouteInfo != nil {
@@ -98,7 +112,7 @@ func wappstruct(a *ag.App) *worker0.App {
 					})
 				}
 			}
-			ap.Networks = append(ap.Networks)
+			ap.Networks = append(ap.Networks, &network)
 		}
 	}
 	return &ap
@@ -118,6 +132,13 @@ func tagset(ap *ag.App, ts []*worker0.App_Tag) {
 	}
 }
 
+func restartset(a *ag.App, r *worker0.App_Restart) {
+	if r != nil {
+		a.RestartInfo.Policy = ag.RestartPolicy(r.PolicyType)
+		a.RestartInfo.Backoff = int(r.Backoff)
+	}
+}
+
 func logset(a *ag.App, t *worker0.App_Log) {
 	if t != nil {
 		a.LogInfo.FilePath = t.FilePath
@@ -125,18 +146,43 @@ func logset(a *ag.App, t *worker0.App_Log) {
 	}
 }
 
+// wbasic convert probe of app to probe of worker0
+func wbasic(b ag.Basic) *worker0.App_Health_Basic {
+	return &worker0.App_Health_Basic{
+		ProbeType:           worker0.App_Health_Basic_Type(int(b.ProbeType)),
+		MethodType:          worker0.App_Health_Basic_Method(int(b.MethodType)),
+		Path:                b.Path,
+		Port:                int32(b.Port),
+		Command:             b.Command,
+		GRPCService:         b.GRPCService,
+		InitialDelaySeconds: int32(b.InitialDelaySeconds),
+		PeriodSeconds:       int32(b.PeriodSeconds),
+		TimeoutSeconds:      int32(b.TimeoutSeconds),
+		FailureThreshold:    int32(b.FailureThreshold),
+		SuccessThreshold:    int32(b.SuccessThreshold),
+	}
+}
+
+func basicset(b *ag.Basic, h *worker0.App_Health_Basic) {
+	if h != nil {
+		b.Port = int(h.Port)
+		b.Path = h.Path
+		b.MethodType = ag.Method(int(h.MethodType))
+		b.ProbeType = ag.ProbeType(int(h.ProbeType))
+		b.Command = h.Command
+		b.GRPCService = h.GRPCService
+		b.InitialDelaySeconds = int(h.InitialDelaySeconds)
+		b.PeriodSeconds = int(h.PeriodSeconds)
+		b.TimeoutSeconds = int(h.TimeoutSeconds)
+		b.FailureThreshold = int(h.FailureThreshold)
+		b.SuccessThreshold = int(h.SuccessThreshold)
+	}
+}
+
 func healthset(a *ag.App, h *worker0.App_Health) {
 	if h != nil {
-		if h.Liveness != nil {
-			a.HealthInfo.Liveness.Port = int(h.Liveness.Port)
-			a.HealthInfo.Liveness.Path = h.Liveness.Path
-			a.HealthInfo.Liveness.MethodType = ag.Method(int(h.Liveness.MethodType))
-		}
-		if h.Readness != nil {
-			a.HealthInfo.Readness.Port = int(h.Readness.Port)
-			a.HealthInfo.Readness.Path = h.Readness.Path
-			a.HealthInfo.Readness.MethodType = ag.Method(int(h.Readness.MethodType))
-		}
+		basicset(&a.HealthInfo.Liveness, h.Liveness)
+		basicset(&a.HealthInfo.Readness, h.Readness)
 	}
 }
 
@@ -154,14 +200,29 @@ func filepremiseset(ap *ag.App, fs []*worker0.App_File) {
 		}
 		for _, file := range fs {
 			ap.FilePremise = append(ap.FilePremise, ag.File{
-				Name:        file.Name,
-				AcquireAddr: file.AcquireAddr,
-				Shell:       file.Shell,
+				Name:            file.Name,
+				AcquireAddr:     file.AcquireAddr,
+				Shell:           file.Shell,
+				Sensitive:       file.Sensitive,
+				CheckSum:        file.CheckSum,
+				ExtractTo:       file.ExtractTo,
+				StripComponents: int(file.StripComponents),
 			})
 		}
 	}
 }
 
+func mainpset(a *ag.App, m *worker0.App_MainProcess) {
+	if m != nil {
+		a.MainP.CheckSum = m.CheckSum
+		a.MainP.Name = m.Name
+		a.MainP.Version = m.Version
+		a.MainP.Type = ag.PType(int(m.Type))
+		a.MainP.WorkDir = m.Workdir
+		a.MainP.StartCMD = m.StartCMD
+	}
+}
+
 func workspaceset(a *ag.App, w *worker0.App_WorkspaceInfo) {
 	if w != nil {
 		a.Workspace.Workspace = w.Workspace
@@ -183,6 +244,29 @@ func filemountset(ap *ag.App, fs []*worker0.App_FileMount) {
 	}
 }
 
+// volumeset set volumes to app, volume with the same name is replaced
+func volumeset(ap *ag.App, vs []*worker0.App_Volume) {
+	for _, v := range vs {
+		vol := ag.Volume{
+			Name:         v.Name,
+			Size:         v.Size,
+			AccessMode:   ag.AccessMode(v.AccessModeType),
+			StorageClass: v.StorageClass,
+			MountPath:    v.MountPath,
+		}
+		replaced := false
+		for i := range ap.Volumes {
+			if ap.Volumes[i].Name == v.Name {
+				ap.Volumes[i] = vol
+				replaced = true
+			}
+		}
+		if !replaced {
+			ap.Volumes = append(ap.Volumes, vol)
+		}
+	}
+}
+
 func envset(ap *ag.App, es []*worker0.App_EnvVar) {
 	if es != nil {
 		if ap.EnvironmentVars == nil {
@@ -220,6 +304,7 @@ func networkset(ap *ag.App, ns []*worker0.App_Network) {
 					RouteType ag.Route `json:"route_type"`
 					Router    string   `json:"router"`
 				}{},
+				ExposeType: ag.Expose(net.ExposeType),
 			}
 			if net.RouteInfo != nil {
 				for _, inf := range net.RouteInfo {
@@ -229,7 +314,7 @@ func networkset(ap *ag.App, ns []*worker0.App_Network) {
 					}{RouteType: ag.Route(int(inf.RouteType)), Router: inf.Router})
 				}
 			}
-			ap.Networks = append(ap.Networks)
+			ap.Networks = append(ap.Networks, network)
 		}
 	}
 }
@@ -255,16 +340,22 @@ func appstruct(a *worker0.App) *ag.App {
 			Liveness: ag.Basic{},
 			Readness: ag.Basic{},
 		},
-		LogInfo: ag.Log{},
-		Tags:    []ag.Tag{},
+		LogInfo:  ag.Log{},
+		Tags:     []ag.Tag{},
+		KeepData: a.KeepData,
+		Workload: ag.Workload(a.WorkloadType),
+		Replicas: int(a.Replicas),
 	}
+	mainpset(&ap, a.MainP)
 	tagset(&ap, a.Tags)
 	logset(&ap, a.LogInfo)
+	restartset(&ap, a.RestartInfo)
 	healthset(&ap, a.HealthInfo)
 	limitset(&ap, a.LimitInfo)
 	filepremiseset(&ap, a.FilePremise)
 	workspaceset(&ap, a.Workspace)
 	filemountset(&ap, a.FileMounts)
+	volumeset(&ap, a.Volumes)
 	envset(&ap, a.EnvironmentVars)
 	networkset(&ap, a.Networks)
 	return &ap
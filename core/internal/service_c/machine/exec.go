@@ -0,0 +1,105 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+)
+
+// ExecInApp exec command in app and wait for its result
+func (R *RMD) ExecInApp(ctx context.Context, appUUID string, in *ag.ExecReq) (*ag.ExecRes, error) {
+	if len(appUUID) == 0 || in == nil {
+		return nil, errors.New("app uuid is nil, please check")
+	}
+	load, ok := RMDIns.appConnRepo.Load(appUUID)
+	if !ok {
+		return nil, errors.Errorf("can not found app by uuid [%s]", appUUID)
+	}
+	ins := load.(*clientIns)
+	log.Infof(ctx, "app exec command [%v]", in.Command)
+	res, err := ins.rpcClient.ExecInApp(contextBuild(ctx, appUUID), &worker0.ExecReq{
+		Command: in.Command,
+		Envs:    in.Envs,
+		Timeout: int32(in.Timeout),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "rpc request exec in app")
+	}
+	return &ag.ExecRes{Stdout: res.Stdout, Stderr: res.Stderr, ExitCode: int(res.ExitCode)}, nil
+}
+
+// ExecInAppTTY exec command in app with tty, inputs are sent until input closed or command exit,
+// output is called for every output of tty
+func (R *RMD) ExecInAppTTY(ctx context.Context, appUUID string, start *ag.ExecReq, input <-chan *ag.TTYInput, output func([]byte) error) (*ag.TTYExit, error) {
+	if len(appUUID) == 0 || start == nil {
+		return nil, errors.New("app uuid is nil, please check")
+	}
+	load, ok := RMDIns.appConnRepo.Load(appUUID)
+	if !ok {
+		return nil, errors.Errorf("can not found app by uuid [%s]", appUUID)
+	}
+	ins := load.(*clientIns)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := ins.rpcClient.ExecInAppTTY(contextBuild(ctx, appUUID))
+	if err != nil {
+		return nil, errors.Wrap(err, "rpc request exec in app with tty")
+	}
+	log.Infof(ctx, "app exec command [%v] with tty", start.Command)
+	err = stream.Send(&worker0.ExecInput{Start: &worker0.ExecReq{Command: start.Command, Envs: start.Envs}})
+	if err != nil {
+		return nil, errors.Wrap(err, "send start input")
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case in, ok := <-input:
+				if !ok {
+					_ = stream.CloseSend()
+					return
+				}
+				ei := &worker0.ExecInput{Stdin: []byte(in.Stdin)}
+				if in.Resize != nil {
+					ei.Resize = &worker0.ExecInput_Size{Width: in.Resize.Width, Height: in.Resize.Height}
+				}
+				if stream.Send(ei) != nil {
+					return
+				}
+			}
+		}
+	}()
+	for {
+		out, err := stream.Recv()
+		if err != nil {
+			return nil, errors.Wrap(err, "receive output")
+		}
+		if len(out.Stdout) != 0 {
+			if err = output(out.Stdout); err != nil {
+				return nil, errors.Wrap(err, "write output")
+			}
+		}
+		if out.Exited {
+			return &ag.TTYExit{ExitCode: int(out.ExitCode), Message: out.Message}, nil
+		}
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"google.golang.org/grpc"
+	"testing"
+)
+
+type fakeExecWorker struct {
+	worker0.Worker0Client
+	stream *fakeTTYStream
+}
+
+func (f *fakeExecWorker) ExecInApp(ctx context.Context, in *worker0.ExecReq, opts ...grpc.CallOption) (*worker0.ExecRes, error) {
+	return &worker0.ExecRes{Stdout: in.Command[0], ExitCode: in.Timeout}, nil
+}
+
+func (f *fakeExecWorker) ExecInAppTTY(ctx context.Context, opts ...grpc.CallOption) (worker0.Worker0_ExecInAppTTYClient, error) {
+	return f.stream, nil
+}
+
+// fakeTTYStream echo the stdin, and exit once send closed
+type fakeTTYStream struct {
+	grpc.ClientStream
+	start  *worker0.ExecReq
+	resize *worker0.ExecInput_Size
+	out    chan *worker0.ExecOutput
+}
+
+func (s *fakeTTYStream) Send(in *worker0.ExecInput) error {
+	if in.Start != nil {
+		s.start = in.Start
+		return nil
+	}
+	if in.Resize != nil {
+		s.resize = in.Resize
+	}
+	s.out <- &worker0.ExecOutput{Stdout: in.Stdin}
+	return nil
+}
+
+func (s *fakeTTYStream) CloseSend() error {
+	s.out <- &worker0.ExecOutput{Exited: true, ExitCode: 3, Message: "exit"}
+	return nil
+}
+
+func (s *fakeTTYStream) Recv() (*worker0.ExecOutput, error) {
+	return <-s.out, nil
+}
+
+func TestRMD_ExecInApp(t *testing.T) {
+	RMDIns.appConnRepo.Store("app-exec", &clientIns{rpcClient: &fakeExecWorker{}})
+	defer RMDIns.appConnRepo.Delete("app-exec")
+
+	res, err := RMDIns.ExecInApp(context.Background(), "app-exec", &ag.ExecReq{Command: []string{"ls"}, Timeout: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, &ag.ExecRes{Stdout: "ls", ExitCode: 2}, res)
+
+	_, err = RMDIns.ExecInApp(context.Background(), "app-none", &ag.ExecReq{})
+	assert.NotNil(t, err)
+}
+
+func TestRMD_ExecInAppTTY(t *testing.T) {
+	s := &fakeTTYStream{out: make(chan *worker0.ExecOutput, 4)}
+	RMDIns.appConnRepo.Store("app-tty", &clientIns{rpcClient: &fakeExecWorker{stream: s}})
+	defer RMDIns.appConnRepo.Delete("app-tty")
+
+	input := make(chan *ag.TTYInput, 2)
+	input <- &ag.TTYInput{Stdin: "a", Resize: &ag.TTYSize{Width: 80, Height: 24}}
+	input <- &ag.TTYInput{Stdin: "b"}
+	close(input)
+	var got string
+	exit, err := RMDIns.ExecInAppTTY(context.Background(), "app-tty", &ag.ExecReq{Command: []string{"sh"}}, input, func(b []byte) error {
+		got += string(b)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &ag.TTYExit{ExitCode: 3, Message: "exit"}, exit)
+	assert.Equal(t, "ab", got)
+	assert.Equal(t, []string{"sh"}, s.start.Command)
+	assert.Equal(t, uint32(80), s.resize.Width)
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"io"
+)
+
+// fileChunkSize max size of data in one chunk sent to agent
+const fileChunkSize = 64 << 10
+
+// ListFiles list files in workspace of app
+func (R *RMD) ListFiles(ctx context.Context, appUUID string, in *ag.FileReq) ([]ag.FileInfo, error) {
+	ins, err := appConn(appUUID)
+	if err != nil {
+		return nil, err
+	}
+	res, err := ins.rpcClient.ListFiles(contextBuild(ctx, appUUID), &worker0.FileReq{Path: in.Path, Recursive: in.Recursive})
+	if err != nil {
+		return nil, errors.Wrap(err, "rpc request list files")
+	}
+	files := make([]ag.FileInfo, 0, len(res.Files))
+	for _, f := range res.Files {
+		files = append(files, *fileinfostruct(f))
+	}
+	return files, nil
+}
+
+// ReadFile read content of file in workspace of app to w
+func (R *RMD) ReadFile(ctx context.Context, appUUID string, in *ag.FileReq, w io.Writer) error {
+	ins, err := appConn(appUUID)
+	if err != nil {
+		return err
+	}
+	stream, err := ins.rpcClient.ReadFile(contextBuild(ctx, appUUID), &worker0.FileReq{Path: in.Path})
+	if err != nil {
+		return errors.Wrap(err, "rpc request read file")
+	}
+	return recvChunks(stream, w)
+}
+
+// WriteFile write content to file in workspace of app
+func (R *RMD) WriteFile(ctx context.Context, appUUID string, in *ag.WriteFileReq) (*ag.FileInfo, error) {
+	if len(in.Path) == 0 {
+		return nil, errors.New("path of file is nil")
+	}
+	ins, err := appConn(appUUID)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := ins.rpcClient.WriteFile(contextBuild(ctx, appUUID))
+	if err != nil {
+		return nil, errors.Wrap(err, "rpc request write file")
+	}
+	req := &worker0.WriteFileReq{Path: in.Path, Mode: in.Mode}
+	content := in.Content
+	for {
+		n := len(content)
+		if n > fileChunkSize {
+			n = fileChunkSize
+		}
+		req.Data = content[:n]
+		content = content[n:]
+		if err = stream.Send(req); err != nil {
+			return nil, errors.Wrap(err, "send file chunk")
+		}
+		if len(content) == 0 {
+			break
+		}
+		req = &worker0.WriteFileReq{}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, errors.Wrap(err, "write file")
+	}
+	log.Infof(ctx, "app write file [%s], size [%d]", res.Path, res.Size)
+	return fileinfostruct(res), nil
+}
+
+// DeleteFile delete file in workspace of app
+func (R *RMD) DeleteFile(ctx context.Context, appUUID string, in *ag.FileReq) error {
+	ins, err := appConn(appUUID)
+	if err != nil {
+		return err
+	}
+	_, err = ins.rpcClient.DeleteFile(contextBuild(ctx, appUUID), &worker0.FileReq{Path: in.Path, Recursive: in.Recursive})
+	if err != nil {
+		return errors.Wrap(err, "rpc request delete file")
+	}
+	log.Infof(ctx, "app delete file [%s]", in.Path)
+	return nil
+}
+
+// ArchiveDir archive dir in workspace of app, tar.gz content is written to w
+func (R *RMD) ArchiveDir(ctx context.Context, appUUID string, in *ag.FileReq, w io.Writer) error {
+	ins, err := appConn(appUUID)
+	if err != nil {
+		return err
+	}
+	stream, err := ins.rpcClient.ArchiveDir(contextBuild(ctx, appUUID), &worker0.FileReq{Path: in.Path})
+	if err != nil {
+		return errors.Wrap(err, "rpc request archive dir")
+	}
+	return recvChunks(stream, w)
+}
+
+// appConn agent connection of app
+func appConn(appUUID string) (*clientIns, error) {
+	if len(appUUID) == 0 {
+		return nil, errors.New("app uuid is nil, please check")
+	}
+	load, ok := RMDIns.appConnRepo.Load(appUUID)
+	if !ok {
+		return nil, errors.Errorf("can not found app by uuid [%s]", appUUID)
+	}
+	return load.(*clientIns), nil
+}
+
+type chunkStream interface {
+	Recv() (*worker0.FileChunk, error)
+}
+
+// recvChunks write received chunks to w until stream end
+func recvChunks(stream chunkStream, w io.Writer) error {
+	for {
+		c, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "receive file chunk")
+		}
+		if _, err = w.Write(c.Data); err != nil {
+			return errors.Wrap(err, "write file chunk")
+		}
+	}
+}
+
+func fileinfostruct(f *worker0.FileInfo) *ag.FileInfo {
+	return &ag.FileInfo{Path: f.Path, Size: f.Size, Mode: f.Mode, ModTime: f.ModTime, IsDir: f.IsDir}
+}
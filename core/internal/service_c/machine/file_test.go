@@ -0,0 +1,100 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package machine
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"google.golang.org/grpc"
+	"io"
+	"testing"
+)
+
+// fakeFileWorker keep written file in memory
+type fakeFileWorker struct {
+	worker0.Worker0Client
+	path    string
+	content []byte
+	sends   int
+}
+
+func (f *fakeFileWorker) WriteFile(ctx context.Context, opts ...grpc.CallOption) (worker0.Worker0_WriteFileClient, error) {
+	return &fakeWriteStream{f: f}, nil
+}
+
+func (f *fakeFileWorker) ReadFile(ctx context.Context, in *worker0.FileReq, opts ...grpc.CallOption) (worker0.Worker0_ReadFileClient, error) {
+	return &fakeChunkStream{data: f.content}, nil
+}
+
+type fakeWriteStream struct {
+	grpc.ClientStream
+	f *fakeFileWorker
+}
+
+func (s *fakeWriteStream) Send(r *worker0.WriteFileReq) error {
+	if s.f.sends == 0 {
+		s.f.path = r.Path
+	}
+	s.f.sends++
+	s.f.content = append(s.f.content, r.Data...)
+	return nil
+}
+
+func (s *fakeWriteStream) CloseAndRecv() (*worker0.FileInfo, error) {
+	return &worker0.FileInfo{Path: s.f.path, Size: int64(len(s.f.content))}, nil
+}
+
+// fakeChunkStream return data in two chunks
+type fakeChunkStream struct {
+	grpc.ClientStream
+	data []byte
+}
+
+func (s *fakeChunkStream) Recv() (*worker0.FileChunk, error) {
+	if len(s.data) == 0 {
+		return nil, io.EOF
+	}
+	n := (len(s.data) + 1) / 2
+	c := s.data[:n]
+	s.data = s.data[n:]
+	return &worker0.FileChunk{Data: c}, nil
+}
+
+func TestRMD_WriteAndReadFile(t *testing.T) {
+	w := &fakeFileWorker{}
+	RMDIns.appConnRepo.Store("app-file", &clientIns{rpcClient: w})
+	defer RMDIns.appConnRepo.Delete("app-file")
+
+	content := bytes.Repeat([]byte("a"), fileChunkSize*2+1)
+	info, err := RMDIns.WriteFile(context.Background(), "app-file", &ag.WriteFileReq{Path: "conf/a", Content: content})
+	assert.Nil(t, err)
+	assert.Equal(t, &ag.FileInfo{Path: "conf/a", Size: int64(len(content))}, info)
+	assert.Equal(t, 3, w.sends)
+
+	var buf bytes.Buffer
+	err = RMDIns.ReadFile(context.Background(), "app-file", &ag.FileReq{Path: "conf/a"}, &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, content, buf.Bytes())
+
+	_, err = RMDIns.WriteFile(context.Background(), "app-file", &ag.WriteFileReq{})
+	assert.NotNil(t, err)
+	err = RMDIns.ReadFile(context.Background(), "app-none", &ag.FileReq{}, &buf)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+)
+
+// GetApp get app with runtime status from agent
+func (R *RMD) GetApp(ctx context.Context, appUUID string) (*ag.AppStatus, error) {
+	if len(appUUID) == 0 {
+		return nil, errors.New("app uuid is nil, please check")
+	}
+	load, ok := RMDIns.appConnRepo.Load(appUUID)
+	if !ok {
+		return nil, errors.Errorf("can not found app by uuid [%s]", appUUID)
+	}
+	ins := load.(*clientIns)
+	res, err := ins.rpcClient.GetApp(contextBuild(ctx, appUUID), &worker0.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, "rpc request get app")
+	}
+	return appstatusstruct(res), nil
+}
+
+// RenderApp render manifests of app by agent, nothing is applied to the cluster
+func (R *RMD) RenderApp(ctx context.Context, appUUID string) ([]ag.Manifest, error) {
+	if len(appUUID) == 0 {
+		return nil, errors.New("app uuid is nil, please check")
+	}
+	load, ok := RMDIns.appConnRepo.Load(appUUID)
+	if !ok {
+		return nil, errors.Errorf("can not found app by uuid [%s]", appUUID)
+	}
+	ins := load.(*clientIns)
+	res, err := ins.rpcClient.RenderApp(contextBuild(ctx, appUUID), &worker0.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, "rpc request render app")
+	}
+	mfs := make([]ag.Manifest, 0, len(res.Manifests))
+	for _, m := range res.Manifests {
+		mfs = append(mfs, ag.Manifest{Kind: m.Kind, Name: m.Name, Namespace: m.Namespace, Yaml: m.Yaml})
+	}
+	return mfs, nil
+}
+
+// ListApps list apps with runtime status on machine
+func (R *RMD) ListApps(ctx context.Context, in *ag.ListAppsReq) ([]ag.AppStatus, error) {
+	if in == nil || in.MachineID == 0 {
+		return nil, errors.New("machine id is nil")
+	}
+	machine, err := model.GetMachineByID(in.MachineID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get machine by id")
+	}
+	rpc, err := connAG(ctx, machine.AGGRPCAddr)
+	if err != nil {
+		return nil, errors.Wrap(err, "connect to machine agent")
+	}
+	req := &worker0.ListAppsReq{}
+	for _, t := range in.Tags {
+		req.Tags = append(req.Tags, &worker0.App_Tag{Key: t.Key, Value: t.Value})
+	}
+	res, err := rpc.ListApps(contextBuild(ctx, ""), req)
+	if err != nil {
+		return nil, errors.Wrap(err, "rpc request list apps")
+	}
+	sts := make([]ag.AppStatus, 0, len(res.Apps))
+	for _, a := range res.Apps {
+		sts = append(sts, *appstatusstruct(a))
+	}
+	log.Debugf(ctx, "Currently list [%d] apps on machine [%d]", len(sts), in.MachineID)
+	return sts, nil
+}
+
+func appstatusstruct(s *worker0.AppStatus) *ag.AppStatus {
+	st := &ag.AppStatus{
+		State:        ag.AppState(int(s.StateType)),
+		Pid:          int(s.Pid),
+		Pods:         s.Pods,
+		RestartCount: int(s.RestartCount),
+		Healthy:      s.Healthy,
+		Message:      s.Message,
+		ExitCode:     int(s.ExitCode),
+		OOMKills:     int(s.OOMKills),
+	}
+	if s.EnforcedLimit != nil {
+		st.EnforcedLimit = &ag.Limit{CPU: int(s.EnforcedLimit.CPU), Memory: int(s.EnforcedLimit.Memory)}
+	}
+	if s.App != nil {
+		st.App = *appstruct(s.App)
+	}
+	return st
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"io"
+)
+
+// StreamLogs stream logs of app from agent, send is called for every line until ctx done or stream end
+func (R *RMD) StreamLogs(ctx context.Context, appUUID string, in *ag.LogReq, send func(*ag.LogLine) error) error {
+	if len(appUUID) == 0 || in == nil {
+		return errors.New("app uuid is nil, please check")
+	}
+	load, ok := RMDIns.appConnRepo.Load(appUUID)
+	if !ok {
+		return errors.Errorf("can not found app by uuid [%s]", appUUID)
+	}
+	ins := load.(*clientIns)
+	req := &worker0.LogReq{Follow: in.Follow, Tail: int32(in.Tail)}
+	if !in.Since.IsZero() {
+		req.SinceTime = in.Since.Unix()
+	}
+	stream, err := ins.rpcClient.StreamLogs(contextBuild(ctx, appUUID), req)
+	if err != nil {
+		return errors.Wrap(err, "rpc request stream logs")
+	}
+	log.Debugf(ctx, "Currently start to stream logs of app, follow [%t]", in.Follow)
+	for {
+		l, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(err, "receive log line")
+		}
+		err = send(&ag.LogLine{Line: l.Line, Source: l.Source})
+		if err != nil {
+			return errors.Wrap(err, "send log line")
+		}
+	}
+}
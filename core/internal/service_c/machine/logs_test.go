@@ -0,0 +1,73 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"google.golang.org/grpc"
+	"io"
+	"testing"
+	"time"
+)
+
+type fakeLogWorker struct {
+	worker0.Worker0Client
+	req   *worker0.LogReq
+	lines []string
+}
+
+func (f *fakeLogWorker) StreamLogs(ctx context.Context, in *worker0.LogReq, opts ...grpc.CallOption) (worker0.Worker0_StreamLogsClient, error) {
+	f.req = in
+	return &fakeLogStream{lines: f.lines}, nil
+}
+
+type fakeLogStream struct {
+	grpc.ClientStream
+	lines []string
+}
+
+func (s *fakeLogStream) Recv() (*worker0.LogLine, error) {
+	if len(s.lines) == 0 {
+		return nil, io.EOF
+	}
+	l := s.lines[0]
+	s.lines = s.lines[1:]
+	return &worker0.LogLine{Line: l, Source: "pod-0"}, nil
+}
+
+func TestRMD_StreamLogs(t *testing.T) {
+	w := &fakeLogWorker{lines: []string{"a", "b"}}
+	RMDIns.appConnRepo.Store("app-logs", &clientIns{rpcClient: w})
+	defer RMDIns.appConnRepo.Delete("app-logs")
+
+	since := time.Unix(1622505600, 0)
+	var got []ag.LogLine
+	err := RMDIns.StreamLogs(context.Background(), "app-logs", &ag.LogReq{Tail: 10, Since: since}, func(l *ag.LogLine) error {
+		got = append(got, *l)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []ag.LogLine{{Line: "a", Source: "pod-0"}, {Line: "b", Source: "pod-0"}}, got)
+	assert.Equal(t, int32(10), w.req.Tail)
+	assert.Equal(t, since.Unix(), w.req.SinceTime)
+
+	err = RMDIns.StreamLogs(context.Background(), "app-none", &ag.LogReq{}, nil)
+	assert.NotNil(t, err)
+}
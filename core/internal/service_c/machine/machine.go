@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/zibuyu28/cmapp/common/cmd"
@@ -38,12 +39,13 @@ const (
 )
 
 const (
-	MachineEngineCoreGRPCPORT  = "MACHINE_ENGINE_CORE_GRPC_PORT"
-	MachineEngineCoreGRPCAddr  = "MACHINE_ENGINE_CORE_GRPC_ADDR"
-	MachineEngineCoreHttpAddr  = "MACHINE_ENGINE_CORE_HTTP_ADDR"
-	MachineEngineDriverID      = "MACHINE_ENGINE_DRIVER_ID"
-	MachineEngineDriverName    = "MACHINE_ENGINE_DRIVER_NAME"
-	MachineEngineDriverVersion = "MACHINE_ENGINE_DRIVER_VERSION"
+	MachineEngineCoreGRPCPORT   = "MACHINE_ENGINE_CORE_GRPC_PORT"
+	MachineEngineCoreGRPCAddr   = "MACHINE_ENGINE_CORE_GRPC_ADDR"
+	MachineEngineCoreHttpAddr   = "MACHINE_ENGINE_CORE_HTTP_ADDR"
+	MachineEngineCoreAgentToken = "MACHINE_ENGINE_CORE_AGENT_TOKEN"
+	MachineEngineDriverID       = "MACHINE_ENGINE_DRIVER_ID"
+	MachineEngineDriverName     = "MACHINE_ENGINE_DRIVER_NAME"
+	MachineEngineDriverVersion  = "MACHINE_ENGINE_DRIVER_VERSION"
 )
 
 // Create execute driver create command to initialization machine
@@ -59,7 +61,9 @@ func Create(ctx context.Context, driverid int, param interface{}) error {
 	if err != nil {
 		return errors.Wrap(err, "marshal param")
 	}
-	err = CreateAction(ctx, DefaultDriverPath, drv, machineUuid(drv.Name), string(marshal))
+	muid := machineUuid(drv.Name)
+	ctx = log.WithMachineUUID(log.WithJobID(ctx, uuid.New().String()), muid)
+	err = CreateAction(ctx, DefaultDriverPath, drv, muid, string(marshal))
 	if err != nil {
 		return errors.Wrap(err, "create action")
 	}
@@ -91,16 +95,17 @@ func CreateAction(ctx context.Context, driverRootPath string, drv *model.Driver,
 		return errors.Wrap(err, "get http grpc addr")
 	}
 
-	command := fmt.Sprintf("%s ma create -u %s -p '%s'", binaryPath, uuid, param)
+	command := fmt.Sprintf("%s ma create -u %s -p '%s' --job-id=%s", binaryPath, uuid, param, log.FieldValue(ctx, log.FieldJobID))
 	newCmd := cmd.NewDefaultCMD(command, []string{}, cmd.WithEnvs(map[string]string{
-		MachineEngineCoreHttpAddr:  httpAddr,
-		MachineEngineCoreGRPCAddr:  grpcAddr,
-		MachineEngineDriverName:    drv.Name,
-		MachineEngineDriverVersion: drv.Version,
-		MachineEngineDriverID:      strconv.Itoa(drv.ID),
-		"BASE_CORE_ADDR":           "",
-		"BASE_IMAGE_REPOSITORY":    "",
-		"BASE_IMAGE_STORE_PATH":    "",
+		MachineEngineCoreHttpAddr:   httpAddr,
+		MachineEngineCoreGRPCAddr:   grpcAddr,
+		MachineEngineCoreAgentToken: viper.GetString("http.agent_token"),
+		MachineEngineDriverName:     drv.Name,
+		MachineEngineDriverVersion:  drv.Version,
+		MachineEngineDriverID:       strconv.Itoa(drv.ID),
+		"BASE_CORE_ADDR":            "",
+		"BASE_IMAGE_REPOSITORY":     "",
+		"BASE_IMAGE_STORE_PATH":     "",
 	}), cmd.WithTimeout(600), cmd.WithStream(outCh))
 	go driverOutput(timeout, outCh)
 	_, err = newCmd.Run()
@@ -24,6 +24,7 @@ import (
 	"github.com/zibuyu28/cmapp/common/log"
 	"github.com/zibuyu28/cmapp/common/md5"
 	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/webhook"
 	"github.com/zibuyu28/cmapp/core/pkg/ag"
 	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
 	"google.golang.org/grpc"
@@ -40,6 +41,9 @@ type RMD struct {
 
 type clientIns struct {
 	rpcClient worker0.Worker0Client
+	machineID int
+	// sharedNetwork apps on machine share network of host, container ports are unique on it
+	sharedNetwork bool
 }
 
 var RMDIns = RMD{agConnRepo: sync.Map{}, appRepo: sync.Map{}, appConnRepo: sync.Map{}}
@@ -47,9 +51,13 @@ var RMDIns = RMD{agConnRepo: sync.Map{}, appRepo: sync.Map{}, appConnRepo: sync.
 var defaultTimeout = 3
 
 func contextBuild(ctx context.Context, appuid string) context.Context {
-	return metadata.NewOutgoingContext(ctx, metadata.New(map[string]string{
+	md := map[string]string{
 		"MA_UUID": appuid,
-	}))
+	}
+	if rid := log.RequestID(ctx); len(rid) != 0 {
+		md[log.FieldRequestID] = rid
+	}
+	return metadata.NewOutgoingContext(log.WithAppUUID(ctx, appuid), metadata.New(md))
 }
 
 func generateAPPUUID() string {
@@ -94,7 +102,7 @@ func (R *RMD) NewApp(ctx context.Context, in *ag.NewAppReq) (*ag.App, error) {
 	}
 
 	outctx := contextBuild(ctx, generateAPPUUID())
-	app, err := rpc.NewApp(outctx, &worker0.NewAppReq{Name: in.Name, Version: in.Version})
+	app, err := rpc.NewApp(outctx, &worker0.NewAppReq{Name: in.Name, Version: in.Version, WorkloadType: worker0.App_Workload(in.Workload)})
 	if err != nil {
 		return nil, errors.Wrap(err, "rpc request new app")
 	}
@@ -108,8 +116,9 @@ func (R *RMD) NewApp(ctx context.Context, in *ag.NewAppReq) (*ag.App, error) {
 	}
 	ags := appstruct(app)
 	RMDIns.appRepo.Store(app.UUID, ags)
-	RMDIns.appConnRepo.Store(app.UUID, &clientIns{rpcClient: rpc})
+	RMDIns.appConnRepo.Store(app.UUID, &clientIns{rpcClient: rpc, machineID: in.MachineID, sharedNetwork: sharedNetwork(machine.Tags)})
 	log.Debugf(outctx, "store app [%s] to repo", app.UUID)
+	webhook.Emit(outctx, webhook.AppCreated, ags)
 	return ags, nil
 }
 
@@ -128,7 +137,12 @@ func (R *RMD) StartApp(ctx context.Context, in *ag.App) error {
 	if err != nil {
 		return errors.Wrap(err, "rpc request start app")
 	}
+	if in.DryRun {
+		log.Infof(ctx, "dry run app success")
+		return nil
+	}
 	log.Infof(ctx, "start app success")
+	webhook.Emit(ctx, webhook.AppStarted, in)
 	return nil
 }
 
@@ -148,6 +162,7 @@ func (R *RMD) StopApp(ctx context.Context, in *ag.App) error {
 		return errors.Wrap(err, "rpc request stop app")
 	}
 	log.Infof(ctx, "stop app success")
+	webhook.Emit(ctx, webhook.AppStopped, in)
 	return nil
 }
 
@@ -166,7 +181,9 @@ func (R *RMD) DestroyApp(ctx context.Context, in *ag.App) error {
 	if err != nil {
 		return errors.Wrap(err, "rpc request destroy app")
 	}
+	releasePorts(ctx, in.UUID)
 	log.Infof(ctx, "destroy app success")
+	webhook.Emit(ctx, webhook.AppDestroyed, in)
 	return nil
 }
 
@@ -258,7 +275,7 @@ func (R *RMD) EnvEx(ctx context.Context, appUUID string, in *ag.EnvVar) error {
 	return nil
 }
 
-func (R *RMD) NetworkEx(ctx context.Context, appUUID string, in *ag.Network) error {
+func (R *RMD) NetworkEx(ctx context.Context, appUUID string, in *ag.Network) (err error) {
 	log.Infof(ctx, "app exec network config [%v]", *in)
 	if len(appUUID) == 0 || in == nil {
 		return errors.New("app uuid is nil, please check")
@@ -272,6 +289,19 @@ func (R *RMD) NetworkEx(ctx context.Context, appUUID string, in *ag.Network) err
 		return errors.Errorf("can not found app by uuid [%s]", appUUID)
 	}
 	ins := load.(*clientIns)
+	if !in.DryRun {
+		var release func()
+		release, err = reservePort(ctx, ins, appUUID, in)
+		if err != nil {
+			return errors.Wrap(err, "reserve port")
+		}
+		// reservation is kept only if network is configured on the agent
+		defer func() {
+			if err != nil {
+				release()
+			}
+		}()
+	}
 	outctx := contextBuild(ctx, appUUID)
 	net, err := ins.rpcClient.NetworkEx(outctx, &worker0.App_Network{
 		PortInfo: &worker0.App_Network_PortInf{
@@ -279,14 +309,16 @@ func (R *RMD) NetworkEx(ctx context.Context, appUUID string, in *ag.Network) err
 			Name:         in.PortInfo.Name,
 			ProtocolType: worker0.App_Network_PortInf_Protocol(in.PortInfo.ProtocolType),
 		},
-		RouteInfo: nil,
+		RouteInfo:  nil,
+		ExposeType: worker0.App_Network_Expose(in.ExposeType),
+		DryRun:     in.DryRun,
 	})
 	if err != nil {
 		return errors.Wrap(err, "rpc request config network")
 	}
 
 	value, tok := RMDIns.appRepo.Load(appUUID)
-	if tok {
+	if tok && !in.DryRun {
 		log.Infof(ctx, "set local app network")
 		app := value.(*ag.App)
 		networkset(app, []*worker0.App_Network{net})
@@ -332,9 +364,13 @@ func (R *RMD) FilePremiseEx(ctx context.Context, appUUID string, in *ag.File) er
 	ins := load.(*clientIns)
 	outctx := contextBuild(ctx, appUUID)
 	f, err := ins.rpcClient.FilePremiseEx(outctx, &worker0.App_File{
-		Name:        in.Name,
-		AcquireAddr: in.AcquireAddr,
-		Shell:       in.Shell,
+		Name:            in.Name,
+		AcquireAddr:     in.AcquireAddr,
+		Shell:           in.Shell,
+		Sensitive:       in.Sensitive,
+		CheckSum:        in.CheckSum,
+		ExtractTo:       in.ExtractTo,
+		StripComponents: int32(in.StripComponents),
 	})
 	if err != nil {
 		return errors.Wrap(err, "rpc request file premise")
@@ -360,6 +396,16 @@ func (R *RMD) LimitEx(ctx context.Context, appUUID string, in *ag.Limit) error {
 		return errors.Errorf("can not found app by uuid [%s]", appUUID)
 	}
 	ins := load.(*clientIns)
+	if ins.machineID != 0 {
+		m, err := model.GetMachineByID(ins.machineID)
+		if err != nil {
+			return errors.Wrap(err, "get machine of app")
+		}
+		err = overcommit(m, RMDIns.committedLimit(ins.machineID, appUUID), in)
+		if err != nil {
+			return errors.Wrap(err, "check machine resource")
+		}
+	}
 	outctx := contextBuild(ctx, appUUID)
 	l, err := ins.rpcClient.LimitEx(outctx, &worker0.App_Limit{
 		CPU:    int32(in.CPU),
@@ -391,16 +437,8 @@ func (R *RMD) HealthEx(ctx context.Context, appUUID string, in *ag.Health) error
 	ins := load.(*clientIns)
 	outctx := contextBuild(ctx, appUUID)
 	rh, err := ins.rpcClient.HealthEx(outctx, &worker0.App_Health{
-		Liveness: &worker0.App_Health_Basic{
-			MethodType: worker0.App_Health_Basic_Method(in.Liveness.MethodType),
-			Path:       in.Liveness.Path,
-			Port:       int32(in.Liveness.Port),
-		},
-		Readness: &worker0.App_Health_Basic{
-			MethodType: worker0.App_Health_Basic_Method(in.Readness.MethodType),
-			Path:       in.Readness.Path,
-			Port:       int32(in.Readness.Port),
-		},
+		Liveness: wbasic(in.Liveness),
+		Readness: wbasic(in.Readness),
 	})
 	if err != nil {
 		return errors.Wrap(err, "rpc request set health")
@@ -415,6 +453,69 @@ func (R *RMD) HealthEx(ctx context.Context, appUUID string, in *ag.Health) error
 	return nil
 }
 
+func (R *RMD) RestartEx(ctx context.Context, appUUID string, in *ag.Restart) error {
+	log.Infof(ctx, "app exec set restart [%v]", *in)
+	if len(appUUID) == 0 || in == nil {
+		return errors.New("app uuid is nil, please check")
+	}
+
+	load, ok := RMDIns.appConnRepo.Load(appUUID)
+	if !ok {
+		return errors.Errorf("can not found app by uuid [%s]", appUUID)
+	}
+	ins := load.(*clientIns)
+	outctx := contextBuild(ctx, appUUID)
+	re, err := ins.rpcClient.RestartEx(outctx, &worker0.App_Restart{
+		PolicyType: worker0.App_Restart_Policy(in.Policy),
+		Backoff:    int32(in.Backoff),
+	})
+	if err != nil {
+		return errors.Wrap(err, "rpc request set restart info")
+	}
+	value, tok := RMDIns.appRepo.Load(appUUID)
+	if tok {
+		log.Infof(ctx, "set local app restart info")
+		app := value.(*ag.App)
+		restartset(app, re)
+	}
+	log.Infof(ctx, "app exec set restart info success")
+	return nil
+}
+
+func (R *RMD) VolumeEx(ctx context.Context, appUUID string, in *ag.Volume) error {
+	log.Infof(ctx, "app exec set volume [%v]", *in)
+	if len(appUUID) == 0 || in == nil {
+		return errors.New("app uuid is nil, please check")
+	}
+
+	load, ok := RMDIns.appConnRepo.Load(appUUID)
+	if !ok {
+		return errors.Errorf("can not found app by uuid [%s]", appUUID)
+	}
+	ins := load.(*clientIns)
+	outctx := contextBuild(ctx, appUUID)
+	v, err := ins.rpcClient.VolumeEx(outctx, &worker0.App_Volume{
+		Name:           in.Name,
+		Size:           in.Size,
+		AccessModeType: worker0.App_Volume_AccessMode(in.AccessMode),
+		StorageClass:   in.StorageClass,
+		MountPath:      in.MountPath,
+	})
+	if err != nil {
+		return errors.Wrap(err, "rpc request set volume")
+	}
+	in.StorageClass = v.StorageClass
+	in.MountPath = v.MountPath
+	value, tok := RMDIns.appRepo.Load(appUUID)
+	if tok {
+		log.Infof(ctx, "set local app volume")
+		app := value.(*ag.App)
+		volumeset(app, []*worker0.App_Volume{v})
+	}
+	log.Infof(ctx, "app exec set volume success")
+	return nil
+}
+
 func (R *RMD) LogEx(ctx context.Context, appUUID string, in *ag.Log) error {
 	log.Infof(ctx, "app exec set log [%v]", *in)
 	if len(appUUID) == 0 || in == nil {
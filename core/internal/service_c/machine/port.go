@@ -0,0 +1,175 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"sync"
+)
+
+// portMu serialize reservation in core, unique index in ledger is the final guard
+var portMu sync.Mutex
+
+// podNetworkTag tag of machine whose apps run in their own network namespace, like pods on k8s.
+// Container ports of different apps never conflict on such machine, only host ports are unique
+const podNetworkTag = "k8s"
+
+// sharedNetwork whether apps on machine share network of host, like processes on vbox
+func sharedNetwork(tags []string) bool {
+	for _, t := range tags {
+		if t == podNetworkTag {
+			return false
+		}
+	}
+	return true
+}
+
+// ledger port reservations of one machine
+type ledger struct {
+	// msg conflict message, empty means no conflict
+	msg string
+	// owned whether the port is already reserved by the app
+	owned     bool
+	hostPorts []int32
+	// appHostPorts host ports already reserved by the app
+	appHostPorts map[int]struct{}
+}
+
+// ledgerCheck check port and host port in ledger of machine
+func ledgerCheck(machineID int, appUUID string, port, hostPort int, shared bool) (*ledger, error) {
+	rs, err := model.GetPortReservations(machineID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get port reservations")
+	}
+	return newLedger(rs, machineID, appUUID, port, hostPort, shared), nil
+}
+
+// newLedger check port and host port in reservations of machine. Container port is only
+// checked on machine with shared network
+func newLedger(rs []model.PortReservation, machineID int, appUUID string, port, hostPort int, shared bool) *ledger {
+	l := &ledger{appHostPorts: make(map[int]struct{})}
+	for _, r := range rs {
+		if r.Kind == model.PortKindContainer && !shared {
+			continue
+		}
+		if r.Kind == model.PortKindHost {
+			l.hostPorts = append(l.hostPorts, int32(r.Port))
+		}
+		if r.AppUUID == appUUID {
+			if r.Kind == model.PortKindContainer && r.Port == port {
+				l.owned = true
+			}
+			if r.Kind == model.PortKindHost {
+				l.appHostPorts[r.Port] = struct{}{}
+			}
+			continue
+		}
+		if len(l.msg) != 0 {
+			continue
+		}
+		if r.Kind == model.PortKindContainer && r.Port == port {
+			l.msg = fmt.Sprintf("port [%d] is reserved by app [%s] on machine [%d]", port, r.AppUUID, machineID)
+		}
+		if r.Kind == model.PortKindHost && hostPort != 0 && r.Port == hostPort {
+			l.msg = fmt.Sprintf("host port [%d] is reserved by app [%s] on machine [%d]", hostPort, r.AppUUID, machineID)
+		}
+	}
+	return l
+}
+
+// records reservations to record for port reserved by agent, container port is only
+// recorded on machine with shared network, and host port already owned by app is skipped
+func (l *ledger) records(machineID int, appUUID string, port, hostPort int, shared bool) []*model.PortReservation {
+	var rs []*model.PortReservation
+	if shared {
+		rs = append(rs, &model.PortReservation{MachineID: machineID, Kind: model.PortKindContainer, Port: port, AppUUID: appUUID})
+	}
+	// port which is not exposed on host has no host port
+	if _, ok := l.appHostPorts[hostPort]; hostPort != 0 && !ok {
+		rs = append(rs, &model.PortReservation{MachineID: machineID, Kind: model.PortKindHost, Port: hostPort, AppUUID: appUUID})
+	}
+	return rs
+}
+
+// CheckPort check port in ledger of core and on the agent
+func (R *RMD) CheckPort(ctx context.Context, appUUID string, in *ag.PortReq) (*ag.PortRes, error) {
+	if len(appUUID) == 0 || in == nil {
+		return nil, errors.New("app uuid is nil, please check")
+	}
+	load, ok := RMDIns.appConnRepo.Load(appUUID)
+	if !ok {
+		return nil, errors.Errorf("can not found app by uuid [%s]", appUUID)
+	}
+	ins := load.(*clientIns)
+	l, err := ledgerCheck(ins.machineID, appUUID, in.Port, in.HostPort, ins.sharedNetwork)
+	if err != nil {
+		return nil, errors.Wrap(err, "check port ledger")
+	}
+	if len(l.msg) != 0 {
+		return &ag.PortRes{Port: in.Port, HostPort: in.HostPort, Message: l.msg}, nil
+	}
+	res, err := ins.rpcClient.CheckPort(contextBuild(ctx, appUUID), &worker0.PortReq{
+		Port:         int32(in.Port),
+		HostPort:     int32(in.HostPort),
+		ProtocolType: worker0.App_Network_PortInf_Protocol(in.ProtocolType),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "rpc request check port")
+	}
+	return &ag.PortRes{Available: res.Available, Port: int(res.Port), HostPort: int(res.HostPort), Message: res.Message}, nil
+}
+
+// reservePort reserve port and host port for app in ledger and on the agent. The returned
+// release func drops the reservations made by this call, it is used when the port is not taken
+func reservePort(ctx context.Context, ins *clientIns, appUUID string, in *ag.Network) (func(), error) {
+	portMu.Lock()
+	defer portMu.Unlock()
+	l, err := ledgerCheck(ins.machineID, appUUID, in.PortInfo.Port, 0, ins.sharedNetwork)
+	if err != nil {
+		return nil, errors.Wrap(err, "check port ledger")
+	}
+	if len(l.msg) != 0 {
+		return nil, errors.New(l.msg)
+	}
+	if l.owned {
+		log.Debugf(ctx, "Currently port [%d] is already reserved by app", in.PortInfo.Port)
+		return func() {}, nil
+	}
+	res, err := ins.rpcClient.ReservePort(contextBuild(ctx, appUUID), &worker0.PortReq{
+		Port:             int32(in.PortInfo.Port),
+		ProtocolType:     worker0.App_Network_PortInf_Protocol(in.PortInfo.ProtocolType),
+		ExcludeHostPorts: l.hostPorts,
+		ExposeType:       worker0.App_Network_Expose(in.ExposeType),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "rpc request reserve port")
+	}
+	if !res.Available {
+		return nil, errors.Errorf("port [%d] is not available, message [%s]", in.PortInfo.Port, res.Message)
+	}
+	rs := l.records(ins.machineID, appUUID, int(res.Port), int(res.HostPort), ins.sharedNetwork)
+	err = model.InsertPortReservations(rs)
+	if err != nil {
+		return nil, errors.Wrap(err, "record port reservations")
+	}
+	log.Infof(ctx, "Currently reserve port [%d] host port [%d] for app on machine [%d]", res.Port, res.HostPort, ins.machineID)
+	return func() {
+		if err := model.DeletePortReservations(rs); err != nil {
+			log.Errorf(ctx, "Manage err when release port [%d] of app. Err: [%v]", res.Port, err)
+			return
+		}
+		log.Infof(ctx, "Currently release port [%d] host port [%d] for app on machine [%d]", res.Port, res.HostPort, ins.machineID)
+	}, nil
+}
+
+// releasePorts release all ports reserved by app
+func releasePorts(ctx context.Context, appUUID string) {
+	err := model.DeletePortReservationsByApp(appUUID)
+	if err != nil {
+		log.Errorf(ctx, "Manage err when release ports of app. Err: [%v]", err)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package machine
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/core/internal/model"
+	"testing"
+)
+
+func TestLedger(t *testing.T) {
+	rs := []model.PortReservation{
+		{MachineID: 1, Kind: model.PortKindContainer, Port: 5984, AppUUID: "peer1-db"},
+		{MachineID: 1, Kind: model.PortKindContainer, Port: 7051, AppUUID: "peer1"},
+		{MachineID: 1, Kind: model.PortKindHost, Port: 30051, AppUUID: "peer1"},
+	}
+	t.Run("test shared network", func(t *testing.T) {
+		l := newLedger(rs, 1, "peer2-db", 5984, 0, true)
+		assert.Contains(t, l.msg, "port [5984] is reserved by app [peer1-db]")
+		l = newLedger(rs, 1, "peer1", 7051, 0, true)
+		assert.Empty(t, l.msg)
+		assert.True(t, l.owned)
+		assert.Equal(t, []int32{30051}, l.hostPorts)
+
+		records := newLedger(rs, 1, "peer2", 7052, 0, true).records(1, "peer2", 7052, 30052, true)
+		assert.Len(t, records, 2)
+		assert.Equal(t, model.PortKindContainer, records[0].Kind)
+		assert.Equal(t, model.PortKindHost, records[1].Kind)
+	})
+	t.Run("test pod network", func(t *testing.T) {
+		l := newLedger(rs, 1, "peer2-db", 5984, 0, false)
+		assert.Empty(t, l.msg, "container port is only unique in pod")
+		assert.False(t, l.owned)
+		l = newLedger(rs, 1, "peer2", 7051, 30051, false)
+		assert.Contains(t, l.msg, "host port [30051] is reserved by app [peer1]")
+
+		assert.Empty(t, newLedger(rs, 1, "peer2-db", 5984, 0, false).records(1, "peer2-db", 5984, 0, false))
+		l = newLedger(rs, 1, "peer1", 7051, 0, false)
+		assert.Empty(t, l.records(1, "peer1", 7051, 30051, false), "host port is already reserved by app")
+		records := l.records(1, "peer1", 9443, 30443, false)
+		assert.Len(t, records, 1)
+		assert.Equal(t, model.PortKindHost, records[0].Kind)
+		assert.Equal(t, 30443, records[0].Port)
+	})
+	t.Run("test network of machine", func(t *testing.T) {
+		assert.False(t, sharedNetwork([]string{"k8s"}))
+		assert.True(t, sharedNetwork([]string{"virtualbox"}))
+	})
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package machine
+
+import (
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+)
+
+// committedLimit sum limits of other apps which are on the same machine
+func (R *RMD) committedLimit(machineID int, appUUID string) ag.Limit {
+	var committed ag.Limit
+	R.appConnRepo.Range(func(key, value interface{}) bool {
+		uid := key.(string)
+		if uid == appUUID || value.(*clientIns).machineID != machineID {
+			return true
+		}
+		if a, ok := R.appRepo.Load(uid); ok {
+			committed.CPU += a.(*ag.App).LimitInfo.CPU
+			committed.Memory += a.(*ag.App).LimitInfo.Memory
+		}
+		return true
+	})
+	return committed
+}
+
+// overcommit check whether limit would overcommit machine, the larger one of
+// used resource reported by agent and limits committed by other apps is taken.
+// check is skipped if machine never report its resource
+func overcommit(m *model.Machine, committed ag.Limit, in *ag.Limit) error {
+	if m.CPUAllocatable > 0 && in.CPU > 0 {
+		if free := m.CPUAllocatable - max(m.CPUUsed, committed.CPU); in.CPU > free {
+			return errors.Errorf("cpu limit [%d]m overcommit machine [%d], allocatable [%d]m, free [%d]m",
+				in.CPU, m.ID, m.CPUAllocatable, free)
+		}
+	}
+	if m.MemoryAllocatable > 0 && in.Memory > 0 {
+		if free := m.MemoryAllocatable - max(m.MemoryUsed, committed.Memory); in.Memory > free {
+			return errors.Errorf("memory limit [%d]MB overcommit machine [%d], allocatable [%d]MB, free [%d]MB",
+				in.Memory, m.ID, m.MemoryAllocatable, free)
+		}
+	}
+	return nil
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
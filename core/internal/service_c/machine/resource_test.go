@@ -0,0 +1,35 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package machine
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"testing"
+)
+
+func TestOvercommit(t *testing.T) {
+	m := &model.Machine{ID: 1, CPUAllocatable: 2000, MemoryAllocatable: 4096, CPUUsed: 500, MemoryUsed: 1024}
+
+	assert.Nil(t, overcommit(m, ag.Limit{}, &ag.Limit{CPU: 1500, Memory: 3072}))
+	assert.NotNil(t, overcommit(m, ag.Limit{}, &ag.Limit{CPU: 1600, Memory: 100}))
+	// committed limits larger than used
+	assert.NotNil(t, overcommit(m, ag.Limit{CPU: 1000, Memory: 3072}, &ag.Limit{CPU: 500, Memory: 1025}))
+	// machine not report resource
+	assert.Nil(t, overcommit(&model.Machine{ID: 2}, ag.Limit{}, &ag.Limit{CPU: 100000, Memory: 100000}))
+}
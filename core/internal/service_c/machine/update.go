@@ -0,0 +1,82 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/webhook"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+)
+
+// UpdateApp apply changed spec to app, including new package version
+func (R *RMD) UpdateApp(ctx context.Context, appUUID string, in *ag.UpdateAppReq) (*ag.App, error) {
+	log.Info(ctx, "update app")
+	if in == nil {
+		return nil, errors.New("update request is nil")
+	}
+	ins, err := appConn(appUUID)
+	if err != nil {
+		return nil, err
+	}
+	req := &worker0.UpdateAppReq{
+		Name:           in.Name,
+		Version:        in.Version,
+		UpdateStrategy: worker0.UpdateAppReq_Strategy(in.Strategy),
+	}
+	for _, e := range in.Envs {
+		req.Envs = append(req.Envs, &worker0.App_EnvVar{Key: e.Key, Value: e.Value})
+	}
+	for _, m := range in.FileMounts {
+		req.FileMounts = append(req.FileMounts, &worker0.App_FileMount{File: m.File, MountTo: m.MountTo, Volume: m.Volume})
+	}
+	res, err := ins.rpcClient.UpdateApp(contextBuild(ctx, appUUID), req)
+	if err != nil {
+		return nil, errors.Wrap(err, "rpc request update app")
+	}
+	app := appstruct(res)
+	log.Infof(ctx, "update app success")
+	webhook.Emit(ctx, webhook.AppUpdated, app)
+	return app, nil
+}
+
+// ScaleApp scale app to desired replicas, k8s only
+func (R *RMD) ScaleApp(ctx context.Context, appUUID string, in *ag.ScaleReq) (*ag.App, error) {
+	if in == nil {
+		return nil, errors.New("scale request is nil")
+	}
+	log.Infof(ctx, "scale app to [%d] replicas", in.Replicas)
+	ins, err := appConn(appUUID)
+	if err != nil {
+		return nil, err
+	}
+	res, err := ins.rpcClient.ScaleApp(contextBuild(ctx, appUUID), &worker0.ScaleReq{Replicas: int32(in.Replicas)})
+	if err != nil {
+		return nil, errors.Wrap(err, "rpc request scale app")
+	}
+	app := appstruct(res)
+	value, tok := RMDIns.appRepo.Load(appUUID)
+	if tok {
+		log.Infof(ctx, "set local app replicas")
+		value.(*ag.App).Replicas = app.Replicas
+	}
+	log.Infof(ctx, "scale app to [%d] replicas success", app.Replicas)
+	webhook.Emit(ctx, webhook.AppUpdated, app)
+	return app, nil
+}
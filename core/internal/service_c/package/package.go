@@ -102,6 +102,10 @@ func (p *PM) RegisterPackage(tar string) error {
 		}
 
 		if pkg.Image != nil {
+			err = validator.New().Struct(pkg.Image)
+			if err != nil {
+				return errors.Wrapf(err, "check image of package [%s]", pkg.Name)
+			}
 			fileName := filepath.Join(dir, pkg.Image.FileName)
 			_, err = os.Stat(fileName)
 			if err != nil {
@@ -113,6 +117,7 @@ func (p *PM) RegisterPackage(tar string) error {
 			mp.ImageTag = pkg.Version
 			mp.ImageWorkDir = pkg.Image.WorkDir
 			mp.ImageStartCommands = pkg.Image.StartCommands
+			mp.ImagePullPolicy = pkg.Image.PullPolicy
 		}
 		mps = append(mps, mp)
 	}
@@ -154,6 +159,8 @@ type Image struct {
 	FileName      string   `json:"file_name" validate:"required"`
 	WorkDir       string   `json:"work_dir" validate:"required"`
 	StartCommands []string `json:"start_commands" validate:"required"`
+	// PullPolicy pull policy of image, worker decides it if empty
+	PullPolicy string `json:"pull_policy" validate:"omitempty,oneof=Always IfNotPresent Never"`
 }
 
 func (p *PM) GetPackageInfo(name, version string) (*PackageInfo, error) {
@@ -185,11 +192,13 @@ func (p *PM) GetPackageInfo(name, version string) (*PackageInfo, error) {
 			Tag           string   `json:"tag" validate:"required"`
 			WorkDir       string   `json:"work_dir" validate:"required"`
 			StartCommands []string `json:"start_command" validate:"required"`
+			PullPolicy    string   `json:"pull_policy"`
 		}{
 			ImageName:     pkg.ImageFullName,
 			Tag:           pkg.ImageTag,
 			WorkDir:       pkg.ImageWorkDir,
 			StartCommands: pkg.ImageStartCommands,
+			PullPolicy:    pkg.ImagePullPolicy,
 		},
 		Binary: struct {
 			Download            string   `json:"download" validate:"required"`
@@ -214,6 +223,7 @@ type PackageInfo struct {
 		Tag           string   `json:"tag" validate:"required"`
 		WorkDir       string   `json:"work_dir" validate:"required"`
 		StartCommands []string `json:"start_command" validate:"required"`
+		PullPolicy    string   `json:"pull_policy"`
 	}
 	Binary struct {
 		Download            string   `json:"download" validate:"required"`
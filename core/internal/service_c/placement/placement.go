@@ -0,0 +1,173 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package placement
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"sort"
+)
+
+// machineStateNormal only normal machine can be scheduled, 1处理中，2正常，3异常
+const machineStateNormal = 2
+
+type candidate struct {
+	machine *model.Machine
+	tags    map[string]struct{}
+	// nodes count of nodes already on machine
+	nodes int
+	// capKnown whether machine report capacity, capacity check will be skipped if not
+	capKnown bool
+	freeCPU  int
+	freeMem  int
+	groups   map[string]struct{}
+}
+
+// Schedule pick machines for nodes, return node name -> machine id
+func Schedule(ctx context.Context, req *ag.PlacementReq) (map[string]int, error) {
+	ms, err := model.GetMachinesByState(machineStateNormal)
+	if err != nil {
+		return nil, errors.Wrap(err, "get normal machines")
+	}
+	counts, err := model.CountNodesByMachine()
+	if err != nil {
+		return nil, errors.Wrap(err, "count nodes of machines")
+	}
+	res, err := place(ms, counts, req.Nodes)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof(ctx, "Currently schedule [%d] nodes to machines [%v]", len(req.Nodes), res)
+	return res, nil
+}
+
+// place pinned nodes first, then the rest by memory desc to lower fragmentation
+func place(ms []model.Machine, counts map[int]int, nodes []ag.PlacementNode) (map[string]int, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("nodes to schedule is nil")
+	}
+	cs := make([]*candidate, 0, len(ms))
+	byID := make(map[int]*candidate)
+	for i := range ms {
+		c := newCandidate(&ms[i], counts[ms[i].ID])
+		cs = append(cs, c)
+		byID[ms[i].ID] = c
+	}
+
+	res := make(map[string]int)
+	var free []ag.PlacementNode
+	for _, n := range nodes {
+		if _, ok := res[n.Name]; ok {
+			return nil, errors.Errorf("duplicate node name [%s]", n.Name)
+		}
+		if n.MachineID == 0 {
+			res[n.Name] = 0
+			free = append(free, n)
+			continue
+		}
+		c, ok := byID[n.MachineID]
+		if !ok {
+			return nil, errors.Errorf("pinned machine [%d] of node [%s] is not found or not normal", n.MachineID, n.Name)
+		}
+		if len(n.AntiAffinity) != 0 {
+			if _, ok := c.groups[n.AntiAffinity]; ok {
+				return nil, errors.Errorf("pinned machine [%d] of node [%s] conflict with anti affinity group [%s]", n.MachineID, n.Name, n.AntiAffinity)
+			}
+		}
+		c.assign(n)
+		res[n.Name] = c.machine.ID
+	}
+
+	sort.SliceStable(free, func(i, j int) bool {
+		return free[i].Memory > free[j].Memory
+	})
+	for _, n := range free {
+		var best *candidate
+		for _, c := range cs {
+			if !c.fit(n) {
+				continue
+			}
+			if best == nil || c.better(best) {
+				best = c
+			}
+		}
+		if best == nil {
+			return nil, errors.Errorf("no machine fit node [%s], tags [%v], anti affinity [%s], cpu [%d], memory [%d]",
+				n.Name, n.Tags, n.AntiAffinity, n.CPU, n.Memory)
+		}
+		best.assign(n)
+		res[n.Name] = best.machine.ID
+	}
+	return res, nil
+}
+
+func newCandidate(m *model.Machine, nodes int) *candidate {
+	c := &candidate{
+		machine: m,
+		nodes:   nodes,
+		tags:    make(map[string]struct{}),
+		groups:  make(map[string]struct{}),
+	}
+	for _, t := range m.Tags {
+		c.tags[t] = struct{}{}
+	}
+	if m.CPUAllocatable > 0 && m.MemoryAllocatable > 0 {
+		c.capKnown = true
+		c.freeCPU = m.CPUAllocatable - m.CPUUsed
+		c.freeMem = m.MemoryAllocatable - m.MemoryUsed
+	}
+	return c
+}
+
+func (c *candidate) fit(n ag.PlacementNode) bool {
+	for _, t := range n.Tags {
+		if _, ok := c.tags[t]; !ok {
+			return false
+		}
+	}
+	if len(n.AntiAffinity) != 0 {
+		if _, ok := c.groups[n.AntiAffinity]; ok {
+			return false
+		}
+	}
+	if c.capKnown && (c.freeCPU < n.CPU || c.freeMem < n.Memory) {
+		return false
+	}
+	return true
+}
+
+// better spread first, then more free memory, then lower id
+func (c *candidate) better(o *candidate) bool {
+	if c.nodes != o.nodes {
+		return c.nodes < o.nodes
+	}
+	if c.capKnown && o.capKnown && c.freeMem != o.freeMem {
+		return c.freeMem > o.freeMem
+	}
+	return c.machine.ID < o.machine.ID
+}
+
+func (c *candidate) assign(n ag.PlacementNode) {
+	c.nodes++
+	c.freeCPU -= n.CPU
+	c.freeMem -= n.Memory
+	if len(n.AntiAffinity) != 0 {
+		c.groups[n.AntiAffinity] = struct{}{}
+	}
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package placement
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"testing"
+)
+
+func Test_place(t *testing.T) {
+	ms := []model.Machine{
+		{ID: 1, Tags: []string{"ssd"}},
+		{ID: 2, Tags: []string{"ssd"}, CPUAllocatable: 2000, MemoryAllocatable: 1024},
+		{ID: 3},
+	}
+	t.Run("test spread and anti affinity", func(t *testing.T) {
+		res, err := place(ms, map[int]int{3: 5}, []ag.PlacementNode{
+			{Name: "peer0", AntiAffinity: "org1"},
+			{Name: "peer1", AntiAffinity: "org1"},
+			{Name: "orderer0", MachineID: 3},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, res["orderer0"])
+		assert.NotEqual(t, res["peer0"], res["peer1"])
+		assert.NotEqual(t, 3, res["peer0"])
+		assert.NotEqual(t, 3, res["peer1"])
+	})
+	t.Run("test tags and capacity", func(t *testing.T) {
+		res, err := place(ms, nil, []ag.PlacementNode{
+			{Name: "peer0", Tags: []string{"ssd"}, Memory: 2048},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, res["peer0"])
+		_, err = place(ms, nil, []ag.PlacementNode{
+			{Name: "peer0", Tags: []string{"ssd"}, AntiAffinity: "org1"},
+			{Name: "peer1", Tags: []string{"ssd"}, AntiAffinity: "org1"},
+			{Name: "peer2", Tags: []string{"ssd"}, AntiAffinity: "org1"},
+		})
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package registry
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/core/internal/model"
+	"strings"
+)
+
+// DefaultServer registry of image which has no registry host
+const DefaultServer = "docker.io"
+
+// RegisterReq register registry credential request
+type RegisterReq struct {
+	Server   string `json:"server" binding:"required"`
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Email    string `json:"email"`
+}
+
+// Credential credential of registry for pulling image
+type Credential struct {
+	Server   string `json:"server"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email"`
+}
+
+// Register store credential of registry, credential of registered server is replaced
+func Register(ctx context.Context, req *RegisterReq) (*model.Registry, error) {
+	server := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(req.Server, "https://"), "http://"), "/")
+	if len(server) == 0 || strings.Contains(server, "/") {
+		return nil, errors.Errorf("server [%s] is not a registry host", req.Server)
+	}
+	r, err := model.GetRegistryByServer(server)
+	if err != nil {
+		return nil, errors.Wrap(err, "get registry")
+	}
+	if r != nil {
+		r.Username, r.Password, r.Email = req.Username, req.Password, req.Email
+		err = model.UpdateRegistry(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "update registry")
+		}
+		log.Infof(ctx, "Currently update credential of registry [%s]", server)
+		return r, nil
+	}
+	r = &model.Registry{Server: server, Username: req.Username, Password: req.Password, Email: req.Email}
+	err = model.InsertRegistry(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "insert registry")
+	}
+	log.Infof(ctx, "Currently register credential of registry [%s]", server)
+	return r, nil
+}
+
+// Remove remove credential of registry
+func Remove(ctx context.Context, id int) error {
+	err := model.DeleteRegistry(id)
+	if err != nil {
+		return errors.Wrap(err, "delete registry")
+	}
+	return nil
+}
+
+// List list registries, password is not included
+func List(ctx context.Context) ([]model.Registry, error) {
+	return model.GetRegistries()
+}
+
+// ImageCredential credential of registry which image belongs to, nil is returned if the
+// registry is not registered
+func ImageCredential(ctx context.Context, image string) (*Credential, error) {
+	server := Server(image)
+	r, err := model.GetRegistryByServer(server)
+	if err != nil {
+		return nil, errors.Wrap(err, "get registry")
+	}
+	if r == nil {
+		log.Debugf(ctx, "Currently no credential of registry [%s] for image [%s]", server, image)
+		return nil, nil
+	}
+	return &Credential{Server: r.Server, Username: r.Username, Password: r.Password, Email: r.Email}, nil
+}
+
+// Server registry host of image, first part of image is host if it has '.' or ':' or it is
+// 'localhost', otherwise image is in default registry
+func Server(image string) string {
+	i := strings.Index(image, "/")
+	if i == -1 {
+		return DefaultServer
+	}
+	host := image[:i]
+	if host != "localhost" && !strings.ContainsAny(host, ".:") {
+		return DefaultServer
+	}
+	return host
+}
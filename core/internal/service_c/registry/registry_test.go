@@ -0,0 +1,31 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package registry
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	t.Run("test registry host of image", func(t *testing.T) {
+		assert.Equal(t, DefaultServer, Server("busybox:latest"))
+		assert.Equal(t, DefaultServer, Server("library/busybox:latest"))
+		assert.Equal(t, "harbor.example.com", Server("harbor.example.com/cmapp/app:1.0"))
+		assert.Equal(t, "harbor:5000", Server("harbor:5000/app:1.0"))
+		assert.Equal(t, "localhost", Server("localhost/app:1.0"))
+	})
+}
@@ -0,0 +1,264 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/audit"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// EventType type of event
+type EventType string
+
+const (
+	MachineCreated EventType = "machine.created"
+	MachineUpdated EventType = "machine.updated"
+	ChainCreated   EventType = "chain.created"
+	ChainUpdated   EventType = "chain.updated"
+	NodesCreated   EventType = "nodes.created"
+	AppCreated     EventType = "app.created"
+	AppStarted     EventType = "app.started"
+	AppStopped     EventType = "app.stopped"
+	AppDestroyed   EventType = "app.destroyed"
+	AppUpdated     EventType = "app.updated"
+	AppHealth      EventType = "app.health"
+)
+
+// EventTypes all supported event types
+var EventTypes = []EventType{MachineCreated, MachineUpdated, ChainCreated, ChainUpdated, NodesCreated,
+	AppCreated, AppStarted, AppStopped, AppDestroyed, AppUpdated, AppHealth}
+
+const (
+	HeaderEvent     = "X-Cmapp-Event"
+	HeaderDelivery  = "X-Cmapp-Delivery"
+	HeaderSignature = "X-Cmapp-Signature"
+)
+
+const (
+	resultSuccess = "success"
+	resultFail    = "fail"
+)
+
+var (
+	maxAttempts  = 5
+	firstBackoff = 2 * time.Second
+	maxBackoff   = time.Minute
+)
+
+var client = &http.Client{Timeout: 10 * time.Second}
+
+// Event event send to webhook
+type Event struct {
+	ID        string      `json:"id"`
+	Type      EventType   `json:"type"`
+	Time      time.Time   `json:"time"`
+	RequestID string      `json:"request_id,omitempty"`
+	Data      interface{} `json:"data"`
+}
+
+// RegisterReq register webhook request
+type RegisterReq struct {
+	URL    string      `json:"url" binding:"required"`
+	Secret string      `json:"secret" binding:"required"`
+	Events []EventType `json:"events"`
+}
+
+// Register register webhook subscription
+func Register(ctx context.Context, req *RegisterReq) (*model.Webhook, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse url [%s]", req.URL)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("unsupported url scheme [%s]", u.Scheme)
+	}
+	var events []string
+	for _, e := range req.Events {
+		if !supported(e) {
+			return nil, errors.Errorf("unsupported event type [%s]", e)
+		}
+		events = append(events, string(e))
+	}
+	w := &model.Webhook{URL: req.URL, Secret: req.Secret, Events: events, Enabled: true}
+	err = model.InsertWebhook(w)
+	if err != nil {
+		return nil, errors.Wrap(err, "insert webhook")
+	}
+	log.Infof(ctx, "Currently register webhook [%d] to url [%s]", w.ID, w.URL)
+	return w, nil
+}
+
+// Remove remove webhook subscription
+func Remove(ctx context.Context, id int) error {
+	err := model.DeleteWebhook(id)
+	if err != nil {
+		return errors.Wrap(err, "delete webhook")
+	}
+	return nil
+}
+
+// List list webhook subscriptions
+func List(ctx context.Context) ([]model.Webhook, error) {
+	return model.GetWebhooks()
+}
+
+// Deliveries delivery log of webhook
+func Deliveries(ctx context.Context, id, limit int) ([]model.WebhookDelivery, error) {
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+	return model.GetWebhookDeliveries(id, limit)
+}
+
+func supported(t EventType) bool {
+	for _, et := range EventTypes {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Emit emit event to subscribed webhooks, delivery is asynchronous, never block the caller
+func Emit(ctx context.Context, t EventType, data interface{}) {
+	ev := &Event{
+		ID:        uuid.New().String(),
+		Type:      t,
+		Time:      time.Now(),
+		RequestID: log.RequestID(ctx),
+		Data:      redactData(data),
+	}
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		log.Errorf(ctx, "Manage err when marshal event [%s]. Err: [%v]", t, err)
+		return
+	}
+	ws, err := model.GetEnabledWebhooks()
+	if err != nil {
+		log.Errorf(ctx, "Manage err when get webhooks for event [%s]. Err: [%v]", t, err)
+		return
+	}
+	// delivery should not be canceled with the request
+	dctx := log.WithField(context.Background(), log.FieldRequestID, ev.RequestID)
+	for i := range ws {
+		if !subscribed(&ws[i], t) {
+			continue
+		}
+		go deliver(dctx, ws[i], ev, payload)
+	}
+}
+
+// redactData redact secrets in data of event before it leaves core or is saved in delivery,
+// eg: kubeconfig and ssh password in custom info of machine
+func redactData(data interface{}) interface{} {
+	r := audit.RedactValue(data)
+	if len(r) == 0 {
+		return data
+	}
+	return json.RawMessage(r)
+}
+
+func subscribed(w *model.Webhook, t EventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == string(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// deliver send payload with retry and exponential backoff, every attempt is logged
+func deliver(ctx context.Context, w model.Webhook, ev *Event, payload []byte) {
+	backoff := firstBackoff
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		code, err := post(w, ev, payload)
+		d := &model.WebhookDelivery{
+			WebhookID:  w.ID,
+			EventID:    ev.ID,
+			EventType:  string(ev.Type),
+			Payload:    string(payload),
+			Attempt:    attempt,
+			StatusCode: code,
+			Result:     resultSuccess,
+		}
+		if err != nil {
+			d.Result = resultFail
+			d.Message = err.Error()
+		}
+		if e := model.InsertWebhookDelivery(d); e != nil {
+			log.Errorf(ctx, "Manage err when record delivery of event [%s]. Err: [%v]", ev.ID, e)
+		}
+		if err == nil {
+			log.Debugf(ctx, "Currently deliver event [%s] to webhook [%d] success", ev.ID, w.ID)
+			return
+		}
+		log.Warnf(ctx, "Currently deliver event [%s] to webhook [%d] failed, attempt [%d]. Err: [%v]", ev.ID, w.ID, attempt, err)
+		if attempt == maxAttempts {
+			break
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+	log.Errorf(ctx, "Manage err when deliver event [%s] to webhook [%d], give up after [%d] attempts", ev.ID, w.ID, maxAttempts)
+}
+
+func post(w model.Webhook, ev *Event, payload []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, errors.Wrapf(err, "new http request, url [%s]", w.URL)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(ev.Type))
+	req.Header.Set(HeaderDelivery, ev.ID)
+	req.Header.Set(HeaderSignature, Sign(w.Secret, payload))
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "do http post")
+	}
+	defer res.Body.Close()
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		data, _ := ioutil.ReadAll(res.Body)
+		return res.StatusCode, errors.Errorf("http response code : [%d], data : [%s]", res.StatusCode, string(data))
+	}
+	return res.StatusCode, nil
+}
+
+// Sign hmac sha256 signature of payload, format is 'sha256=<hex>'
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return fmt.Sprintf("sha256=%s", hex.EncodeToString(mac.Sum(nil)))
+}
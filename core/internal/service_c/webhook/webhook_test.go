@@ -0,0 +1,55 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package webhook
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/proto/ma_manager"
+	"testing"
+)
+
+func TestSign(t *testing.T) {
+	t.Run("test hmac sign", func(t *testing.T) {
+		sign := Sign("secret", []byte(`{"id":"1"}`))
+		assert.Equal(t, "sha256=", sign[:7])
+		assert.Equal(t, sign, Sign("secret", []byte(`{"id":"1"}`)))
+		assert.NotEqual(t, sign, Sign("other", []byte(`{"id":"1"}`)))
+	})
+}
+
+func Test_redactData(t *testing.T) {
+	t.Run("test redact custom info of machine", func(t *testing.T) {
+		m := &ma_manager.TypedMachine{UUID: "m-1", CustomInfo: map[string]string{
+			"kubeConfigBase64": "a3ViZQ==", "server_ssh_password": "p", "namespace": "default"}}
+		b, err := json.Marshal(redactData(m))
+		assert.Nil(t, err)
+		assert.NotContains(t, string(b), "a3ViZQ==")
+		assert.NotContains(t, string(b), `"p"`)
+		assert.Contains(t, string(b), "default")
+		assert.Equal(t, "p", m.CustomInfo["server_ssh_password"], "machine itself is not changed")
+	})
+}
+
+func Test_subscribed(t *testing.T) {
+	t.Run("test event filter", func(t *testing.T) {
+		assert.True(t, subscribed(&model.Webhook{}, MachineCreated))
+		w := &model.Webhook{Events: []string{string(ChainUpdated)}}
+		assert.True(t, subscribed(w, ChainUpdated))
+		assert.False(t, subscribed(w, MachineCreated))
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/webhook"
 	"github.com/zibuyu28/cmapp/core/proto/ch_manager"
 )
 
@@ -45,6 +46,7 @@ func UpdateChain(ctx context.Context, chain *ch_manager.TypedChain) error {
 		if err != nil {
 			return errors.Wrap(err, "udpate chain")
 		}
+		webhook.Emit(ctx, webhook.ChainUpdated, chain)
 	}
 	return nil
 }
@@ -68,6 +70,7 @@ func StoreChainRec(ctx context.Context, chain *ch_manager.TypedChain) error {
 		return errors.Wrap(err, "insert chain")
 	}
 	chain.ID = int32(mc.ID)
+	webhook.Emit(ctx, webhook.ChainCreated, chain)
 	return nil
 }
 
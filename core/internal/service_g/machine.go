@@ -21,7 +21,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/zibuyu28/cmapp/common/log"
 	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/webhook"
 	"github.com/zibuyu28/cmapp/core/proto/ma_manager"
+	"time"
 )
 
 // UpdateMachineRec update machine
@@ -49,6 +51,29 @@ func UpdateMachineRec(ctx context.Context, machine *ma_manager.TypedMachine) err
 	if err != nil {
 		return errors.Wrap(err, "update machine")
 	}
+	webhook.Emit(ctx, webhook.MachineUpdated, machine)
+	return nil
+}
+
+// UpdateMachineResource update allocatable and used resource of machine
+func UpdateMachineResource(ctx context.Context, res *ma_manager.MachineResource) error {
+	m := &model.Machine{
+		CPUAllocatable:    int(res.CPUAllocatable),
+		MemoryAllocatable: int(res.MemoryAllocatable),
+		DiskAllocatable:   int(res.DiskAllocatable),
+		CPUUsed:           int(res.CPUUsed),
+		MemoryUsed:        int(res.MemoryUsed),
+		DiskUsed:          int(res.DiskUsed),
+		ResourceTime:      time.Now(),
+	}
+	fields := []string{"cpu_allocatable", "memory_allocatable", "disk_allocatable",
+		"cpu_used", "memory_used", "disk_used", "resource_time"}
+	err := model.UpdateMachine(m, int(res.MachineID), fields)
+	if err != nil {
+		return errors.Wrap(err, "update machine resource")
+	}
+	log.Debugf(ctx, "Currently machine resource updated, cpu [%d/%d]m, memory [%d/%d]MB, disk [%d/%d]MB",
+		res.CPUUsed, res.CPUAllocatable, res.MemoryUsed, res.MemoryAllocatable, res.DiskUsed, res.DiskAllocatable)
 	return nil
 }
 
@@ -67,6 +92,7 @@ func StoreMachineRec(ctx context.Context, machine *ma_manager.TypedMachine) erro
 		return errors.Wrap(err, "insert machine")
 	}
 	machine.ID = int32(m.ID)
+	webhook.Emit(ctx, webhook.MachineCreated, machine)
 	return nil
 }
 
@@ -19,8 +19,12 @@ package service_g
 import (
 	"context"
 	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
 	"github.com/zibuyu28/cmapp/core/internal/model"
+	"github.com/zibuyu28/cmapp/core/internal/service_c/webhook"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
 	"github.com/zibuyu28/cmapp/core/proto/ch_manager"
+	"github.com/zibuyu28/cmapp/core/proto/ma_manager"
 )
 
 // StoreNodeRec store node record
@@ -41,6 +45,7 @@ func StoreNodeRec(ctx context.Context, node *ch_manager.TypedNode) error {
 		return errors.Wrap(err, "insert node")
 	}
 	node.ID = int32(mn.ID)
+	webhook.Emit(ctx, webhook.NodesCreated, []*ch_manager.TypedNode{node})
 	return nil
 }
 
@@ -67,6 +72,7 @@ func StoreNodeRecs(ctx context.Context, tns []*ch_manager.TypedNode) error {
 	for _, mn := range mns {
 		muid[mn.UUID].ID = int32(mn.ID)
 	}
+	webhook.Emit(ctx, webhook.NodesCreated, tns)
 	return nil
 }
 
@@ -79,3 +85,28 @@ func RemoveNodeRec(ctx context.Context, id int) error {
 	}
 	return nil
 }
+
+// UpdateAppHealth update state of node which the app runs by health of app, node is
+// found by app tag ag.NodeTagKey, or node uuid is same as app uuid
+func UpdateAppHealth(ctx context.Context, h *ma_manager.AppHealth) error {
+	ctx = log.WithAppUUID(ctx, h.AppUUID)
+	nodeUUID := h.AppUUID
+	if v, ok := h.Tags[ag.NodeTagKey]; ok && len(v) != 0 {
+		nodeUUID = v
+	}
+	state := ch_manager.TypedNode_Abnormal
+	if h.Healthy {
+		state = ch_manager.TypedNode_Normal
+	}
+	n, err := model.UpdateNodeStateByUUID(nodeUUID, int(state))
+	if err != nil {
+		return errors.Wrap(err, "update node state")
+	}
+	if n == 0 {
+		log.Debug(ctx, "Currently no node of app, skip node state update")
+	} else {
+		log.Infof(ctx, "Currently node [%s] state updated to [%s], message [%s]", nodeUUID, state, h.Message)
+	}
+	webhook.Emit(ctx, webhook.AppHealth, h)
+	return nil
+}
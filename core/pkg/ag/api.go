@@ -22,6 +22,8 @@ type MachineDriverAPI interface {
 	StartApp(appuid string, in *App) error
 	StopApp(appuid string, in *App) error
 	DestroyApp(appuid string, in *App) error
+	UpdateApp(appuid string, in *UpdateAppReq) (*App, error)
+	ScaleApp(appuid string, in *ScaleReq) (*App, error)
 
 	// --- construct App ---
 
@@ -33,10 +35,32 @@ type MachineDriverAPI interface {
 	LimitEx(appuid string, in *Limit) error
 	HealthEx(appuid string, in *Health) error
 	LogEx(appuid string, in *Log) error
+	RestartEx(appuid string, in *Restart) error
+	VolumeEx(appuid string, in *Volume) error
+
+	// --- port ---
+
+	CheckPort(appuid string, in *PortReq) (*PortRes, error)
+
+	// --- inspect ---
+
+	GetApp(appuid string) (*AppStatus, error)
+	ListApps(in *ListAppsReq) ([]AppStatus, error)
+	RenderApp(appuid string) ([]Manifest, error)
+
+	// --- file ---
+
+	ListFiles(appuid string, in *FileReq) ([]FileInfo, error)
+	ReadFile(appuid, path string) ([]byte, error)
+	WriteFile(appuid string, in *WriteFileReq) (*FileInfo, error)
+	DeleteFile(appuid string, in *FileReq) error
+	ArchiveDir(appuid, path string) ([]byte, error)
 }
 
 type CoreAPI interface {
 	DownloadFile(fileName string) ([]byte, error)
 	// UploadFile upload file, fileName is full path of the file, than return the download path of this file
 	UploadFile(fileName string) (string, error)
+	// Schedule pick machines for nodes, return node name -> machine id
+	Schedule(req *PlacementReq) (map[string]int, error)
 }
@@ -135,16 +135,20 @@ func (c Core) UploadFile(fileName string) (string, error) {
 
 func getFileURL(version APIVersion, coreHttpAddr string) string {
 	//host := viper.GetString("CORE_HOST")
-	if len(coreHttpAddr) == 0 {
-		log.Debugf(context.Background(), "use default core addr [%s]", coreDefaultHttpAddr)
-		coreHttpAddr = coreDefaultHttpAddr
-	}
-
 	//port := viper.GetInt("CORE_PORT")
 	//if port == 0 {
 	//	port = coreDefaultPort
 	//}
-	return fmt.Sprintf("%s/api/%s/file", coreHttpAddr, version)
+	return fmt.Sprintf("%s/api/%s/file", coreAddr(coreHttpAddr), version)
+}
+
+// coreAddr return default core addr if addr is empty
+func coreAddr(coreHttpAddr string) string {
+	if len(coreHttpAddr) == 0 {
+		log.Debugf(context.Background(), "use default core addr [%s]", coreDefaultHttpAddr)
+		return coreDefaultHttpAddr
+	}
+	return coreHttpAddr
 }
 
 func (c *Core) uploadfile(filename string) ([]byte, error) {
@@ -0,0 +1,50 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ag
+
+// ExecReq request to exec command in app
+type ExecReq struct {
+	Command []string          `json:"command"`
+	Envs    map[string]string `json:"envs"`
+	// Timeout timeout in seconds, 0 means 30 seconds, ignored in tty mode
+	Timeout int `json:"timeout"`
+}
+
+// ExecRes result of command exec in app
+type ExecRes struct {
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exit_code"`
+}
+
+// TTYInput input of tty exec, stdin or resize
+type TTYInput struct {
+	Stdin  string   `json:"stdin"`
+	Resize *TTYSize `json:"resize"`
+}
+
+// TTYSize size of terminal
+type TTYSize struct {
+	Width  uint32 `json:"width"`
+	Height uint32 `json:"height"`
+}
+
+// TTYExit exit of tty exec
+type TTYExit struct {
+	ExitCode int    `json:"exit_code"`
+	Message  string `json:"message"`
+}
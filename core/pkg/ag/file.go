@@ -0,0 +1,134 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ag
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+)
+
+// FileReq file in workspace of app, path is relative to workspace
+type FileReq struct {
+	Path      string `json:"path" form:"path"`
+	Recursive bool   `json:"recursive" form:"recursive"`
+}
+
+// FileInfo file in workspace of app
+type FileInfo struct {
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	Mode    uint32 `json:"mode"`
+	ModTime int64  `json:"mod_time"`
+	IsDir   bool   `json:"is_dir"`
+}
+
+// WriteFileReq write content to file in workspace of app, mode 0 means 0644
+type WriteFileReq struct {
+	Path    string `json:"path"`
+	Mode    uint32 `json:"mode"`
+	Content []byte `json:"content"`
+}
+
+// ListFiles list files in workspace of app
+func (h *HMD) ListFiles(appUUID string, in *FileReq) ([]FileInfo, error) {
+	req := Req{
+		AppUUID: appUUID,
+		Fnc:     listFiles.String(),
+		Param:   in,
+	}
+	ins, err := h.SendPost(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send list files request")
+	}
+	var res []FileInfo
+	err = json.Unmarshal(ins, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal file info list")
+	}
+	return res, nil
+}
+
+// ReadFile read content of file in workspace of app
+func (h *HMD) ReadFile(appUUID, path string) ([]byte, error) {
+	req := Req{
+		AppUUID: appUUID,
+		Fnc:     readFile.String(),
+		Param:   FileReq{Path: path},
+	}
+	ins, err := h.SendPost(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send read file request")
+	}
+	var res []byte
+	err = json.Unmarshal(ins, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal file content")
+	}
+	return res, nil
+}
+
+// WriteFile write file in workspace of app
+func (h *HMD) WriteFile(appUUID string, in *WriteFileReq) (*FileInfo, error) {
+	req := Req{
+		AppUUID: appUUID,
+		Fnc:     writeFile.String(),
+		Param:   in,
+	}
+	ins, err := h.SendPost(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send write file request")
+	}
+	res := &FileInfo{}
+	err = json.Unmarshal(ins, res)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal file info")
+	}
+	return res, nil
+}
+
+// DeleteFile delete file in workspace of app
+func (h *HMD) DeleteFile(appUUID string, in *FileReq) error {
+	req := Req{
+		AppUUID: appUUID,
+		Fnc:     deleteFile.String(),
+		Param:   in,
+	}
+	_, err := h.SendPost(req)
+	if err != nil {
+		return errors.Wrap(err, "send delete file request")
+	}
+	return nil
+}
+
+// ArchiveDir archive dir in workspace of app, tar.gz content is returned
+func (h *HMD) ArchiveDir(appUUID, path string) ([]byte, error) {
+	req := Req{
+		AppUUID: appUUID,
+		Fnc:     archiveDir.String(),
+		Param:   FileReq{Path: path},
+	}
+	ins, err := h.SendPost(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send archive dir request")
+	}
+	var res []byte
+	err = json.Unmarshal(ins, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal archive content")
+	}
+	return res, nil
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ag
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+)
+
+// AppState runtime state of app
+type AppState int
+
+const (
+	StateUnknown AppState = iota
+	StateCreated
+	StateStarting
+	StateRunning
+	StateStopped
+	StateFailed
+)
+
+// AppStatus app record on agent with its runtime status
+type AppStatus struct {
+	App   App      `json:"app"`
+	State AppState `json:"state"`
+	// Pid pid of main process, virtualbox only
+	Pid int `json:"pid"`
+	// Pods pods of app, k8s only
+	Pods         []string `json:"pods"`
+	RestartCount int      `json:"restart_count"`
+	Healthy      bool     `json:"healthy"`
+	Message      string   `json:"message"`
+	// ExitCode exit code of the last exited main process, virtualbox only
+	ExitCode int `json:"exit_code"`
+	// EnforcedLimit limit enforced by cgroup on main process, nil if not enforced, virtualbox only
+	EnforcedLimit *Limit `json:"enforced_limit,omitempty"`
+	// OOMKills count of processes killed by oom, virtualbox only
+	OOMKills int `json:"oom_kills"`
+}
+
+// ListAppsReq list apps on machine, only apps which have all the tags are returned
+type ListAppsReq struct {
+	MachineID int   `json:"machine_id"`
+	Tags      []Tag `json:"tags"`
+}
+
+// Manifest object of app rendered by agent, k8s only
+type Manifest struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Yaml object in yaml, data of secret is redacted
+	Yaml string `json:"yaml"`
+}
+
+// GetApp get app with runtime status from agent
+func (h *HMD) GetApp(appUUID string) (*AppStatus, error) {
+	req := Req{
+		AppUUID: appUUID,
+		Fnc:     getApp.String(),
+	}
+	ins, err := h.SendPost(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send get app request")
+	}
+	res := &AppStatus{}
+	err = json.Unmarshal(ins, res)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal app status")
+	}
+	return res, nil
+}
+
+// ListApps list apps with runtime status on machine
+func (h *HMD) ListApps(in *ListAppsReq) ([]AppStatus, error) {
+	req := Req{
+		Fnc:   listApps.String(),
+		Param: in,
+	}
+	ins, err := h.SendPost(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send list apps request")
+	}
+	var res []AppStatus
+	err = json.Unmarshal(ins, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal app status list")
+	}
+	return res, nil
+}
+
+// RenderApp render manifests of app without touching the cluster, k8s only
+func (h *HMD) RenderApp(appUUID string) ([]Manifest, error) {
+	req := Req{
+		AppUUID: appUUID,
+		Fnc:     renderApp.String(),
+	}
+	ins, err := h.SendPost(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send render app request")
+	}
+	var res []Manifest
+	err = json.Unmarshal(ins, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal manifests")
+	}
+	return res, nil
+}
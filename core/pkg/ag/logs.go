@@ -0,0 +1,36 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ag
+
+import "time"
+
+// LogReq request to stream logs of app
+type LogReq struct {
+	// Follow keep streaming new lines
+	Follow bool `json:"follow" form:"follow"`
+	// Tail only last N lines of history, 0 means all
+	Tail int `json:"tail" form:"tail"`
+	// Since only lines after the time
+	Since time.Time `json:"since" form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// LogLine one line of app log
+type LogLine struct {
+	Line string `json:"line"`
+	// Source pod name for k8s, file name for virtualbox
+	Source string `json:"source"`
+}
@@ -32,6 +32,8 @@ const (
 	startApp      function = "StartApp"
 	stopApp       function = "StopApp"
 	destroyApp    function = "DestroyApp"
+	updateApp     function = "UpdateApp"
+	scaleApp      function = "ScaleApp"
 	tagEx         function = "TagEx"
 	fileMountEx   function = "FileMountEx"
 	envEx         function = "EnvEx"
@@ -40,6 +42,17 @@ const (
 	limitEx       function = "LimitEx"
 	healthEx      function = "HealthEx"
 	logEx         function = "LogEx"
+	restartEx     function = "RestartEx"
+	volumeEx      function = "VolumeEx"
+	checkPort     function = "CheckPort"
+	getApp        function = "GetApp"
+	listApps      function = "ListApps"
+	renderApp     function = "RenderApp"
+	listFiles     function = "ListFiles"
+	readFile      function = "ReadFile"
+	writeFile     function = "WriteFile"
+	deleteFile    function = "DeleteFile"
+	archiveDir    function = "ArchiveDir"
 )
 
 func (f function) String() string {
@@ -73,8 +86,55 @@ type App struct {
 	HealthInfo      Health        `json:"health_info"`
 	LogInfo         Log           `json:"log_info"`
 	Tags            []Tag         `json:"tags"`
+	RestartInfo     Restart       `json:"restart_info"`
+	// KeepData keep data of app when it is destroyed
+	KeepData bool `json:"keep_data"`
+	// Workload workload kind of app, k8s only
+	Workload Workload `json:"workload"`
+	// Volumes volumes claimed by app, k8s only
+	Volumes []Volume `json:"volumes"`
+	// DryRun start app only validates it, k8s objects are validated by the cluster without being applied
+	DryRun bool `json:"dry_run"`
+	// Replicas desired replicas of workload, k8s only. 0 means 1
+	Replicas int `json:"replicas"`
 }
 
+// AccessMode access mode of volume
+type AccessMode int
+
+const (
+	ReadWriteOnce AccessMode = iota
+	ReadWriteMany
+	ReadOnlyMany
+)
+
+// WorkspaceVolume name of volume which configures storage of workspace
+const WorkspaceVolume = "workspace"
+
+// Volume volume claimed by app, k8s only
+type Volume struct {
+	Name string `json:"name"`
+	// Size quantity of storage, eg: 10Gi
+	Size       string     `json:"size"`
+	AccessMode AccessMode `json:"access_mode"`
+	// StorageClass empty means storage class of agent
+	StorageClass string `json:"storage_class"`
+	// MountPath absolute path in container, ignored by workspace
+	MountPath string `json:"mount_path"`
+}
+
+// Workload workload kind of app on k8s, StatefulSet gives app stable network identity and storage
+type Workload int
+
+const (
+	WorkloadDeployment Workload = iota
+	WorkloadStatefulSet
+)
+
+// NodeTagKey key of app tag whose value is uuid of node which the app runs, used to
+// reflect health of app to node
+const NodeTagKey = "node"
+
 type Tag struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
@@ -85,6 +145,21 @@ type Log struct {
 	FilePath     string `json:"file_path"`
 }
 
+// RestartPolicy restart policy of main process
+type RestartPolicy int
+
+const (
+	RestartAlways RestartPolicy = iota
+	RestartOnFailure
+	RestartNever
+)
+
+type Restart struct {
+	Policy RestartPolicy `json:"policy"`
+	// Backoff seconds to wait before the first restart, doubled for every continuous restart
+	Backoff int `json:"backoff"`
+}
+
 type Method int
 
 const (
@@ -92,10 +167,31 @@ const (
 	POST
 )
 
+// ProbeType type of probe, GRPC is checked by standard grpc health service
+type ProbeType int
+
+const (
+	ProbeHTTP ProbeType = iota
+	ProbeTCP
+	ProbeExec
+	ProbeGRPC
+)
+
 type Basic struct {
-	MethodType Method `json:"method_type"`
-	Path       string `json:"path"`
-	Port       int    `json:"port"`
+	ProbeType  ProbeType `json:"probe_type"`
+	MethodType Method    `json:"method_type"`
+	Path       string    `json:"path"`
+	Port       int       `json:"port"`
+	// Command command of exec probe, executed in workspace of app
+	Command []string `json:"command"`
+	// GRPCService service checked by grpc probe, empty for the whole server
+	GRPCService string `json:"grpc_service"`
+	// timings of probe in seconds, defaults of worker are used if zero
+	InitialDelaySeconds int `json:"initial_delay_seconds"`
+	PeriodSeconds       int `json:"period_seconds"`
+	TimeoutSeconds      int `json:"timeout_seconds"`
+	FailureThreshold    int `json:"failure_threshold"`
+	SuccessThreshold    int `json:"success_threshold"`
 }
 
 type Health struct {
@@ -112,6 +208,14 @@ type File struct {
 	Name        string `json:"name"`
 	AcquireAddr string `json:"acquire_addr"`
 	Shell       string `json:"shell"`
+	// Sensitive sensitive file is delivered by secret on k8s, shell is not supported
+	Sensitive bool `json:"sensitive"`
+	// CheckSum sha256 of file in hex, verified after file is acquired
+	CheckSum string `json:"check_sum"`
+	// ExtractTo dir relative to workdir which the tar.gz file is extracted into if set
+	ExtractTo string `json:"extract_to"`
+	// StripComponents leading components stripped from path of entries when extracting
+	StripComponents int `json:"strip_components"`
 }
 
 type WorkspaceInfo struct {
@@ -142,8 +246,26 @@ type Network struct {
 		RouteType Route  `json:"route_type"`
 		Router    string `json:"router"`
 	}
+	// ExposeType how port is exposed out of cluster, k8s only
+	ExposeType Expose `json:"expose_type"`
+	// DryRun only return routes of port, neither port is reserved nor service is created, k8s only
+	DryRun bool `json:"dry_run"`
 }
 
+// Expose expose mode of port on k8s, route OUT carries the external address it produces
+type Expose int
+
+const (
+	ExposeNodePort Expose = iota
+	// ExposeClusterIP port is only reachable in cluster, no route OUT
+	ExposeClusterIP
+	ExposeLoadBalancer
+	// ExposeIngress http by host of ingress on domain of agent
+	ExposeIngress
+	// ExposeTLSPassthrough tls passthrough by host of ingress, for grpc with tls
+	ExposeTLSPassthrough
+)
+
 type EnvVar struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
@@ -159,12 +281,16 @@ type FileMount struct {
 type HMD struct {
 	V            APIVersion
 	CoreHttpAddr string
+	// Token write token of core api, it is required by functions touching files of app
+	Token string
 }
 
 type NewAppReq struct {
 	MachineID int
 	Name      string
 	Version   string
+	// Workload can not be changed after app created
+	Workload Workload
 }
 
 type Req struct {
@@ -350,6 +476,40 @@ func (h *HMD) HealthEx(appUUID string, health *Health) error {
 	return nil
 }
 
+func (h *HMD) RestartEx(appUUID string, restart *Restart) error {
+	req := Req{
+		AppUUID: appUUID,
+		Fnc:     restartEx.String(),
+		Param:   restart,
+	}
+	ins, err := h.SendPost(req)
+	if err != nil {
+		return errors.Wrap(err, "send set restart request")
+	}
+	err = json.Unmarshal(ins, restart)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal restart")
+	}
+	return nil
+}
+
+func (h *HMD) VolumeEx(appUUID string, volume *Volume) error {
+	req := Req{
+		AppUUID: appUUID,
+		Fnc:     volumeEx.String(),
+		Param:   volume,
+	}
+	ins, err := h.SendPost(req)
+	if err != nil {
+		return errors.Wrap(err, "send set volume request")
+	}
+	err = json.Unmarshal(ins, volume)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal volume")
+	}
+	return nil
+}
+
 func (h *HMD) LogEx(appUUID string, log *Log) error {
 	req := Req{
 		AppUUID: appUUID,
@@ -392,7 +552,11 @@ const (
 )
 
 func (h *HMD) SendPost(req interface{}) ([]byte, error) {
-	respb, err := httputil.HTTPDoPost(req, getURL(h.V, h.CoreHttpAddr))
+	var header map[string]string
+	if len(h.Token) != 0 {
+		header = map[string]string{"Authorization": "Bearer " + h.Token}
+	}
+	respb, err := httputil.HTTPDoPostWithHeader(req, getURL(h.V, h.CoreHttpAddr), header)
 	if err != nil {
 		return nil, errors.Wrap(err, "send req to core")
 	}
@@ -0,0 +1,64 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package ag
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/httputil"
+)
+
+// PlacementNode resource needs and constraints of one node to be placed
+type PlacementNode struct {
+	Name string `json:"name" binding:"required"`
+	// MachineID pinned machine, 0 means scheduled by core
+	MachineID int `json:"machine_id"`
+	// Tags machine must have all these tags
+	Tags []string `json:"tags"`
+	// AntiAffinity nodes in the same group are never placed on the same machine, e.g. org of peers
+	AntiAffinity string `json:"anti_affinity"`
+	// CPU unit is m
+	CPU int `json:"cpu"`
+	// Memory unit is MB
+	Memory int `json:"memory"`
+}
+
+// PlacementReq placement request
+type PlacementReq struct {
+	Nodes []PlacementNode `json:"nodes" binding:"required"`
+}
+
+// Schedule ask core to pick machines for nodes, return node name -> machine id
+func (c Core) Schedule(req *PlacementReq) (map[string]int, error) {
+	respb, err := httputil.HTTPDoPost(req, fmt.Sprintf("%s/api/%s/placement/schedule", coreAddr(c.CoreHttpAddr), c.ApiVersion))
+	if err != nil {
+		return nil, errors.Wrap(err, "send placement req to core")
+	}
+	var resp = struct {
+		Code    CoreCode       `json:"code"`
+		Message string         `json:"message"`
+		Data    map[string]int `json:"data"`
+	}{}
+	err = json.Unmarshal(respb, &resp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unmarshal resp [%s]", string(respb))
+	}
+	if resp.Code != SUCCESS {
+		return nil, errors.Errorf("fail to schedule, message [%s]", resp.Message)
+	}
+	return resp.Data, nil
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package ag
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+)
+
+// PortReq port check request
+type PortReq struct {
+	// Port port in container or vm
+	Port int `json:"port"`
+	// HostPort port exported on host, 0 means not check
+	HostPort     int      `json:"host_port"`
+	ProtocolType Protocol `json:"protocol_type"`
+}
+
+// PortRes port check result
+type PortRes struct {
+	Available bool   `json:"available"`
+	Port      int    `json:"port"`
+	HostPort  int    `json:"host_port"`
+	Message   string `json:"message"`
+}
+
+// CheckPort check whether port is available on the machine of app
+func (h *HMD) CheckPort(appUUID string, in *PortReq) (*PortRes, error) {
+	req := Req{
+		AppUUID: appUUID,
+		Fnc:     checkPort.String(),
+		Param:   in,
+	}
+	ins, err := h.SendPost(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send check port request")
+	}
+	res := &PortRes{}
+	err = json.Unmarshal(ins, res)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal port result")
+	}
+	return res, nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ag
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+)
+
+// UpdateStrategy how deployment of app is updated, k8s only
+type UpdateStrategy int
+
+const (
+	Recreate UpdateStrategy = iota
+	RollingUpdate
+)
+
+// UpdateAppReq changed spec of app
+type UpdateAppReq struct {
+	// Name and Version new package from registry, empty keeps the current package
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Envs envs to set, empty value removes the env
+	Envs []EnvVar `json:"envs"`
+	// FileMounts mounts of app after update, empty keeps the current mounts
+	FileMounts []FileMount    `json:"file_mounts"`
+	Strategy   UpdateStrategy `json:"strategy"`
+}
+
+// UpdateApp apply changed spec to app, app is redeployed if it has been started
+func (h *HMD) UpdateApp(appUUID string, in *UpdateAppReq) (*App, error) {
+	req := Req{
+		AppUUID: appUUID,
+		Fnc:     updateApp.String(),
+		Param:   in,
+	}
+	ins, err := h.SendPost(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send update app request")
+	}
+	app := App{}
+	err = json.Unmarshal(ins, &app)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal app")
+	}
+	return &app, nil
+}
+
+// ScaleReq desired replicas of app, k8s only
+type ScaleReq struct {
+	// Replicas at least 1, stop app to scale it to zero
+	Replicas int `json:"replicas"`
+}
+
+// ScaleApp scale app to desired replicas, workload is scaled if app has been started
+func (h *HMD) ScaleApp(appUUID string, in *ScaleReq) (*App, error) {
+	req := Req{
+		AppUUID: appUUID,
+		Fnc:     scaleApp.String(),
+		Param:   in,
+	}
+	ins, err := h.SendPost(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send scale app request")
+	}
+	app := App{}
+	err = json.Unmarshal(ins, &app)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal app")
+	}
+	return &app, nil
+}
@@ -214,6 +214,182 @@ func (x *TypedMachine) GetAGGRPCAddr() string {
 	return ""
 }
 
+// MachineResource resource of machine, cpu unit is 'm', memory and disk unit is 'MB'
+type MachineResource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MachineID         int32 `protobuf:"varint,1,opt,name=MachineID,proto3" json:"MachineID,omitempty"`
+	CPUAllocatable    int32 `protobuf:"varint,2,opt,name=CPUAllocatable,proto3" json:"CPUAllocatable,omitempty"`
+	MemoryAllocatable int32 `protobuf:"varint,3,opt,name=MemoryAllocatable,proto3" json:"MemoryAllocatable,omitempty"`
+	DiskAllocatable   int32 `protobuf:"varint,4,opt,name=DiskAllocatable,proto3" json:"DiskAllocatable,omitempty"`
+	CPUUsed           int32 `protobuf:"varint,5,opt,name=CPUUsed,proto3" json:"CPUUsed,omitempty"`
+	MemoryUsed        int32 `protobuf:"varint,6,opt,name=MemoryUsed,proto3" json:"MemoryUsed,omitempty"`
+	DiskUsed          int32 `protobuf:"varint,7,opt,name=DiskUsed,proto3" json:"DiskUsed,omitempty"`
+}
+
+func (x *MachineResource) Reset() {
+	*x = MachineResource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ma_manager_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineResource) ProtoMessage() {}
+
+func (x *MachineResource) ProtoReflect() protoreflect.Message {
+	mi := &file_ma_manager_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineResource.ProtoReflect.Descriptor instead.
+func (*MachineResource) Descriptor() ([]byte, []int) {
+	return file_ma_manager_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MachineResource) GetMachineID() int32 {
+	if x != nil {
+		return x.MachineID
+	}
+	return 0
+}
+
+func (x *MachineResource) GetCPUAllocatable() int32 {
+	if x != nil {
+		return x.CPUAllocatable
+	}
+	return 0
+}
+
+func (x *MachineResource) GetMemoryAllocatable() int32 {
+	if x != nil {
+		return x.MemoryAllocatable
+	}
+	return 0
+}
+
+func (x *MachineResource) GetDiskAllocatable() int32 {
+	if x != nil {
+		return x.DiskAllocatable
+	}
+	return 0
+}
+
+func (x *MachineResource) GetCPUUsed() int32 {
+	if x != nil {
+		return x.CPUUsed
+	}
+	return 0
+}
+
+func (x *MachineResource) GetMemoryUsed() int32 {
+	if x != nil {
+		return x.MemoryUsed
+	}
+	return 0
+}
+
+func (x *MachineResource) GetDiskUsed() int32 {
+	if x != nil {
+		return x.DiskUsed
+	}
+	return 0
+}
+
+// AppHealth health transition of app, node of app is found by tag 'node' or app uuid
+type AppHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MachineID int32             `protobuf:"varint,1,opt,name=MachineID,proto3" json:"MachineID,omitempty"`
+	AppUUID   string            `protobuf:"bytes,2,opt,name=AppUUID,proto3" json:"AppUUID,omitempty"`
+	Tags      map[string]string `protobuf:"bytes,3,rep,name=Tags,proto3" json:"Tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Healthy   bool              `protobuf:"varint,4,opt,name=Healthy,proto3" json:"Healthy,omitempty"`
+	Message   string            `protobuf:"bytes,5,opt,name=Message,proto3" json:"Message,omitempty"`
+}
+
+func (x *AppHealth) Reset() {
+	*x = AppHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ma_manager_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppHealth) ProtoMessage() {}
+
+func (x *AppHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_ma_manager_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppHealth.ProtoReflect.Descriptor instead.
+func (*AppHealth) Descriptor() ([]byte, []int) {
+	return file_ma_manager_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AppHealth) GetMachineID() int32 {
+	if x != nil {
+		return x.MachineID
+	}
+	return 0
+}
+
+func (x *AppHealth) GetAppUUID() string {
+	if x != nil {
+		return x.AppUUID
+	}
+	return ""
+}
+
+func (x *AppHealth) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *AppHealth) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *AppHealth) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_ma_manager_proto protoreflect.FileDescriptor
 
 var file_ma_manager_proto_rawDesc = []byte{
@@ -241,20 +417,57 @@ var file_ma_manager_proto_rawDesc = []byte{
 	0x6f, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
 	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
 	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0xb2, 0x01, 0x0a, 0x0d, 0x4d, 0x61, 0x63, 0x68,
-	0x69, 0x6e, 0x65, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x11, 0x52, 0x65, 0x70,
-	0x6f, 0x72, 0x74, 0x49, 0x6e, 0x69, 0x74, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x12, 0x0d,
-	0x2e, 0x54, 0x79, 0x70, 0x65, 0x64, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x1a, 0x0d, 0x2e,
-	0x54, 0x79, 0x70, 0x65, 0x64, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x22, 0x00, 0x12, 0x37,
-	0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e,
-	0x65, 0x12, 0x0d, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x64, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
-	0x1a, 0x13, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x61, 0x63, 0x68, 0x69,
-	0x6e, 0x65, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x12, 0x0d, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x64,
-	0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x1a, 0x11, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x22, 0x00, 0x42, 0x0e, 0x5a, 0x0c,
-	0x2e, 0x2f, 0x6d, 0x61, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x85, 0x02, 0x0a, 0x0f, 0x4d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4d,
+	0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x44, 0x12, 0x26, 0x0a, 0x0e, 0x43, 0x50, 0x55,
+	0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0e, 0x43, 0x50, 0x55, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x12, 0x2c, 0x0a, 0x11, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x41, 0x6c, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x28, 0x0a, 0x0f, 0x44, 0x69, 0x73, 0x6b, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x44, 0x69, 0x73, 0x6b, 0x41, 0x6c,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x50, 0x55,
+	0x55, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x43, 0x50, 0x55, 0x55,
+	0x73, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55, 0x73, 0x65,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55,
+	0x73, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x6b, 0x55, 0x73, 0x65, 0x64, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x44, 0x69, 0x73, 0x6b, 0x55, 0x73, 0x65, 0x64, 0x22,
+	0xda, 0x01, 0x0a, 0x09, 0x41, 0x70, 0x70, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x1c, 0x0a,
+	0x09, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x41,
+	0x70, 0x70, 0x55, 0x55, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x41, 0x70,
+	0x70, 0x55, 0x55, 0x49, 0x44, 0x12, 0x28, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x41, 0x70, 0x70, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e,
+	0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0x9f, 0x02, 0x0a,
+	0x0d, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x12, 0x33,
+	0x0a, 0x11, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x69, 0x74, 0x4d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x65, 0x12, 0x0d, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x64, 0x4d, 0x61, 0x63, 0x68, 0x69,
+	0x6e, 0x65, 0x1a, 0x0d, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x64, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e,
+	0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x4d,
+	0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x12, 0x0d, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x64, 0x4d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x1a, 0x13, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x0d,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x12, 0x0d, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x64, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x1a, 0x11, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x22,
+	0x00, 0x12, 0x37, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x10, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x11, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x0f, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x41, 0x70, 0x70, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x0a, 0x2e,
+	0x41, 0x70, 0x70, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x1a, 0x11, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x22, 0x00, 0x42, 0x0e,
+	0x5a, 0x0c, 0x2e, 0x2f, 0x6d, 0x61, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -269,26 +482,34 @@ func file_ma_manager_proto_rawDescGZIP() []byte {
 	return file_ma_manager_proto_rawDescData
 }
 
-var file_ma_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_ma_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_ma_manager_proto_goTypes = []interface{}{
 	(*UpdateMachineRes)(nil),   // 0: UpdateMachineRes
 	(*RegisterMachineRes)(nil), // 1: RegisterMachineRes
 	(*TypedMachine)(nil),       // 2: TypedMachine
-	nil,                        // 3: TypedMachine.CustomInfoEntry
+	(*MachineResource)(nil),    // 3: MachineResource
+	(*AppHealth)(nil),          // 4: AppHealth
+	nil,                        // 5: TypedMachine.CustomInfoEntry
+	nil,                        // 6: AppHealth.TagsEntry
 }
 var file_ma_manager_proto_depIdxs = []int32{
-	3, // 0: TypedMachine.CustomInfo:type_name -> TypedMachine.CustomInfoEntry
-	2, // 1: MachineManage.ReportInitMachine:input_type -> TypedMachine
-	2, // 2: MachineManage.RegisterMachine:input_type -> TypedMachine
-	2, // 3: MachineManage.UpdateMachine:input_type -> TypedMachine
-	2, // 4: MachineManage.ReportInitMachine:output_type -> TypedMachine
-	1, // 5: MachineManage.RegisterMachine:output_type -> RegisterMachineRes
-	0, // 6: MachineManage.UpdateMachine:output_type -> UpdateMachineRes
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	5, // 0: TypedMachine.CustomInfo:type_name -> TypedMachine.CustomInfoEntry
+	6, // 1: AppHealth.Tags:type_name -> AppHealth.TagsEntry
+	2, // 2: MachineManage.ReportInitMachine:input_type -> TypedMachine
+	2, // 3: MachineManage.RegisterMachine:input_type -> TypedMachine
+	2, // 4: MachineManage.UpdateMachine:input_type -> TypedMachine
+	3, // 5: MachineManage.ReportResource:input_type -> MachineResource
+	4, // 6: MachineManage.ReportAppHealth:input_type -> AppHealth
+	2, // 7: MachineManage.ReportInitMachine:output_type -> TypedMachine
+	1, // 8: MachineManage.RegisterMachine:output_type -> RegisterMachineRes
+	0, // 9: MachineManage.UpdateMachine:output_type -> UpdateMachineRes
+	0, // 10: MachineManage.ReportResource:output_type -> UpdateMachineRes
+	0, // 11: MachineManage.ReportAppHealth:output_type -> UpdateMachineRes
+	7, // [7:12] is the sub-list for method output_type
+	2, // [2:7] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_ma_manager_proto_init() }
@@ -333,6 +554,30 @@ func file_ma_manager_proto_init() {
 				return nil
 			}
 		}
+		file_ma_manager_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineResource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ma_manager_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppHealth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -340,7 +585,7 @@ func file_ma_manager_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ma_manager_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -371,6 +616,10 @@ type MachineManageClient interface {
 	// RegisterMachine register machine to core center, maybe to maintain heartbeat, check health status
 	RegisterMachine(ctx context.Context, in *TypedMachine, opts ...grpc.CallOption) (*RegisterMachineRes, error)
 	UpdateMachine(ctx context.Context, in *TypedMachine, opts ...grpc.CallOption) (*UpdateMachineRes, error)
+	// ReportResource report allocatable and used resource of machine periodically
+	ReportResource(ctx context.Context, in *MachineResource, opts ...grpc.CallOption) (*UpdateMachineRes, error)
+	// ReportAppHealth report health transition of app on machine
+	ReportAppHealth(ctx context.Context, in *AppHealth, opts ...grpc.CallOption) (*UpdateMachineRes, error)
 }
 
 type machineManageClient struct {
@@ -408,6 +657,24 @@ func (c *machineManageClient) UpdateMachine(ctx context.Context, in *TypedMachin
 	return out, nil
 }
 
+func (c *machineManageClient) ReportResource(ctx context.Context, in *MachineResource, opts ...grpc.CallOption) (*UpdateMachineRes, error) {
+	out := new(UpdateMachineRes)
+	err := c.cc.Invoke(ctx, "/MachineManage/ReportResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineManageClient) ReportAppHealth(ctx context.Context, in *AppHealth, opts ...grpc.CallOption) (*UpdateMachineRes, error) {
+	out := new(UpdateMachineRes)
+	err := c.cc.Invoke(ctx, "/MachineManage/ReportAppHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MachineManageServer is the server API for MachineManage service.
 type MachineManageServer interface {
 	// ReportInitMachine report Machine message to init
@@ -415,6 +682,10 @@ type MachineManageServer interface {
 	// RegisterMachine register machine to core center, maybe to maintain heartbeat, check health status
 	RegisterMachine(context.Context, *TypedMachine) (*RegisterMachineRes, error)
 	UpdateMachine(context.Context, *TypedMachine) (*UpdateMachineRes, error)
+	// ReportResource report allocatable and used resource of machine periodically
+	ReportResource(context.Context, *MachineResource) (*UpdateMachineRes, error)
+	// ReportAppHealth report health transition of app on machine
+	ReportAppHealth(context.Context, *AppHealth) (*UpdateMachineRes, error)
 }
 
 // UnimplementedMachineManageServer can be embedded to have forward compatible implementations.
@@ -430,6 +701,12 @@ func (*UnimplementedMachineManageServer) RegisterMachine(context.Context, *Typed
 func (*UnimplementedMachineManageServer) UpdateMachine(context.Context, *TypedMachine) (*UpdateMachineRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateMachine not implemented")
 }
+func (*UnimplementedMachineManageServer) ReportResource(context.Context, *MachineResource) (*UpdateMachineRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportResource not implemented")
+}
+func (*UnimplementedMachineManageServer) ReportAppHealth(context.Context, *AppHealth) (*UpdateMachineRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportAppHealth not implemented")
+}
 
 func RegisterMachineManageServer(s *grpc.Server, srv MachineManageServer) {
 	s.RegisterService(&_MachineManage_serviceDesc, srv)
@@ -489,6 +766,42 @@ func _MachineManage_UpdateMachine_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MachineManage_ReportResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MachineResource)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineManageServer).ReportResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/MachineManage/ReportResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineManageServer).ReportResource(ctx, req.(*MachineResource))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MachineManage_ReportAppHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppHealth)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineManageServer).ReportAppHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/MachineManage/ReportAppHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineManageServer).ReportAppHealth(ctx, req.(*AppHealth))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _MachineManage_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "MachineManage",
 	HandlerType: (*MachineManageServer)(nil),
@@ -505,6 +818,14 @@ var _MachineManage_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateMachine",
 			Handler:    _MachineManage_UpdateMachine_Handler,
 		},
+		{
+			MethodName: "ReportResource",
+			Handler:    _MachineManage_ReportResource_Handler,
+		},
+		{
+			MethodName: "ReportAppHealth",
+			Handler:    _MachineManage_ReportAppHealth_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ma_manager.proto",
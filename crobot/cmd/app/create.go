@@ -20,6 +20,7 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/zibuyu28/cmapp/common/log"
 	"github.com/zibuyu28/cmapp/crobot/internal/cengine"
 	"time"
 )
@@ -32,6 +33,7 @@ var (
 	coreHttpAddr  string
 	coreGrpcAddr  string
 	param         string
+	jobID         string
 )
 
 // createCmd create command
@@ -55,7 +57,8 @@ to quickly create a Cobra application.`,
 			CoreHttpAddr:  coreHttpAddr,
 			CoreGrpcAddr:  coreGrpcAddr,
 		}
-		err := cengine.CreateChain(context.Background(), inf, createUUID, param)
+		ctx := log.WithChainUUID(log.WithJobID(context.Background(), jobID), createUUID)
+		err := cengine.CreateChain(ctx, inf, createUUID, param)
 		time.Sleep(time.Second)
 		cobra.CheckErr(err)
 	},
@@ -66,6 +69,7 @@ func init() {
 
 	createCmd.Flags().StringVarP(&createUUID, "uuid", "u", "", "the create machine's uuid")
 	createCmd.Flags().StringVarP(&param, "param", "p", "", "param to create action, format in json")
+	createCmd.Flags().StringVarP(&jobID, "job-id", "", "", "id of the job in core which runs this action, written to log")
 
 	createCmd.Flags().StringVarP(&driverName, "driver-name", "", "", "name of the driver which use to create chain")
 	createCmd.Flags().StringVarP(&driverVersion, "driver-version", "", "", "version of the driver which use to create chain")
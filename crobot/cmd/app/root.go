@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/zibuyu28/cmapp/common/log"
 	"github.com/zibuyu28/cmapp/crobot/drivers"
 	"github.com/zibuyu28/cmapp/crobot/pkg/plugin"
 	"os"
@@ -118,4 +119,11 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+
+	// init logger if log config is set, otherwise keep the default one
+	if viper.IsSet("log") {
+		var lc log.Config
+		cobra.CheckErr(viper.UnmarshalKey("log", &lc))
+		cobra.CheckErr(log.Init(lc))
+	}
 }
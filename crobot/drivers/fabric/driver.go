@@ -168,6 +168,12 @@ func (f *FabricDriver) InitChain(ctx context.Context, _ *driver.Empty) (*driver.
 	f.Fabric.UUID = data[0]
 	// TODO: check fabric param
 
+	// MachineID of node is optional, core will pick one
+	err = process.PlaceNodes(ctx, f.CoreHTTPAddr, f.Fabric)
+	if err != nil {
+		return nil, errors.Wrap(err, "place nodes")
+	}
+
 	cb, err := json.Marshal(f.Fabric.Channels)
 	if err != nil {
 		return nil, errors.Wrap(err, "marshal channels")
@@ -45,7 +45,7 @@ type Channel struct {
 type Orderer struct {
 	Name         string
 	UUID         string
-	MachineID    int
+	MachineID    int // optional, scheduled by core if not set
 	GRPCPort     int
 	HealthPort   int
 	Tag          string
@@ -54,6 +54,11 @@ type Orderer struct {
 	RemoteConfig string
 	LogLevel     string
 	APP          *ag.App
+	// MachineTags machine which the orderer is placed on must have all these tags
+	MachineTags []string
+	// CPU unit is m, Memory unit is MB, default limit is used if 0
+	CPU    int
+	Memory int
 }
 
 type Organization struct {
@@ -64,7 +69,7 @@ type Organization struct {
 type Peer struct {
 	Name                string
 	UUID                string
-	MachineID           int
+	MachineID           int // optional, scheduled by core if not set
 	GRPCPort            int
 	ChainCodeListenPort int
 	EventPort           int
@@ -79,6 +84,11 @@ type Peer struct {
 	APP                 *ag.App
 	CouchDB             *ag.App
 	RMTDocker           string
+	// MachineTags machine which the peer is placed on must have all these tags
+	MachineTags []string
+	// CPU unit is m, Memory unit is MB, default limit is used if 0
+	CPU    int
+	Memory int
 }
 
 // Fabric fabric chain info
@@ -93,4 +103,7 @@ type Fabric struct {
 	RemoteGenesisBlock string
 	Orderers           []Orderer
 	Peers              []Peer
+	// OrdererAntiAffinity never place two orderers on the same machine, orderers are only
+	// spread over machines by default
+	OrdererAntiAffinity bool
 }
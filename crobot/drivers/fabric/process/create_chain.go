@@ -169,11 +169,21 @@ func constructPeer(ctx context.Context, chain *model.Fabric) error {
 		if err != nil {
 			return errors.Wrap(err, "set tag")
 		}
+		// node tag reflects health of app to the node
+		err = hmd.TagEx(peer.APP.UUID, &ag.Tag{
+			Key:   ag.NodeTagKey,
+			Value: peer.UUID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "set node tag")
+		}
 		log.Debugf(ctx, "cert addr [%s]", peer.RemoteCert)
 		err = hmd.FilePremiseEx(peer.APP.UUID, &ag.File{
-			Name:        "msp.tar.gz",
-			AcquireAddr: peer.RemoteCert,
-			Shell:       "mkdir config && tar -zxvf msp.tar.gz -C config/ --strip-components 1",
+			Name:            "msp.tar.gz",
+			AcquireAddr:     peer.RemoteCert,
+			Sensitive:       true,
+			ExtractTo:       "config",
+			StripComponents: 1,
 		})
 		if err != nil {
 			return errors.Wrap(err, "set cert file premise")
@@ -188,10 +198,8 @@ func constructPeer(ctx context.Context, chain *model.Fabric) error {
 			return errors.Wrap(err, "set core.yaml file premise")
 		}
 
-		err = hmd.LimitEx(peer.APP.UUID, &ag.Limit{
-			CPU:    1000,
-			Memory: 1024,
-		})
+		limit := nodeLimit(peer.CPU, peer.Memory)
+		err = hmd.LimitEx(peer.APP.UUID, &limit)
 		if err != nil {
 			return errors.Wrap(err, "set app limit")
 		}
@@ -291,11 +299,21 @@ func constructOrder(ctx context.Context, chain *model.Fabric) error {
 		if err != nil {
 			return errors.Wrap(err, "set tag")
 		}
+		// node tag reflects health of app to the node
+		err = hmd.TagEx(order.APP.UUID, &ag.Tag{
+			Key:   ag.NodeTagKey,
+			Value: order.UUID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "set node tag")
+		}
 		log.Debugf(ctx, "cert addr [%s]", order.RemoteCert)
 		err = hmd.FilePremiseEx(order.APP.UUID, &ag.File{
-			Name:        "msp.tar.gz",
-			AcquireAddr: order.RemoteCert,
-			Shell:       "mkdir config && tar -zxvf msp.tar.gz -C config/ --strip-components 1",
+			Name:            "msp.tar.gz",
+			AcquireAddr:     order.RemoteCert,
+			Sensitive:       true,
+			ExtractTo:       "config",
+			StripComponents: 1,
 		})
 		if err != nil {
 			return errors.Wrap(err, "set cert file premise")
@@ -319,10 +337,8 @@ func constructOrder(ctx context.Context, chain *model.Fabric) error {
 			return errors.Wrap(err, "set orderer.yaml file premise")
 		}
 
-		err = hmd.LimitEx(order.APP.UUID, &ag.Limit{
-			CPU:    1000,
-			Memory: 1024,
-		})
+		limit := nodeLimit(order.CPU, order.Memory)
+		err = hmd.LimitEx(order.APP.UUID, &limit)
 		if err != nil {
 			return errors.Wrap(err, "set app limit")
 		}
@@ -402,6 +418,8 @@ func newOrdererApp(orderer *model.Orderer, version string) error {
 		MachineID: orderer.MachineID,
 		Name:      "orderer",
 		Version:   version,
+		// stateful set keeps identity and ledger data of node
+		Workload: ag.WorkloadStatefulSet,
 	})
 	if err != nil {
 		return errors.Wrap(err, "new app")
@@ -456,6 +474,8 @@ func newCouchDBApp(peer *model.Peer, version string) error {
 		MachineID: peer.MachineID,
 		Name:      "couchdb",
 		Version:   version,
+		// stateful set keeps identity and ledger data of node
+		Workload: ag.WorkloadStatefulSet,
 	})
 	if err != nil {
 		return errors.Wrap(err, "new app")
@@ -485,6 +505,8 @@ func newPeerApp(peer *model.Peer, version string) error {
 		MachineID: peer.MachineID,
 		Name:      "peer",
 		Version:   version,
+		// stateful set keeps identity and ledger data of node
+		Workload: ag.WorkloadStatefulSet,
 	})
 	if err != nil {
 		return errors.Wrap(err, "new app")
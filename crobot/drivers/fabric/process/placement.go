@@ -0,0 +1,108 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package process
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/core/pkg/ag"
+	"github.com/zibuyu28/cmapp/crobot/drivers/fabric/model"
+)
+
+// ordererGroup anti affinity group of orderers, only used if chain asks for orderer anti affinity
+const ordererGroup = "orderer"
+
+// default limit of node app if node spec has no cpu or memory
+const (
+	defaultCPU    = 1000
+	defaultMemory = 1024
+)
+
+// nodeLimit limit of node app, which is also the resource needs of node in placement
+func nodeLimit(cpu, memory int) ag.Limit {
+	l := ag.Limit{CPU: cpu, Memory: memory}
+	if l.CPU == 0 {
+		l.CPU = defaultCPU
+	}
+	if l.Memory == 0 {
+		l.Memory = defaultMemory
+	}
+	return l
+}
+
+// PlaceNodes ask core to pick machines for orderers and peers which has no machine id,
+// by machine tags and resource needs of node spec. Peers of the same organization will never
+// be placed on the same machine, orderers are spread over machines and only kept apart
+// if chain asks for it, so a single machine like a k8s cluster can hold all of them
+func PlaceNodes(ctx context.Context, coreHttpAddr string, chain *model.Fabric) error {
+	var nodes []ag.PlacementNode
+	for _, o := range chain.Orderers {
+		l := nodeLimit(o.CPU, o.Memory)
+		n := ag.PlacementNode{
+			Name:      o.UUID,
+			MachineID: o.MachineID,
+			Tags:      o.MachineTags,
+			CPU:       l.CPU,
+			Memory:    l.Memory,
+		}
+		if chain.OrdererAntiAffinity {
+			n.AntiAffinity = ordererGroup
+		}
+		nodes = append(nodes, n)
+	}
+	for _, p := range chain.Peers {
+		l := nodeLimit(p.CPU, p.Memory)
+		nodes = append(nodes, ag.PlacementNode{
+			Name:         p.UUID,
+			MachineID:    p.MachineID,
+			Tags:         p.MachineTags,
+			AntiAffinity: p.Organization.UUID,
+			CPU:          l.CPU,
+			Memory:       l.Memory,
+		})
+	}
+	needed := false
+	for _, n := range nodes {
+		if n.MachineID == 0 {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return nil
+	}
+	c := ag.Core{ApiVersion: ag.V1, CoreHttpAddr: coreHttpAddr}
+	res, err := c.Schedule(&ag.PlacementReq{Nodes: nodes})
+	if err != nil {
+		return errors.Wrap(err, "schedule nodes")
+	}
+	for i := range chain.Orderers {
+		o := &chain.Orderers[i]
+		if o.MachineID == 0 {
+			o.MachineID = res[o.UUID]
+			log.Infof(ctx, "Currently orderer [%s] is placed on machine [%d]", o.Name, o.MachineID)
+		}
+	}
+	for i := range chain.Peers {
+		p := &chain.Peers[i]
+		if p.MachineID == 0 {
+			p.MachineID = res[p.UUID]
+			log.Infof(ctx, "Currently peer [%s] is placed on machine [%d]", p.Name, p.MachineID)
+		}
+	}
+	return nil
+}
@@ -53,6 +53,11 @@ const (
 func CreateChain(ctx context.Context, info InitInfo, uuid, param string) error {
 	ctx, cancelFunc := context.WithCancel(ctx)
 	defer cancelFunc()
+	// core writes chain uuid and job id of the reports to its log
+	ctx = metadata.NewOutgoingContext(ctx, metadata.New(map[string]string{
+		log.FieldChainUUID: uuid,
+		log.FieldJobID:     log.FieldValue(ctx, log.FieldJobID),
+	}))
 	log.Debugf(ctx, "create chain process")
 	// 初始化链的参数
 	err := validator.New().Struct(&info)
@@ -97,7 +102,7 @@ func CreateChain(ctx context.Context, info InitInfo, uuid, param string) error {
 		return errors.Wrap(err, "get core grpc client")
 	}
 	log.Debugf(ctx, "init chain")
-	outgoingContext := metadata.NewOutgoingContext(ctx, metadata.New(map[string]string{"uuid": uuid}))
+	outgoingContext := metadata.AppendToOutgoingContext(ctx, "uuid", uuid)
 	chain, err := cmIns.InitChain(outgoingContext, &driver.Empty{})
 	if err != nil {
 		return errors.Wrap(err, "init chain")
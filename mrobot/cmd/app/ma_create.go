@@ -26,6 +26,7 @@ import (
 
 var param string
 var uuid string
+var jobID string
 
 // createCmd represents the ma command
 var createCmd = &cobra.Command{
@@ -38,8 +39,7 @@ Cobra is a CLI library for Go that empowers applications.
 This application is a tool to generate the needed files
 to quickly create a Cobra application.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
-		log.Debugf(ctx, "uuid : %s", uuid)
+		ctx := log.WithMachineUUID(log.WithJobID(context.Background(), jobID), uuid)
 		log.Debugf(ctx, "param : %s", param)
 		err := mengine.CreateMachine(ctx, uuid, param)
 		time.Sleep(time.Second)
@@ -60,4 +60,5 @@ func init() {
 	// is called directly, e.g.:
 	createCmd.Flags().StringVarP(&uuid, "uuid", "u", "", "the create machine's uuid")
 	createCmd.Flags().StringVarP(&param, "param", "p", "", "param to create action, format in json")
+	createCmd.Flags().StringVarP(&jobID, "job-id", "", "", "id of the job in core which runs this action, written to log")
 }
@@ -114,4 +114,11 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err == nil {
 		log.Infof(context.Background(), "Using config file:%s", viper.ConfigFileUsed())
 	}
+
+	// init logger if log config is set, otherwise keep the default one
+	if viper.IsSet("log") {
+		var lc log.Config
+		cobra.CheckErr(viper.UnmarshalKey("log", &lc))
+		cobra.CheckErr(log.Init(lc))
+	}
 }
@@ -36,6 +36,16 @@ func (c *Client) CreateIngress(ingress *v1beta1.Ingress) error {
 	return nil
 }
 
+// UpdateIngress update ingress
+func (c *Client) UpdateIngress(ingress *v1beta1.Ingress) error {
+	ingressClient := c.k.ExtensionsV1beta1().Ingresses(ingress.Namespace)
+	_, err := ingressClient.Update(c.ctx, ingress, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "update ingress [%s]", ingress.Name)
+	}
+	return nil
+}
+
 //GetIngressByName .
 func (c *Client) GetIngressByName(name, namespace string, ops metav1.GetOptions) (*v1beta1.Ingress, error) {
 	ingressClient := c.k.ExtensionsV1beta1().Ingresses(namespace)
@@ -46,8 +56,9 @@ func (c *Client) GetIngressByName(name, namespace string, ops metav1.GetOptions)
 	return redep, nil
 }
 
+// DeleteIngress .
 func (c *Client) DeleteIngress(ingress *v1beta1.Ingress, ops metav1.DeleteOptions) error {
-	ingressClient := c.k.CoreV1().PersistentVolumeClaims(ingress.Namespace)
+	ingressClient := c.k.ExtensionsV1beta1().Ingresses(ingress.Namespace)
 	err := ingressClient.Delete(c.ctx, ingress.Name, ops)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
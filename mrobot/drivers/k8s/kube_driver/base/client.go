@@ -27,7 +27,8 @@ import (
 )
 
 type Client struct {
-	k   *kubernetes.Clientset
+	k   kubernetes.Interface
+	cfg *rest.Config
 	ctx context.Context
 }
 
@@ -41,7 +42,7 @@ func NewClientInCluster(ctx context.Context) (*Client, error)  {
 	if err != nil {
 		return nil, errors.Wrap(err, "new kube client")
 	}
-	return &Client{k: kubeClient, ctx: ctx}, nil
+	return &Client{k: kubeClient, cfg: c, ctx: ctx}, nil
 }
 
 // NewClientByConfig new client by config
@@ -58,7 +59,13 @@ func NewClientByConfig(ctx context.Context,kubeConfig []byte) (*Client, error)
 	if err != nil {
 		return nil, errors.Wrap(err, "new kube client")
 	}
-	return &Client{k: kubeClient, ctx: ctx}, nil
+	return &Client{k: kubeClient, cfg: config, ctx: ctx}, nil
+}
+
+// NewClientByInterface new client by kube interface, such as fake clientset of client-go in test.
+// exec is not supported since there is no rest config
+func NewClientByInterface(ctx context.Context, k kubernetes.Interface) *Client {
+	return &Client{k: k, ctx: ctx}
 }
 
 // NewClientByAuth new client by auth
@@ -74,7 +81,7 @@ func NewClientByAuth(ctx context.Context,apiURL, token, cert string) (*Client, e
 	if err != nil {
 		return nil, errors.Wrap(err, "new client for config")
 	}
-	return &Client{k: kubeClient, ctx: ctx}, nil
+	return &Client{k: kubeClient, cfg: config, ctx: ctx}, nil
 }
 
 func newConfig(apiURL string, token string, caCert string) (*rest.Config, error) {
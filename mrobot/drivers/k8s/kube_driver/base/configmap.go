@@ -0,0 +1,50 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateConfigMap create config map, it is updated if exists
+func (c *Client) CreateConfigMap(cm *corev1.ConfigMap) error {
+	cms := c.k.CoreV1().ConfigMaps(cm.Namespace)
+	_, err := cms.Create(c.ctx, cm, metav1.CreateOptions{})
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "create config map [%s]", cm.Name)
+	}
+	_, err = cms.Update(c.ctx, cm, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "update config map [%s]", cm.Name)
+	}
+	return nil
+}
+
+// DeleteConfigMap delete config map, nothing happens if it not exists
+func (c *Client) DeleteConfigMap(cm *corev1.ConfigMap, ops metav1.DeleteOptions) error {
+	err := c.k.CoreV1().ConfigMaps(cm.Namespace).Delete(c.ctx, cm.Name, ops)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "delete config map [%s]", cm.Name)
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ package base
 
 import (
 	"context"
+	"fmt"
 	"github.com/pkg/errors"
 	"github.com/zibuyu28/cmapp/common/log"
 	"strings"
@@ -26,6 +27,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 //CreateDeployment .
@@ -61,6 +63,15 @@ func (c *Client) CreateDeployment(dep *appsv1.Deployment) error {
 	return nil
 }
 
+// GetDeployment get deployment by name
+func (c *Client) GetDeployment(name, namespace string) (*appsv1.Deployment, error) {
+	dep, err := c.k.AppsV1().Deployments(namespace).Get(c.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "get deployment [%s]", name)
+	}
+	return dep, nil
+}
+
 // CheckDeployment check deployment exist
 func (c *Client) CheckDeployment(dep *appsv1.Deployment) (r bool, err error) {
 	deploymentsClient := c.k.AppsV1().Deployments(dep.GetObjectMeta().GetNamespace())
@@ -160,3 +171,13 @@ func (c *Client) UpdateDeployment(dep *appsv1.Deployment) error {
 	}
 	return nil
 }
+
+//ScaleDeployment patch replicas of deployment
+func (c *Client) ScaleDeployment(name, namespace string, replicas int32) error {
+	patch := fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas)
+	_, err := c.k.AppsV1().Deployments(namespace).Patch(c.ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "scale deployment [%s]", name)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base
+
+import (
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DryRun validate object by api server without persisting it, object which exists is validated
+// by update. config map, secret, claim, service, stateful set and deployment are supported
+func (c *Client) DryRun(obj metav1.Object) error {
+	create := metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}
+	update := metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}}
+	var err error
+	switch o := obj.(type) {
+	case *corev1.ConfigMap:
+		cli := c.k.CoreV1().ConfigMaps(o.Namespace)
+		if _, err = cli.Create(c.ctx, o, create); apierrors.IsAlreadyExists(err) {
+			_, err = cli.Update(c.ctx, o, update)
+		}
+	case *corev1.Secret:
+		cli := c.k.CoreV1().Secrets(o.Namespace)
+		if _, err = cli.Create(c.ctx, o, create); apierrors.IsAlreadyExists(err) {
+			_, err = cli.Update(c.ctx, o, update)
+		}
+	case *corev1.PersistentVolumeClaim:
+		cli := c.k.CoreV1().PersistentVolumeClaims(o.Namespace)
+		if _, err = cli.Create(c.ctx, o, create); apierrors.IsAlreadyExists(err) {
+			_, err = cli.Update(c.ctx, o, update)
+		}
+	case *corev1.Service:
+		cli := c.k.CoreV1().Services(o.Namespace)
+		if _, err = cli.Create(c.ctx, o, create); apierrors.IsAlreadyExists(err) {
+			_, err = cli.Update(c.ctx, o, update)
+		}
+	case *appsv1.StatefulSet:
+		cli := c.k.AppsV1().StatefulSets(o.Namespace)
+		if _, err = cli.Create(c.ctx, o, create); apierrors.IsAlreadyExists(err) {
+			_, err = cli.Update(c.ctx, o, update)
+		}
+	case *appsv1.Deployment:
+		cli := c.k.AppsV1().Deployments(o.Namespace)
+		if _, err = cli.Create(c.ctx, o, create); apierrors.IsAlreadyExists(err) {
+			_, err = cli.Update(c.ctx, o, update)
+		}
+	default:
+		return errors.Errorf("dry run of [%T] not support", obj)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "dry run [%s]", obj.GetName())
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+	utilexec "k8s.io/utils/exec"
+	"net/http"
+)
+
+// ExecOptions options to exec command in container of pod
+type ExecOptions struct {
+	// Ctx stop the exec once done, optional
+	Ctx       context.Context
+	Namespace string
+	Pod       string
+	Container string
+	Command   []string
+	Stdin     io.Reader
+	Stdout    io.Writer
+	Stderr    io.Writer
+	TTY       bool
+	// SizeQueue resize events of tty
+	SizeQueue remotecommand.TerminalSizeQueue
+}
+
+// Exec exec command in container of pod, exit code of command is returned
+func (c *Client) Exec(opts *ExecOptions) (int, error) {
+	if c.cfg == nil {
+		return -1, errors.New("exec not support, client has no rest config")
+	}
+	req := c.k.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(opts.Namespace).
+		Name(opts.Pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: opts.Container,
+			Command:   opts.Command,
+			Stdin:     opts.Stdin != nil,
+			Stdout:    opts.Stdout != nil,
+			Stderr:    opts.Stderr != nil && !opts.TTY,
+			TTY:       opts.TTY,
+		}, scheme.ParameterCodec)
+	transport, upgrader, err := spdy.RoundTripperFor(c.cfg)
+	if err != nil {
+		return -1, errors.Wrapf(err, "new round tripper of pod [%s]", opts.Pod)
+	}
+	if opts.Ctx != nil {
+		upgrader = &ctxUpgrader{ctx: opts.Ctx, Upgrader: upgrader}
+	}
+	executor, err := remotecommand.NewSPDYExecutorForTransports(transport, upgrader, "POST", req.URL())
+	if err != nil {
+		return -1, errors.Wrapf(err, "new executor of pod [%s]", opts.Pod)
+	}
+	so := remotecommand.StreamOptions{
+		Stdin:             opts.Stdin,
+		Stdout:            opts.Stdout,
+		Tty:               opts.TTY,
+		TerminalSizeQueue: opts.SizeQueue,
+	}
+	if !opts.TTY {
+		so.Stderr = opts.Stderr
+	}
+	err = executor.Stream(so)
+	if err != nil {
+		if opts.Ctx != nil && opts.Ctx.Err() != nil {
+			return -1, errors.Wrapf(opts.Ctx.Err(), "exec command in pod [%s]", opts.Pod)
+		}
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) && exitErr.Exited() {
+			return exitErr.ExitStatus(), nil
+		}
+		return -1, errors.Wrapf(err, "exec command in pod [%s]", opts.Pod)
+	}
+	return 0, nil
+}
+
+// ctxUpgrader close the stream connection once ctx done, client-go of this
+// version has no StreamWithContext, so closing connection is the only way to
+// make Stream return
+type ctxUpgrader struct {
+	spdy.Upgrader
+	ctx context.Context
+}
+
+// NewConnection watch ctx until connection closed
+func (u *ctxUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	conn, err := u.Upgrader.NewConnection(resp)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		select {
+		case <-u.ctx.Done():
+			_ = conn.Close()
+		case <-conn.CloseChan():
+		}
+	}()
+	return conn, nil
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package base
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"regexp"
+	"strings"
+)
+
+// IsolatedLabel label of namespace prepared for apps with the same value of namespace tag
+const IsolatedLabel = "cmapp.io/isolated"
+
+var invalidNameChars = regexp.MustCompile("[^a-z0-9-]+")
+
+// TagNamespace namespace derived from value of namespace tag, like '<namespace>-<tag value>'
+func TagNamespace(namespace, value string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(fmt.Sprintf("%s-%s", namespace, value)), "-")
+	if len(name) > validation.DNS1123LabelMaxLength {
+		name = name[:validation.DNS1123LabelMaxLength]
+	}
+	return strings.Trim(name, "-")
+}
+
+// GetNamespace get namespace
+func (c *Client) GetNamespace(name string) (*corev1.Namespace, error) {
+	return c.k.CoreV1().Namespaces().Get(c.ctx, name, metav1.GetOptions{})
+}
+
+// CreateNamespace create namespace, it is kept if exists
+func (c *Client) CreateNamespace(ns *corev1.Namespace) error {
+	_, err := c.k.CoreV1().Namespaces().Create(c.ctx, ns, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "create namespace [%s]", ns.Name)
+	}
+	return nil
+}
+
+// CreateResourceQuota create resource quota, it is updated if exists
+func (c *Client) CreateResourceQuota(rq *corev1.ResourceQuota) error {
+	quotas := c.k.CoreV1().ResourceQuotas(rq.Namespace)
+	old, err := quotas.Get(c.ctx, rq.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "get resource quota [%s]", rq.Name)
+		}
+		_, err = quotas.Create(c.ctx, rq, metav1.CreateOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "create resource quota [%s]", rq.Name)
+		}
+		return nil
+	}
+	old.Spec = rq.Spec
+	_, err = quotas.Update(c.ctx, old, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "update resource quota [%s]", rq.Name)
+	}
+	return nil
+}
+
+// CreateNetworkPolicy create network policy, it is updated if exists
+func (c *Client) CreateNetworkPolicy(np *networkingv1.NetworkPolicy) error {
+	policies := c.k.NetworkingV1().NetworkPolicies(np.Namespace)
+	old, err := policies.Get(c.ctx, np.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "get network policy [%s]", np.Name)
+		}
+		_, err = policies.Create(c.ctx, np, metav1.CreateOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "create network policy [%s]", np.Name)
+		}
+		return nil
+	}
+	old.Spec = np.Spec
+	_, err = policies.Update(c.ctx, old, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "update network policy [%s]", np.Name)
+	}
+	return nil
+}
+
+// DeleteNetworkPolicy delete network policy, not found is ignored
+func (c *Client) DeleteNetworkPolicy(name, namespace string) error {
+	err := c.k.NetworkingV1().NetworkPolicies(namespace).Delete(c.ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "delete network policy [%s]", name)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base
+
+import (
+	"github.com/pkg/errors"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ListPods list pods in namespace, empty namespace means all namespaces
+func (c *Client) ListPods(namespace string) ([]corev1.Pod, error) {
+	list, err := c.k.CoreV1().Pods(namespace).List(c.ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list pods in namespace [%s]", namespace)
+	}
+	return list.Items, nil
+}
+
+// ListPodsByLabels list pods in namespace which have all the labels
+func (c *Client) ListPodsByLabels(namespace string, ls map[string]string) ([]corev1.Pod, error) {
+	list, err := c.k.CoreV1().Pods(namespace).List(c.ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(ls).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list pods in namespace [%s] by labels [%v]", namespace, ls)
+	}
+	return list.Items, nil
+}
+
+// PodLogs stream logs of pod
+func (c *Client) PodLogs(namespace, pod string, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+	rc, err := c.k.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream(c.ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "stream logs of pod [%s]", pod)
+	}
+	return rc, nil
+}
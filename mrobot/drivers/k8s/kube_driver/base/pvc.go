@@ -27,6 +27,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 //CreatePersistentVolumeClaim .
@@ -163,3 +164,23 @@ func (c *Client) DeletePersistentVolumeClaim(pvc *corev1.PersistentVolumeClaim,
 		return errors.New("pvc delete state unknown with context deadline")
 	}
 }
+
+// ListPersistentVolumeClaimsByLabels list pvc which have all the labels
+func (c *Client) ListPersistentVolumeClaimsByLabels(namespace string, ls map[string]string) ([]corev1.PersistentVolumeClaim, error) {
+	list, err := c.k.CoreV1().PersistentVolumeClaims(namespace).List(c.ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(ls).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list pvc in namespace [%s] by labels [%v]", namespace, ls)
+	}
+	return list.Items, nil
+}
+
+// UpdatePersistentVolumeClaim update pvc, only requested storage can be increased for bound pvc
+func (c *Client) UpdatePersistentVolumeClaim(pvc *corev1.PersistentVolumeClaim) error {
+	_, err := c.k.CoreV1().PersistentVolumeClaims(pvc.Namespace).Update(c.ctx, pvc, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "update pvc [%s]", pvc.Name)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package base
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateServiceAccount create service account, it is kept if exists
+func (c *Client) CreateServiceAccount(sa *corev1.ServiceAccount) error {
+	_, err := c.k.CoreV1().ServiceAccounts(sa.Namespace).Create(c.ctx, sa, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "create service account [%s]", sa.Name)
+	}
+	return nil
+}
+
+// CreateRole create role, rules are updated if exists
+func (c *Client) CreateRole(role *rbacv1.Role) error {
+	roles := c.k.RbacV1().Roles(role.Namespace)
+	_, err := roles.Create(c.ctx, role, metav1.CreateOptions{})
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "create role [%s]", role.Name)
+	}
+	_, err = roles.Update(c.ctx, role, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "update role [%s]", role.Name)
+	}
+	return nil
+}
+
+// CreateRoleBinding create role binding, it is kept if exists since role ref can not be updated
+func (c *Client) CreateRoleBinding(rb *rbacv1.RoleBinding) error {
+	_, err := c.k.RbacV1().RoleBindings(rb.Namespace).Create(c.ctx, rb, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "create role binding [%s]", rb.Name)
+	}
+	return nil
+}
+
+// CreateClusterRole create cluster role, rules are updated if exists
+func (c *Client) CreateClusterRole(role *rbacv1.ClusterRole) error {
+	roles := c.k.RbacV1().ClusterRoles()
+	_, err := roles.Create(c.ctx, role, metav1.CreateOptions{})
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "create cluster role [%s]", role.Name)
+	}
+	_, err = roles.Update(c.ctx, role, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "update cluster role [%s]", role.Name)
+	}
+	return nil
+}
+
+// CreateClusterRoleBinding create cluster role binding, it is kept if exists
+func (c *Client) CreateClusterRoleBinding(rb *rbacv1.ClusterRoleBinding) error {
+	_, err := c.k.RbacV1().ClusterRoleBindings().Create(c.ctx, rb, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "create cluster role binding [%s]", rb.Name)
+	}
+	return nil
+}
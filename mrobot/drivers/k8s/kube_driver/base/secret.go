@@ -18,9 +18,13 @@ package base
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"github.com/pkg/errors"
 	"github.com/zibuyu28/cmapp/common/log"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"strings"
 	"time"
@@ -31,6 +35,44 @@ func (c *Client) GetSecretByName(namespace, name string, ops metav1.GetOptions)
 	return c.k.CoreV1().Secrets(namespace).Get(c.ctx, name, ops)
 }
 
+// CreateSecret create secret, it is updated if exists
+func (c *Client) CreateSecret(se *corev1.Secret) error {
+	secrets := c.k.CoreV1().Secrets(se.Namespace)
+	_, err := secrets.Create(c.ctx, se, metav1.CreateOptions{})
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "create secret [%s]", se.Name)
+	}
+	_, err = secrets.Update(c.ctx, se, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "update secret [%s]", se.Name)
+	}
+	return nil
+}
+
+// PullSecret docker registry secret for pulling image from the registry server
+func PullSecret(name, namespace string, labels map[string]string, server, username, password, email string) (*corev1.Secret, error) {
+	auth := map[string]interface{}{
+		"username": username,
+		"password": password,
+		"auth":     base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", username, password))),
+	}
+	if len(email) != 0 {
+		auth["email"] = email
+	}
+	cfg, err := json.Marshal(map[string]interface{}{"auths": map[string]interface{}{server: auth}})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal docker config")
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: cfg},
+	}, nil
+}
+
 func (c *Client) DeleteSecret(se *corev1.Secret, ops metav1.DeleteOptions) error {
 	serectsClient := c.k.CoreV1().Secrets(se.Namespace)
 	err := serectsClient.Delete(c.ctx, se.Name, ops)
@@ -80,3 +80,12 @@ func (c *Client) UpdateService(service *corev1.Service) error {
 
 	return nil
 }
+
+// ListServices list services in namespace, empty namespace means all namespaces
+func (c *Client) ListServices(namespace string) ([]corev1.Service, error) {
+	list, err := c.k.CoreV1().Services(namespace).List(c.ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list services in namespace [%s]", namespace)
+	}
+	return list.Items, nil
+}
@@ -18,6 +18,7 @@ package base
 
 import (
 	"context"
+	"fmt"
 	"github.com/pkg/errors"
 	"github.com/zibuyu28/cmapp/common/log"
 	"strings"
@@ -26,6 +27,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 //GetStatefulSetByName .
@@ -157,3 +159,28 @@ func (c *Client) DeleteStatefulSet(sfs *appsv1.StatefulSet, ops metav1.DeleteOpt
 		return errors.New("stateful set delete state unknown with context deadline")
 	}
 }
+
+//UpdateStatefulSet .
+func (c *Client) UpdateStatefulSet(sfs *appsv1.StatefulSet) error {
+	sfsClient := c.k.AppsV1().StatefulSets(sfs.Namespace)
+	old, err := sfsClient.Get(c.ctx, sfs.GetObjectMeta().GetName(), metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "get stateful set [%s]", sfs.Name)
+	}
+	sfs.ResourceVersion = old.ResourceVersion
+	_, err = sfsClient.Update(c.ctx, sfs, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrap(err, "update stateful set")
+	}
+	return nil
+}
+
+//ScaleStatefulSet patch replicas of stateful set
+func (c *Client) ScaleStatefulSet(name, namespace string, replicas int32) error {
+	patch := fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas)
+	_, err := c.k.AppsV1().StatefulSets(namespace).Patch(c.ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "scale stateful set [%s]", name)
+	}
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/zibuyu28/cmapp/common/tmp"
 	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
 	"github.com/zibuyu28/cmapp/mrobot/pkg"
+	"github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/core"
 	"github.com/zibuyu28/cmapp/plugin/proto/driver"
 	"google.golang.org/grpc/metadata"
 	v1 "k8s.io/api/apps/v1"
@@ -64,6 +65,14 @@ type DriverK8s struct {
 
 	StorageClassName string `validate:"required"`
 	Labels           map[string]string
+	// ImagePullPolicy pull policy of agent image
+	ImagePullPolicy string `validate:"omitempty,oneof=Always IfNotPresent Never"`
+	// NamespaceTag apps are placed in namespace derived from value of this tag, all apps are in Namespace if empty
+	NamespaceTag string
+	// NamespaceTagValues values of namespace tag, namespaces of them are prepared for agent
+	NamespaceTagValues []string `validate:"required_with=NamespaceTag"`
+	// NamespaceQuota hard quota of namespace derived from tag
+	NamespaceQuota string
 }
 
 func NewDriverK8s() {
@@ -104,6 +113,30 @@ func (d *DriverK8s) GetCreateFlags(ctx context.Context, empty *driver.Empty) (*d
 			EnvVar: "KUBE_LABELS",
 			Value:  nil,
 		},
+		{
+			Name:   "ImagePullPolicy",
+			Usage:  "pull policy of agent image, one of [Always, IfNotPresent, Never]",
+			EnvVar: "KUBE_IMAGE_PULL_POLICY",
+			Value:  []string{string(corev1.PullIfNotPresent)},
+		},
+		{
+			Name:   "NamespaceTag",
+			Usage:  "tag of app to place apps with different values in different namespaces, like \"chain_uuid\"",
+			EnvVar: "KUBE_NAMESPACE_TAG",
+			Value:  nil,
+		},
+		{
+			Name:   "NamespaceTagValues",
+			Usage:  "values of namespace tag, namespace of each value is created with machine, format like \"chain1,chain2\"",
+			EnvVar: "KUBE_NAMESPACE_TAG_VALUES",
+			Value:  nil,
+		},
+		{
+			Name:   "NamespaceQuota",
+			Usage:  "hard quota of namespace created for tag, format like \"pods=100,requests.storage=100Gi\"",
+			EnvVar: "KUBE_NAMESPACE_QUOTA",
+			Value:  []string{"pods=100"},
+		},
 	}
 	baseFlags.Flags = append(baseFlags.Flags, flags...)
 	return baseFlags, nil
@@ -115,6 +148,7 @@ func (d *DriverK8s) SetConfigFromFlags(ctx context.Context, flags *driver.Flags)
 	d.CoreAddr = m["CoreAddr"]
 	d.CoreHTTPAddr = m["CoreHTTPAddr"]
 	d.CoreGRPCAddr = m["CoreGRPCAddr"]
+	d.CoreAgentToken = m["CoreAgentToken"]
 	d.ImageRepository.Repository = m["Repository"]
 	d.ImageRepository.StorePath = m["StorePath"]
 
@@ -122,6 +156,18 @@ func (d *DriverK8s) SetConfigFromFlags(ctx context.Context, flags *driver.Flags)
 	d.NodeIP = m["NodeIP"]
 	d.Namespace = m["Namespace"]
 	d.StorageClassName = m["StorageClassName"]
+	d.ImagePullPolicy = m["ImagePullPolicy"]
+	if len(d.ImagePullPolicy) == 0 {
+		d.ImagePullPolicy = string(corev1.PullIfNotPresent)
+	}
+	d.NamespaceTag = m["NamespaceTag"]
+	d.NamespaceQuota = m["NamespaceQuota"]
+	d.NamespaceTagValues = nil
+	for _, value := range strings.Split(m["NamespaceTagValues"], ",") {
+		if value = strings.TrimSpace(value); len(value) != 0 {
+			d.NamespaceTagValues = append(d.NamespaceTagValues, value)
+		}
+	}
 	labels := m["Labels"]
 	d.Labels = make(map[string]string)
 	kvs := strings.Split(labels, ",")
@@ -138,6 +184,10 @@ func (d *DriverK8s) SetConfigFromFlags(ctx context.Context, flags *driver.Flags)
 	if err != nil {
 		return nil, errors.Wrap(err, "validate param")
 	}
+	_, err = parseQuota(d.NamespaceQuota)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse namespace quota")
+	}
 
 	driverName := os.Getenv(PluginEnvDriverName)
 	if len(driverName) == 0 {
@@ -240,16 +290,38 @@ func (d *DriverK8s) InstallMRobot(ctx context.Context, m *driver.Machine) (*driv
 
 	log.Debugf(ctx, "Currently image info [%v]", image)
 
+	labels := map[string]string{"app": uuiddata[0], "machine_id": fmt.Sprintf("%d", coreID)}
+
+	// agent image may be in private registry, credential is stored in core
+	var pullSecret string
+	cred, err := core.RegistryCredentialByAddr(ctx, d.CoreHTTPAddr, d.CoreAgentToken, image)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get registry credential of image [%s]", image)
+	}
+	if cred != nil {
+		se, err := base.PullSecret(fmt.Sprintf("%s-pull", uuiddata[0]), d.Namespace, labels,
+			cred.Server, cred.Username, cred.Password, cred.Email)
+		if err != nil {
+			return nil, errors.Wrap(err, "build pull secret")
+		}
+		err = c.CreateSecret(se)
+		if err != nil {
+			return nil, errors.Wrap(err, "create pull secret")
+		}
+		pullSecret = se.Name
+	}
+
 	const (
 		DriAgentNamespace    = "DRIAGENT_NAMESPACE"
-		DriAgentKubeConfig   = "DRIAGENT_KUBECONFIG"
 		DriAgentNodeIP       = "DRIAGENT_NODEIP"
 		DriAgentStorageClass = "DRIAGENT_STORAGECLASS"
 		DriAgentK8sUUID      = "DRIAGENT_K8SUUID"
 		DriAgentDomain       = "DRIAGENT_DOMAIN"
 		DriAgentMachineID    = "DRIAGENT_MACHINE_ID"
+		DriAgentNsTag        = "DRIAGENT_NAMESPACE_TAG"
 		DriCoreHttpAddr      = "DRIAGENT_CORE_HTTP_ADDR"
 		DriCoreGrpcAddr      = "DRIAGENT_CORE_GRPC_ADDR"
+		DriCoreAgentToken    = "DRIAGENT_CORE_AGENT_TOKEN"
 	)
 
 	mrobotEnvs := map[string]string{
@@ -257,27 +329,54 @@ func (d *DriverK8s) InstallMRobot(ctx context.Context, m *driver.Machine) (*driv
 		DriAgentNamespace:    d.Namespace,
 		DriAgentNodeIP:       d.NodeIP,
 		DriAgentStorageClass: d.StorageClassName,
-		DriAgentKubeConfig:   d.KubeConfigBase64,
 		DriCoreHttpAddr:      d.CoreHTTPAddr,
 		DriCoreGrpcAddr:      d.CoreGRPCAddr,
+		DriCoreAgentToken:    d.CoreAgentToken,
 		DriAgentK8sUUID:      datas[0],
 		DriAgentDomain:       "test-domain",
+		DriAgentNsTag:        d.NamespaceTag,
 		AgentPluginBuildIn:   "true",
 		AgentPluginName:      "k8s",
 	}
 
+	// agent runs with its own service account, kube config of driver is not passed to it
+	identity := newAgentIdentity(uuiddata[0], d.Namespace, labels, len(d.NamespaceTag) != 0)
+	err = identity.apply(ctx, c)
+	if err != nil {
+		return nil, errors.Wrap(err, "create identity of agent")
+	}
+	// agent can not create namespaces, so namespaces of tag values are prepared here
+	if identity.NamespaceRole != nil {
+		quota, err := parseQuota(d.NamespaceQuota)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse namespace quota")
+		}
+		for _, value := range d.NamespaceTagValues {
+			err = newTagNamespace(d.Namespace, d.NamespaceTag, value, labels, quota, identity).apply(ctx, c)
+			if err != nil {
+				return nil, errors.Wrapf(err, "prepare namespace of tag [%s=%s]", d.NamespaceTag, value)
+			}
+		}
+	}
+
 	tempdata := struct {
-		ImageName string
-		MachineID int
-		Env       map[string]string
-		Namespace string
-		UUID      string
+		ImageName       string
+		ImagePullPolicy string
+		PullSecret      string
+		ServiceAccount  string
+		MachineID       int
+		Env             map[string]string
+		Namespace       string
+		UUID            string
 	}{
-		ImageName: image,
-		MachineID: coreID,
-		Env:       mrobotEnvs,
-		Namespace: d.Namespace,
-		UUID:      uuiddata[0],
+		ImageName:       image,
+		ImagePullPolicy: d.ImagePullPolicy,
+		PullSecret:      pullSecret,
+		ServiceAccount:  identity.ServiceAccount.Name,
+		MachineID:       coreID,
+		Env:             mrobotEnvs,
+		Namespace:       d.Namespace,
+		UUID:            uuiddata[0],
 	}
 
 	mrobotDepYaml, err := tmp.AdvanceTemplate(tempdata, []byte(mRobotDep))
@@ -376,10 +475,13 @@ spec:
         app: {{.UUID}}
         machine_id: {{.MachineID}}
     spec:
+      serviceAccountName: {{.ServiceAccount}}{{ if .PullSecret }}
+      imagePullSecrets:
+        - name: {{.PullSecret}}{{ end }}
       containers:
         - name: kw
           image: {{.ImageName}}
-          imagePullPolicy: IfNotPresent
+          imagePullPolicy: {{.ImagePullPolicy}}
           resources:
             requests:
               cpu: "100m"
@@ -0,0 +1,136 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package loc_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"strings"
+)
+
+// defaultNamespaceQuota quota of namespace if it is not set
+const defaultNamespaceQuota = "pods=100"
+
+// tagNamespace namespace prepared for apps with the same value of namespace tag, it is created
+// by driver since agent is not allowed to create namespaces or bind roles
+type tagNamespace struct {
+	Namespace   *corev1.Namespace
+	RoleBinding *rbacv1.RoleBinding
+	Quota       *corev1.ResourceQuota
+	Isolation   *networkingv1.NetworkPolicy
+}
+
+// newTagNamespace namespace of tag value with quota and isolation policy, namespace role of
+// agent is bound to its service account in it
+func newTagNamespace(namespace, tag, value string, labels map[string]string, quota corev1.ResourceList, id *agentIdentity) *tagNamespace {
+	name := base.TagNamespace(namespace, value)
+	nsLabels := map[string]string{base.IsolatedLabel: "true"}
+	for k, v := range labels {
+		nsLabels[k] = v
+	}
+	if len(validation.IsQualifiedName(tag)) == 0 && len(validation.IsValidLabelValue(value)) == 0 {
+		nsLabels[tag] = value
+	}
+	return &tagNamespace{
+		Namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nsLabels}},
+		RoleBinding: &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: id.NamespaceRole.Name, Namespace: name, Labels: labels},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: id.ServiceAccount.Name, Namespace: namespace}},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: id.NamespaceRole.Name},
+		},
+		Quota: &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-quota", name), Namespace: name, Labels: labels},
+			Spec:       corev1.ResourceQuotaSpec{Hard: quota},
+		},
+		Isolation: isolationPolicy(name, labels),
+	}
+}
+
+// apply create namespace, permissions of agent in it, quota and isolation policy
+func (t *tagNamespace) apply(ctx context.Context, c *base.Client) error {
+	log.Debugf(ctx, "Currently start to create namespace [%s] for tag", t.Namespace.Name)
+	err := c.CreateNamespace(t.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "create namespace")
+	}
+	err = c.CreateRoleBinding(t.RoleBinding)
+	if err != nil {
+		return errors.Wrap(err, "create role binding")
+	}
+	err = c.CreateResourceQuota(t.Quota)
+	if err != nil {
+		return errors.Wrap(err, "create resource quota")
+	}
+	err = c.CreateNetworkPolicy(t.Isolation)
+	if err != nil {
+		return errors.Wrap(err, "create network policy")
+	}
+	return nil
+}
+
+// isolationPolicy pods in namespace only accept traffic from the same namespace and namespaces
+// which are not isolated, so apps of different tags can not reach each other. Exposed ports
+// are allowed by exposed policy of each app, which is created by agent
+func isolationPolicy(ns string, labels map[string]string) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-isolation", ns), Namespace: ns, Labels: labels},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{}},
+						{NamespaceSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: base.IsolatedLabel, Operator: metav1.LabelSelectorOpDoesNotExist},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// parseQuota parse quota like 'pods=100,requests.storage=100Gi'
+func parseQuota(s string) (corev1.ResourceList, error) {
+	if len(s) == 0 {
+		s = defaultNamespaceQuota
+	}
+	quota := corev1.ResourceList{}
+	for _, kv := range strings.Split(s, ",") {
+		kav := strings.SplitN(strings.TrimSpace(kv), "=", 2)
+		if len(kav) != 2 {
+			return nil, errors.Errorf("quota [%s] not correct, format like 'pods=100'", kv)
+		}
+		q, err := resource.ParseQuantity(kav[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse quantity of quota [%s]", kv)
+		}
+		quota[corev1.ResourceName(kav[0])] = q
+	}
+	return quota, nil
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package loc_dri
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"testing"
+)
+
+func TestParseQuota(t *testing.T) {
+	quota, err := parseQuota("")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100), quota.Pods().Value())
+
+	quota, err = parseQuota("pods=20, requests.storage=100Gi")
+	assert.Nil(t, err)
+	assert.Len(t, quota, 2)
+	assert.Equal(t, int64(20), quota.Pods().Value())
+
+	_, err = parseQuota("pods")
+	assert.NotNil(t, err)
+	_, err = parseQuota("pods=x")
+	assert.NotNil(t, err)
+}
+
+func TestNewTagNamespace(t *testing.T) {
+	ctx := context.Background()
+	id := newAgentIdentity("u1", "cmapp", nil, true)
+	quota, err := parseQuota("")
+	assert.Nil(t, err)
+	tn := newTagNamespace("cmapp", "chain", "C1", map[string]string{"machine_id": "3"}, quota, id)
+	assert.Equal(t, "cmapp-c1", tn.Namespace.Name)
+	assert.Equal(t, map[string]string{base.IsolatedLabel: "true", "machine_id": "3", "chain": "C1"}, tn.Namespace.Labels)
+	assert.Equal(t, "cmapp-c1", tn.RoleBinding.Namespace)
+	assert.Equal(t, "u1-agent-ns", tn.RoleBinding.RoleRef.Name)
+	assert.Equal(t, "u1-agent", tn.RoleBinding.Subjects[0].Name)
+	assert.Equal(t, "cmapp", tn.RoleBinding.Subjects[0].Namespace)
+	assert.Equal(t, "cmapp-c1", tn.Quota.Namespace)
+
+	fc := fake.NewSimpleClientset()
+	cli := base.NewClientByInterface(ctx, fc)
+	assert.Nil(t, tn.apply(ctx, cli))
+	assert.Nil(t, tn.apply(ctx, cli), "prepared namespace is kept")
+	_, err = fc.RbacV1().RoleBindings("cmapp-c1").Get(ctx, "u1-agent-ns", metav1.GetOptions{})
+	assert.Nil(t, err)
+	_, err = fc.NetworkingV1().NetworkPolicies("cmapp-c1").Get(ctx, "cmapp-c1-isolation", metav1.GetOptions{})
+	assert.Nil(t, err)
+}
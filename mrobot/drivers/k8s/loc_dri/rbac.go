@@ -0,0 +1,155 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package loc_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	readVerbs  = []string{"get", "list", "watch"}
+	writeVerbs = []string{"get", "list", "watch", "create", "update", "delete"}
+)
+
+// agentRules permissions of worker in its namespace
+var agentRules = []rbacv1.PolicyRule{
+	{APIGroups: []string{""}, Resources: []string{"services", "configmaps", "secrets", "persistentvolumeclaims"}, Verbs: writeVerbs},
+	{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list", "watch", "delete"}},
+	{APIGroups: []string{""}, Resources: []string{"pods/log"}, Verbs: []string{"get"}},
+	{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"get", "create"}},
+	// workloads are scaled by patching replicas
+	{APIGroups: []string{"apps"}, Resources: []string{"deployments", "statefulsets"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+	{APIGroups: []string{"extensions", "networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: writeVerbs},
+}
+
+// agentClusterRules read only permissions of worker out of its namespace. Resources of
+// cluster are summed from nodes and pods, free node port is picked from services of all
+// namespaces, and storage class of volume is checked
+var agentClusterRules = []rbacv1.PolicyRule{
+	{APIGroups: []string{""}, Resources: []string{"nodes", "pods", "services"}, Verbs: readVerbs},
+	{APIGroups: []string{"storage.k8s.io"}, Resources: []string{"storageclasses"}, Verbs: readVerbs},
+}
+
+// agentTagNamespaceRules permissions of worker in namespace prepared for tag value, network
+// policy of exposed ports is managed besides apps
+var agentTagNamespaceRules = append(append([]rbacv1.PolicyRule{}, agentRules...),
+	rbacv1.PolicyRule{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"networkpolicies"}, Verbs: writeVerbs},
+)
+
+// agentNamespaceRules read only permissions of worker which places apps in namespaces derived
+// from tag. Namespaces and the bindings of namespace role in them are created by the driver,
+// worker only checks namespace is prepared before placing app in it
+var agentNamespaceRules = []rbacv1.PolicyRule{
+	{APIGroups: []string{""}, Resources: []string{"namespaces"}, Verbs: readVerbs},
+}
+
+// agentName name of service account, roles and bindings of agent
+func agentName(uuid string) string {
+	return fmt.Sprintf("%s-agent", uuid)
+}
+
+// agentNamespaceRoleName name of cluster role bound in namespaces derived from tag
+func agentNamespaceRoleName(uuid string) string {
+	return fmt.Sprintf("%s-agent-ns", uuid)
+}
+
+// agentIdentity service account of agent and roles bound to it
+type agentIdentity struct {
+	ServiceAccount     *corev1.ServiceAccount
+	Role               *rbacv1.Role
+	RoleBinding        *rbacv1.RoleBinding
+	ClusterRole        *rbacv1.ClusterRole
+	ClusterRoleBinding *rbacv1.ClusterRoleBinding
+	// NamespaceRole permissions in namespace derived from tag, it is not bound by agent
+	// identity but by role binding created with the namespace by driver, nil if agent is not
+	// isolated
+	NamespaceRole *rbacv1.ClusterRole
+}
+
+// newAgentIdentity identity of agent in namespace, cluster role is named with uuid of agent
+// since it is not in namespace. Agent which places apps in namespaces derived from tag gets
+// write permissions only in namespaces prepared by driver, it can not create namespaces or
+// role bindings
+func newAgentIdentity(uuid, namespace string, labels map[string]string, isolated bool) *agentIdentity {
+	name := agentName(uuid)
+	clusterRules := agentClusterRules
+	var nsRole *rbacv1.ClusterRole
+	if isolated {
+		nsRole = &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: agentNamespaceRoleName(uuid), Labels: labels},
+			Rules:      agentTagNamespaceRules,
+		}
+		clusterRules = append(append([]rbacv1.PolicyRule{}, agentClusterRules...), agentNamespaceRules...)
+	}
+	meta := metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}
+	clusterMeta := metav1.ObjectMeta{Name: name, Labels: labels}
+	subjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: namespace}}
+	return &agentIdentity{
+		NamespaceRole:  nsRole,
+		ServiceAccount: &corev1.ServiceAccount{ObjectMeta: meta},
+		Role:           &rbacv1.Role{ObjectMeta: meta, Rules: agentRules},
+		RoleBinding: &rbacv1.RoleBinding{
+			ObjectMeta: meta,
+			Subjects:   subjects,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name},
+		},
+		ClusterRole: &rbacv1.ClusterRole{ObjectMeta: clusterMeta, Rules: clusterRules},
+		ClusterRoleBinding: &rbacv1.ClusterRoleBinding{
+			ObjectMeta: clusterMeta,
+			Subjects:   subjects,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: name},
+		},
+	}
+}
+
+// apply create identity of agent
+func (a *agentIdentity) apply(ctx context.Context, c *base.Client) error {
+	log.Debugf(ctx, "Currently start to create service account [%s] of agent", a.ServiceAccount.Name)
+	err := c.CreateServiceAccount(a.ServiceAccount)
+	if err != nil {
+		return errors.Wrap(err, "create service account")
+	}
+	err = c.CreateRole(a.Role)
+	if err != nil {
+		return errors.Wrap(err, "create role")
+	}
+	err = c.CreateRoleBinding(a.RoleBinding)
+	if err != nil {
+		return errors.Wrap(err, "create role binding")
+	}
+	err = c.CreateClusterRole(a.ClusterRole)
+	if err != nil {
+		return errors.Wrap(err, "create cluster role")
+	}
+	err = c.CreateClusterRoleBinding(a.ClusterRoleBinding)
+	if err != nil {
+		return errors.Wrap(err, "create cluster role binding")
+	}
+	if a.NamespaceRole != nil {
+		err = c.CreateClusterRole(a.NamespaceRole)
+		if err != nil {
+			return errors.Wrap(err, "create namespace role")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package loc_dri
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewAgentIdentity(t *testing.T) {
+	id := newAgentIdentity("u1", "ns", nil, false)
+	assert.Equal(t, "u1-agent", id.ServiceAccount.Name)
+	assert.Equal(t, "ns", id.Role.Namespace)
+	for _, s := range append(id.RoleBinding.Subjects, id.ClusterRoleBinding.Subjects...) {
+		assert.Equal(t, id.ServiceAccount.Name, s.Name)
+		assert.Equal(t, "ns", s.Namespace)
+	}
+	assert.Equal(t, id.Role.Name, id.RoleBinding.RoleRef.Name)
+	assert.Equal(t, id.ClusterRole.Name, id.ClusterRoleBinding.RoleRef.Name)
+	// permissions out of namespace are read only
+	for _, r := range id.ClusterRole.Rules {
+		assert.Equal(t, readVerbs, r.Verbs)
+	}
+}
+
+func TestNewAgentIdentityIsolated(t *testing.T) {
+	id := newAgentIdentity("u1", "ns", nil, true)
+	assert.Equal(t, agentRules, id.Role.Rules)
+	// cluster role is still read only, namespaces and bindings of namespace role are created
+	// by driver, apps are managed by the namespace role bound in each namespace
+	var namespaces bool
+	for _, r := range id.ClusterRole.Rules {
+		assert.Equal(t, readVerbs, r.Verbs, "resource [%s]", r.Resources[0])
+		assert.NotContains(t, r.Resources, "rolebindings")
+		assert.NotContains(t, r.Resources, "clusterroles")
+		if r.Resources[0] == "namespaces" {
+			namespaces = true
+		}
+	}
+	assert.True(t, namespaces)
+	assert.Equal(t, "u1-agent-ns", id.NamespaceRole.Name)
+	assert.Equal(t, agentTagNamespaceRules, id.NamespaceRole.Rules)
+	assert.Nil(t, newAgentIdentity("u1", "ns", nil, false).NamespaceRole)
+}
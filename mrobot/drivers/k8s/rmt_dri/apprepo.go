@@ -19,7 +19,9 @@ package rmt_dri
 import (
 	"context"
 	"github.com/pkg/errors"
+	agfw "github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/worker"
 	"google.golang.org/grpc/metadata"
+	corev1 "k8s.io/api/core/v1"
 	"sync"
 )
 
@@ -51,6 +53,19 @@ func (w *workRepository) new(ctx context.Context, wsp *App) error {
 	return nil
 }
 
+func (w *workRepository) list() []*App {
+	var apps []*App
+	w.rep.Range(func(key, value interface{}) bool {
+		apps = append(apps, value.(*App))
+		return true
+	})
+	return apps
+}
+
+func (w *workRepository) delete(uid string) {
+	w.rep.Delete(uid)
+}
+
 func guid(ctx context.Context) (uid string, err error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -67,24 +82,57 @@ func guid(ctx context.Context) (uid string, err error) {
 }
 
 type App struct {
-	UID          string `validate:"required"`
-	Image        string `validate:"required"`
+	UID   string `validate:"required"`
+	Image string `validate:"required"`
+	// PullPolicy pull policy of image in package, default policy of k8s is used if empty
+	PullPolicy corev1.PullPolicy
+	// PullSecret secret of registry credential, empty if registry of image is not registered
+	PullSecret   string
 	WorkDir      string `validate:"required"`
 	Command      []string
 	FileMounts   map[string]FileMount
 	Environments map[string]string
 	Ports        map[int]PortInfo
-	Limit        *Limit
-	Log          *Log
-	Health       *HealthOption
-	FilePremises map[string]FilePremise
-	Tags         map[string]string
+	// ReservedPorts container port -> node port reserved by ReservePort, 0 if port is not exposed by NodePort
+	ReservedPorts map[int]int
+	Limit         *Limit
+	Log           *Log
+	Health        *HealthOption
+	FilePremises  map[string]FilePremise
+	Tags          map[string]string
+	// Workload workload kind of app, decided when app is created
+	Workload WorkloadKind
+	// Volumes volumes claimed by app, volume named 'workspace' configures claim of workspace
+	Volumes map[string]VolumeSpec
+	// Namespace namespace of app objects, decided when the first object of app is created
+	Namespace string
+	// Replicas desired replicas of workload, 0 means 1
+	Replicas int32
 }
 
+// WorkloadKind kind of workload which runs the app
+type WorkloadKind string
+
+const (
+	WorkloadDeployment  WorkloadKind = "deployment"
+	WorkloadStatefulSet WorkloadKind = "statefulset"
+)
+
 type FilePremise struct {
 	Name        string
 	AcquireAddr string
 	Shell       string
+	// Sensitive file is delivered by secret
+	Sensitive bool
+	// CheckSum sha256 of file in hex
+	CheckSum string
+	// ExtractTo dir relative to workdir which the tar.gz file is extracted into
+	ExtractTo       string
+	StripComponents int
+	// Content content of file delivered by config map or secret, nil if it is downloaded by init container
+	Content []byte
+	// Files files extracted from the archive, each of them is delivered by secret
+	Files []agfw.ArchiveFile
 }
 
 type Log struct {
@@ -105,6 +153,17 @@ type FileMount struct {
 	Volume  string
 }
 
+// VolumeSpec spec of volume claimed by app
+type VolumeSpec struct {
+	Name string `validate:"required"`
+	// Size quantity of storage, eg: 10Gi
+	Size string `validate:"required"`
+	// AccessMode access mode of claim, eg: ReadWriteOnce
+	AccessMode   string `validate:"required"`
+	StorageClass string `validate:"required"`
+	MountPath    string
+}
+
 type Volume struct {
 	Name  string            `validate:"required"`
 	Type  string            `validate:"required"`
@@ -118,6 +177,10 @@ type PortInfo struct {
 	ServiceName string `validate:"required"`
 	IngressName string
 	NodePort    int
+	// Expose how port is exposed out of cluster
+	Expose ExposeMode
+	// External address produced by expose mode, empty for cluster ip
+	External string
 }
 
 type MethodType string
@@ -127,10 +190,29 @@ const (
 	HttpPost MethodType = "httpPost"
 )
 
+type ProbeType string
+
+const (
+	ProbeHTTP ProbeType = "http"
+	ProbeTCP  ProbeType = "tcp"
+	ProbeExec ProbeType = "exec"
+	ProbeGRPC ProbeType = "grpc"
+)
+
 type HealthBasic struct {
-	Method MethodType
-	Path   string `validate:"required"`
-	Port   int    `validate:"required"`
+	Type    ProbeType
+	Method  MethodType
+	Path    string
+	Port    int
+	Command []string
+	// GRPCService service checked by grpc probe, empty for the whole server
+	GRPCService string
+	// timings in seconds, defaults of worker are used if not set
+	InitialDelay     int
+	Period           int
+	Timeout          int
+	FailureThreshold int
+	SuccessThreshold int
 }
 
 type HealthOption struct {
@@ -0,0 +1,192 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+	"sort"
+	"time"
+)
+
+// defaultExecTimeout timeout in seconds of command exec in app
+const defaultExecTimeout = 30
+
+// ExecInApp exec command in running pod of app
+func (k *K8sWorker) ExecInApp(ctx context.Context, req *worker0.ExecReq) (*worker0.ExecRes, error) {
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	if len(req.Command) == 0 {
+		return nil, errors.New("command to exec is nil")
+	}
+	timeout := int(req.Timeout)
+	if timeout <= 0 {
+		timeout = defaultExecTimeout
+	}
+	cli, pod, err := k.execPod(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	tctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	code, err := cli.Exec(&base.ExecOptions{
+		Ctx:       tctx,
+		Namespace: k.ns(app),
+		Pod:       pod,
+		Container: app.UID,
+		Command:   execCommand(req.Command, req.Envs),
+		Stdout:    &stdout,
+		Stderr:    &stderr,
+	})
+	if err != nil {
+		if errors.Is(tctx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			return nil, errors.Errorf("exec command [%v] in pod [%s] timeout [%d]s", req.Command, pod, timeout)
+		}
+		return nil, errors.Wrap(err, "exec command")
+	}
+	log.Debugf(ctx, "Currently exec command [%v] in pod [%s], exit code [%d]", req.Command, pod, code)
+	return &worker0.ExecRes{Stdout: stdout.String(), Stderr: stderr.String(), ExitCode: int32(code)}, nil
+}
+
+// ExecInAppTTY exec command in running pod of app with tty
+func (k *K8sWorker) ExecInAppTTY(stream worker0.Worker0_ExecInAppTTYServer) error {
+	ctx := stream.Context()
+	app, err := repo.load(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fail to load app from repo")
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		return errors.Wrap(err, "receive start input")
+	}
+	if first.Start == nil || len(first.Start.Command) == 0 {
+		return errors.New("command to exec is nil, first input must carry start")
+	}
+	cli, pod, err := k.execPod(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	stdinr, stdinw := io.Pipe()
+	sizes := &sizeQueue{ch: make(chan *remotecommand.TerminalSize, 4)}
+	go func() {
+		defer stdinw.Close()
+		defer close(sizes.ch)
+		for {
+			in, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			if len(in.Stdin) != 0 {
+				if _, err = stdinw.Write(in.Stdin); err != nil {
+					return
+				}
+			}
+			if in.Resize != nil {
+				// drop resize if executor is not consuming
+				select {
+				case sizes.ch <- &remotecommand.TerminalSize{Width: uint16(in.Resize.Width), Height: uint16(in.Resize.Height)}:
+				default:
+				}
+			}
+		}
+	}()
+
+	code, err := cli.Exec(&base.ExecOptions{
+		Ctx:       ctx,
+		Namespace: k.ns(app),
+		Pod:       pod,
+		Container: app.UID,
+		Command:   execCommand(first.Start.Command, first.Start.Envs),
+		Stdin:     stdinr,
+		Stdout:    &ttyWriter{stream: stream},
+		TTY:       true,
+		SizeQueue: sizes,
+	})
+	out := &worker0.ExecOutput{Exited: true, ExitCode: int32(code)}
+	if err != nil {
+		out.Message = err.Error()
+	}
+	return stream.Send(out)
+}
+
+// execPod pick a running pod of app
+func (k *K8sWorker) execPod(ctx context.Context, app *App) (*base.Client, string, error) {
+	cli, err := k.client(ctx)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "new k8s client")
+	}
+	pods, err := cli.ListPodsByLabels(k.ns(app), map[string]string{"uuid": app.UID})
+	if err != nil {
+		return nil, "", errors.Wrap(err, "list pods of app")
+	}
+	for _, p := range pods {
+		if p.Status.Phase == corev1.PodRunning {
+			return cli, p.Name, nil
+		}
+	}
+	return nil, "", errors.Errorf("no running pod of app [%s]", app.UID)
+}
+
+// execCommand set envs by 'env' since pod exec api not support envs
+func execCommand(command []string, envs map[string]string) []string {
+	if len(envs) == 0 {
+		return command
+	}
+	keys := make([]string, 0, len(envs))
+	for key := range envs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	c := []string{"env"}
+	for _, key := range keys {
+		c = append(c, fmt.Sprintf("%s=%s", key, envs[key]))
+	}
+	return append(c, command...)
+}
+
+type sizeQueue struct {
+	ch chan *remotecommand.TerminalSize
+}
+
+// Next return nil once input stream end
+func (s *sizeQueue) Next() *remotecommand.TerminalSize {
+	return <-s.ch
+}
+
+type ttyWriter struct {
+	stream worker0.Worker0_ExecInAppTTYServer
+}
+
+func (w *ttyWriter) Write(p []byte) (int, error) {
+	err := w.stream.Send(&worker0.ExecOutput{Stdout: append([]byte{}, p...)})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,268 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sort"
+	"time"
+)
+
+// ExposeMode how port of app is exposed out of cluster
+type ExposeMode string
+
+const (
+	ExposeNodePort       ExposeMode = "NodePort"
+	ExposeClusterIP      ExposeMode = "ClusterIP"
+	ExposeLoadBalancer   ExposeMode = "LoadBalancer"
+	ExposeIngress        ExposeMode = "Ingress"
+	ExposeTLSPassthrough ExposeMode = "TLSPassthrough"
+)
+
+// lbWaitTimeout max time to wait address of load balancer assigned
+var lbWaitTimeout = 2 * time.Minute
+
+// serviceName name of cluster ip service which carries all the ports of app
+func serviceName(uid string) string {
+	return fmt.Sprintf("%s-service", uid)
+}
+
+// nodePortName name of node port service of app
+func nodePortName(uid string) string {
+	return fmt.Sprintf("%s-nodeport", uid)
+}
+
+// lbName name of load balancer service of app
+func lbName(uid string) string {
+	return fmt.Sprintf("%s-lb", uid)
+}
+
+// ingressName name of ingress of app, ports of tls passthrough have their own ingress
+func ingressName(uid string, mode ExposeMode) string {
+	if mode == ExposeTLSPassthrough {
+		return fmt.Sprintf("%s-passthrough", uid)
+	}
+	return fmt.Sprintf("%s-ingress", uid)
+}
+
+// ingressHost host of port exposed by ingress
+func (k *K8sWorker) ingressHost(uid string, port int) string {
+	return fmt.Sprintf("m%d-%s-%d.%s", k.MachineID, uid, port, k.Domain)
+}
+
+// expose expose port of app by its mode, return the external address, empty for cluster ip
+func (k *K8sWorker) expose(ctx context.Context, cli *base.Client, app *App, pi *PortInfo) (string, error) {
+	switch pi.Expose {
+	case ExposeClusterIP:
+		return "", nil
+	case ExposeNodePort:
+		svc, err := svcHandle(ctx, cli, nodePortName(app.UID), k.ns(app), corev1.ServiceTypeNodePort, pi)
+		if err != nil {
+			return "", errors.Wrap(err, "node port svc handle")
+		}
+		for _, port := range svc.Spec.Ports {
+			if port.Port == int32(pi.Port) {
+				pi.NodePort = int(port.NodePort)
+			}
+		}
+		if pi.NodePort == 0 {
+			return "", errors.Errorf("find port [%d] target node port is nil", pi.Port)
+		}
+		return fmt.Sprintf("%s:%d", k.NodeIP, pi.NodePort), nil
+	case ExposeLoadBalancer:
+		_, err := svcHandle(ctx, cli, lbName(app.UID), k.ns(app), corev1.ServiceTypeLoadBalancer, pi)
+		if err != nil {
+			return "", errors.Wrap(err, "load balancer svc handle")
+		}
+		addr, err := waitLoadBalancer(ctx, cli, lbName(app.UID), k.ns(app))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s:%d", addr, pi.Port), nil
+	case ExposeIngress, ExposeTLSPassthrough:
+		err := k.checkIngress(pi)
+		if err != nil {
+			return "", err
+		}
+		err = k.ingressHandle(ctx, cli, app, pi)
+		if err != nil {
+			return "", errors.Wrap(err, "ingress handle")
+		}
+		return ingressAddr(pi), nil
+	default:
+		return "", errors.Errorf("expose mode [%s] not support", pi.Expose)
+	}
+}
+
+// plannedExternal external address of port known before it is exposed, node port picked and
+// address of load balancer assigned by cluster are unknown
+func (k *K8sWorker) plannedExternal(app *App, pi *PortInfo) (string, error) {
+	switch pi.Expose {
+	case ExposeClusterIP, ExposeLoadBalancer:
+		return "", nil
+	case ExposeNodePort:
+		return k.outRoute(*pi), nil
+	case ExposeIngress, ExposeTLSPassthrough:
+		err := k.checkIngress(pi)
+		if err != nil {
+			return "", err
+		}
+		planned := *pi
+		planned.IngressName = k.ingressHost(app.UID, pi.Port)
+		return ingressAddr(&planned), nil
+	default:
+		return "", errors.Errorf("expose mode [%s] not support", pi.Expose)
+	}
+}
+
+// checkIngress check whether port can be exposed by ingress
+func (k *K8sWorker) checkIngress(pi *PortInfo) error {
+	if len(k.Domain) == 0 {
+		return errors.Errorf("expose port by [%s] needs domain of agent", pi.Expose)
+	}
+	if pi.Protocol != string(corev1.ProtocolTCP) {
+		return errors.Errorf("protocol [%s] of port can not be exposed by ingress", pi.Protocol)
+	}
+	return nil
+}
+
+// ingressAddr external address of port exposed by ingress
+func ingressAddr(pi *PortInfo) string {
+	if pi.Expose == ExposeTLSPassthrough {
+		return fmt.Sprintf("%s:443", pi.IngressName)
+	}
+	return fmt.Sprintf("%s:80", pi.IngressName)
+}
+
+// waitLoadBalancer wait until address of load balancer assigned to service
+func waitLoadBalancer(ctx context.Context, cli *base.Client, name, namespace string) (string, error) {
+	toutctx, cancel := context.WithTimeout(ctx, lbWaitTimeout)
+	defer cancel()
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	for {
+		svc, err := cli.GetService(name, namespace)
+		if err != nil {
+			return "", errors.Wrapf(err, "get service [%s]", name)
+		}
+		for _, in := range svc.Status.LoadBalancer.Ingress {
+			if len(in.IP) != 0 {
+				return in.IP, nil
+			}
+			if len(in.Hostname) != 0 {
+				return in.Hostname, nil
+			}
+		}
+		log.Debugf(ctx, "Currently wait load balancer of service [%s] assigned", name)
+		select {
+		case <-toutctx.Done():
+			return "", errors.Errorf("wait load balancer of service [%s] assigned timeout", name)
+		case <-ticker.C:
+		}
+	}
+}
+
+// ingressHandle create or update ingress which routes all the ports of app with the same mode
+func (k *K8sWorker) ingressHandle(ctx context.Context, cli *base.Client, app *App, pi *PortInfo) error {
+	pi.IngressName = k.ingressHost(app.UID, pi.Port)
+	ports := []PortInfo{*pi}
+	for _, p := range app.Ports {
+		if p.Expose == pi.Expose && p.Port != pi.Port {
+			ports = append(ports, p)
+		}
+	}
+	igs := k.ingress(app, pi.Expose, ports)
+	old, err := cli.GetIngressByName(igs.Name, k.ns(app), metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "get ingress [%s]", igs.Name)
+		}
+		log.Debugf(ctx, "Currently start to create ingress [%s]", igs.Name)
+		return cli.CreateIngress(igs)
+	}
+	old.Spec = igs.Spec
+	old.Annotations = igs.Annotations
+	log.Debugf(ctx, "Currently start to update ingress [%s]", igs.Name)
+	return cli.UpdateIngress(old)
+}
+
+// ingress build ingress of ports, each port has its own host and is routed to cluster ip service
+func (k *K8sWorker) ingress(app *App, mode ExposeMode, ports []PortInfo) *v1beta1.Ingress {
+	sort.Slice(ports, func(i, j int) bool { return ports[i].Port < ports[j].Port })
+	var rules []v1beta1.IngressRule
+	for _, p := range ports {
+		rules = append(rules, v1beta1.IngressRule{
+			Host: k.ingressHost(app.UID, p.Port),
+			IngressRuleValue: v1beta1.IngressRuleValue{
+				HTTP: &v1beta1.HTTPIngressRuleValue{
+					Paths: []v1beta1.HTTPIngressPath{
+						{
+							Path: "/",
+							Backend: v1beta1.IngressBackend{
+								ServiceName: serviceName(app.UID),
+								ServicePort: intstr.FromInt(p.Port),
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	annotations := map[string]string{"kubernetes.io/ingress.class": "nginx"}
+	if mode == ExposeTLSPassthrough {
+		annotations["nginx.ingress.kubernetes.io/ssl-passthrough"] = "true"
+	}
+	return &v1beta1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "extensions/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ingressName(app.UID, mode),
+			Namespace:   k.ns(app),
+			Labels:      app.Tags,
+			Annotations: annotations,
+		},
+		Spec: v1beta1.IngressSpec{Rules: rules},
+	}
+}
+
+// exposeMode convert expose type of network to mode
+func exposeMode(e worker0.App_Network_Expose) ExposeMode {
+	return ExposeMode(e.String())
+}
+
+// outRoute external route of port, node port of app created before expose mode is used
+func (k *K8sWorker) outRoute(p PortInfo) string {
+	if len(p.External) != 0 {
+		return p.External
+	}
+	if p.NodePort != 0 {
+		return fmt.Sprintf("%s:%d", k.NodeIP, p.NodePort)
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"testing"
+)
+
+func TestIngress(t *testing.T) {
+	k := &K8sWorker{Namespace: "ns", MachineID: 3, Domain: "example.com", NodeIP: "10.0.0.1"}
+	app := &App{UID: "app1", Tags: map[string]string{"uuid": "app1"}}
+	ports := []PortInfo{{Port: 9443, Expose: ExposeTLSPassthrough}, {Port: 7051, Expose: ExposeTLSPassthrough}}
+
+	igs := k.ingress(app, ExposeTLSPassthrough, ports)
+	assert.Equal(t, "app1-passthrough", igs.Name)
+	assert.Equal(t, "true", igs.Annotations["nginx.ingress.kubernetes.io/ssl-passthrough"])
+	assert.Len(t, igs.Spec.Rules, 2)
+	assert.Equal(t, "m3-app1-7051.example.com", igs.Spec.Rules[0].Host)
+	backend := igs.Spec.Rules[0].HTTP.Paths[0].Backend
+	assert.Equal(t, "app1-service", backend.ServiceName)
+	assert.Equal(t, 7051, backend.ServicePort.IntValue())
+
+	igs = k.ingress(app, ExposeIngress, ports[:1])
+	assert.Equal(t, "app1-ingress", igs.Name)
+	assert.NotContains(t, igs.Annotations, "nginx.ingress.kubernetes.io/ssl-passthrough")
+
+	assert.Equal(t, ExposeLoadBalancer, exposeMode(worker0.App_Network_LoadBalancer))
+	assert.Equal(t, "10.0.0.1:30001", k.outRoute(PortInfo{NodePort: 30001}), "app created before expose mode")
+	assert.Equal(t, "lb.example.com:7051", k.outRoute(PortInfo{NodePort: 30001, External: "lb.example.com:7051"}))
+	assert.Empty(t, k.outRoute(PortInfo{Expose: ExposeClusterIP}))
+}
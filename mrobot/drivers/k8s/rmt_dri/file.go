@@ -0,0 +1,241 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"bytes"
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	agfw "github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/worker"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// file operations are run by shell in app container, since workspace pvc is only mounted there.
+// paths are passed as positional params of shell to avoid injection
+const (
+	// guardScript take workspace root from the first param and define 'inws', which checks the
+	// real path of existing file is in workspace, so symlinks can not lead out of it
+	guardScript = `root=$(realpath "$1") || exit 1; shift; inws() { p=$(realpath "$1") || return 1; case "$p" in "$root"|"$root"/*) return 0;; esac; echo "path [$1] is out of workspace" >&2; return 1; }; `
+	statFormat  = "%s|%a|%Y|%F|%n"
+	listScript  = `inws "$1" || exit 1; if [ -d "$1" ]; then find "$1" -mindepth 1 $2 -exec stat -c '` + statFormat + `' {} +; else stat -c '` + statFormat + `' "$1"; fi`
+	readScript  = `inws "$1" && cat -- "$1"`
+	// check the nearest existing parent before mkdir and the parent after it, then write to temp
+	// file first and rename, the rename replaces a symlink instead of following it
+	writeScript = `d=$(dirname "$1"); while [ ! -e "$d" ]; do d=$(dirname "$d"); done; inws "$d" && mkdir -p "$(dirname "$1")" && inws "$(dirname "$1")" && rm -f "$1.tmp" && cat > "$1.tmp" && chmod "$2" "$1.tmp" && mv -f "$1.tmp" "$1" && stat -c '` + statFormat + `' "$1"`
+	// symlink itself is deleted, only its parent is checked
+	deleteScript  = `inws "$(dirname "$1")" && rm $2 -- "$1"`
+	archiveScript = `inws "$1" && [ -d "$1" ] && tar czf - -C "$1" .`
+)
+
+// defaultFileMode mode of written file when mode is not set
+const defaultFileMode = 0644
+
+// ListFiles list files in workspace of app
+func (k *K8sWorker) ListFiles(ctx context.Context, req *worker0.FileReq) (*worker0.ListFilesRes, error) {
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	depth := "-maxdepth 1"
+	if req.Recursive {
+		depth = ""
+	}
+	var out bytes.Buffer
+	err = k.fileExec(ctx, app, listScript, []string{agfw.WorkspacePath(app.WorkDir, req.Path), depth}, nil, &out)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list files of [%s]", req.Path)
+	}
+	files, err := parseStat(app.WorkDir, out.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse files of [%s]", req.Path)
+	}
+	return &worker0.ListFilesRes{Files: files}, nil
+}
+
+// ReadFile read file in workspace of app
+func (k *K8sWorker) ReadFile(req *worker0.FileReq, stream worker0.Worker0_ReadFileServer) error {
+	ctx := stream.Context()
+	app, err := repo.load(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fail to load app from repo")
+	}
+	err = k.fileExec(ctx, app, readScript, []string{agfw.WorkspacePath(app.WorkDir, req.Path)}, nil, &agfw.ChunkWriter{Send: stream.Send})
+	if err != nil {
+		return errors.Wrapf(err, "read file [%s]", req.Path)
+	}
+	return nil
+}
+
+// WriteFile write file in workspace of app
+func (k *K8sWorker) WriteFile(stream worker0.Worker0_WriteFileServer) error {
+	ctx := stream.Context()
+	app, err := repo.load(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fail to load app from repo")
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		return errors.Wrap(err, "receive first chunk")
+	}
+	if len(first.Path) == 0 {
+		return errors.New("path of file is nil, first chunk must carry path")
+	}
+	target := agfw.WorkspacePath(app.WorkDir, first.Path)
+	if target == agfw.WorkspacePath(app.WorkDir, "") {
+		return errors.New("can not write to workspace of app")
+	}
+	mode := first.Mode & 0777
+	if mode == 0 {
+		mode = defaultFileMode
+	}
+	stdinr, stdinw := io.Pipe()
+	go func() {
+		data := first.Data
+		for {
+			if _, err := stdinw.Write(data); err != nil {
+				return
+			}
+			c, err := stream.Recv()
+			if err == io.EOF {
+				_ = stdinw.Close()
+				return
+			}
+			if err != nil {
+				_ = stdinw.CloseWithError(err)
+				return
+			}
+			data = c.Data
+		}
+	}()
+	var out bytes.Buffer
+	err = k.fileExec(ctx, app, writeScript, []string{target, strconv.FormatUint(uint64(mode), 8)}, stdinr, &out)
+	_ = stdinr.Close()
+	if err != nil {
+		return errors.Wrapf(err, "write file [%s]", first.Path)
+	}
+	files, err := parseStat(app.WorkDir, out.String())
+	if err != nil || len(files) != 1 {
+		return errors.Errorf("parse info of file [%s], Err: [%v]", first.Path, err)
+	}
+	log.Debugf(ctx, "Currently write file [%s], size [%d]", files[0].Path, files[0].Size)
+	return stream.SendAndClose(files[0])
+}
+
+// DeleteFile delete file in workspace of app
+func (k *K8sWorker) DeleteFile(ctx context.Context, req *worker0.FileReq) (*worker0.Empty, error) {
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	target := agfw.WorkspacePath(app.WorkDir, req.Path)
+	if target == agfw.WorkspacePath(app.WorkDir, "") {
+		return nil, errors.New("can not delete workspace of app")
+	}
+	flag := ""
+	if req.Recursive {
+		flag = "-r"
+	}
+	err = k.fileExec(ctx, app, deleteScript, []string{target, flag}, nil, &bytes.Buffer{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "delete file [%s]", req.Path)
+	}
+	log.Debugf(ctx, "Currently delete file [%s]", req.Path)
+	return &worker0.Empty{}, nil
+}
+
+// ArchiveDir archive dir in workspace of app as tar.gz
+func (k *K8sWorker) ArchiveDir(req *worker0.FileReq, stream worker0.Worker0_ArchiveDirServer) error {
+	ctx := stream.Context()
+	app, err := repo.load(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fail to load app from repo")
+	}
+	err = k.fileExec(ctx, app, archiveScript, []string{agfw.WorkspacePath(app.WorkDir, req.Path)}, nil, &agfw.ChunkWriter{Send: stream.Send})
+	if err != nil {
+		return errors.Wrapf(err, "archive dir [%s]", req.Path)
+	}
+	return nil
+}
+
+// fileExec run script in app container with guard of workspace, args are passed as positional
+// params of script after workspace root
+func (k *K8sWorker) fileExec(ctx context.Context, app *App, script string, args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(app.WorkDir) == 0 {
+		return errors.Errorf("work dir of app [%s] is nil", app.UID)
+	}
+	cli, pod, err := k.execPod(ctx, app)
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	code, err := cli.Exec(&base.ExecOptions{
+		Ctx:       ctx,
+		Namespace: k.ns(app),
+		Pod:       pod,
+		Container: app.UID,
+		Command:   append([]string{"/bin/sh", "-c", guardScript + script, "sh", agfw.WorkspacePath(app.WorkDir, "")}, args...),
+		Stdin:     stdin,
+		Stdout:    stdout,
+		Stderr:    &stderr,
+	})
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return errors.Errorf("exit code [%d], stderr [%s]", code, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// parseStat parse output of stat with statFormat, paths are relative to root
+func parseStat(root, out string) ([]*worker0.FileInfo, error) {
+	var files []*worker0.FileInfo
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if len(line) == 0 {
+			continue
+		}
+		fs := strings.SplitN(line, "|", 5)
+		if len(fs) != 5 {
+			return nil, errors.Errorf("stat line [%s] not correct", line)
+		}
+		size, err := strconv.ParseInt(fs[0], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse size [%s]", fs[0])
+		}
+		mode, err := strconv.ParseUint(fs[1], 8, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse mode [%s]", fs[1])
+		}
+		mt, err := strconv.ParseInt(fs[2], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse mod time [%s]", fs[2])
+		}
+		files = append(files, &worker0.FileInfo{
+			Path:    strings.TrimPrefix(strings.TrimPrefix(fs[4], strings.TrimSuffix(root, "/")), "/"),
+			Size:    size,
+			Mode:    uint32(mode),
+			ModTime: mt,
+			IsDir:   fs[3] == "directory",
+		})
+	}
+	return files, nil
+}
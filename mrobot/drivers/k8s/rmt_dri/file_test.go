@@ -0,0 +1,74 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileScriptsGuard(t *testing.T) {
+	if _, err := exec.LookPath("realpath"); err != nil {
+		t.Skip("realpath is not found")
+	}
+	dir, err := ioutil.TempDir("", "ws")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	ws, out := filepath.Join(dir, "ws"), filepath.Join(dir, "out")
+	assert.Nil(t, os.MkdirAll(filepath.Join(ws, "sub"), 0755))
+	assert.Nil(t, os.MkdirAll(out, 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(ws, "sub", "a.txt"), []byte("a"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(out, "secret"), []byte("s"), 0644))
+	assert.Nil(t, os.Symlink(out, filepath.Join(ws, "link")))
+
+	run := func(script, stdin string, args ...string) (string, error) {
+		c := exec.Command("/bin/sh", append([]string{"-c", guardScript + script, "sh", ws}, args...)...)
+		c.Stdin = strings.NewReader(stdin)
+		b, err := c.Output()
+		return string(b), err
+	}
+
+	got, err := run(readScript, "", filepath.Join(ws, "sub", "a.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "a", got)
+	_, err = run(readScript, "", filepath.Join(ws, "link", "secret"))
+	assert.NotNil(t, err, "read through symlink out of workspace")
+	_, err = run(listScript, "", filepath.Join(ws, "link"), "-maxdepth 1")
+	assert.NotNil(t, err, "list through symlink out of workspace")
+	_, err = run(archiveScript, "", filepath.Join(ws, "link"))
+	assert.NotNil(t, err, "archive through symlink out of workspace")
+
+	_, err = run(writeScript, "w", filepath.Join(ws, "link", "new", "b.txt"), "644")
+	assert.NotNil(t, err, "write through symlink out of workspace")
+	_, err = os.Stat(filepath.Join(out, "new"))
+	assert.True(t, os.IsNotExist(err), "dir is not created out of workspace")
+	got, err = run(writeScript, "w", filepath.Join(ws, "new", "b.txt"), "644")
+	assert.Nil(t, err)
+	assert.Contains(t, got, "b.txt")
+
+	_, err = run(deleteScript, "", filepath.Join(ws, "link", "secret"), "")
+	assert.NotNil(t, err, "delete through symlink out of workspace")
+	_, err = run(deleteScript, "", filepath.Join(ws, "link"), "")
+	assert.Nil(t, err, "symlink itself is deleted")
+	_, err = os.Stat(filepath.Join(out, "secret"))
+	assert.Nil(t, err)
+}
@@ -0,0 +1,234 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+)
+
+// GetApp get app in metadata with status of its deployment and pods
+func (k *K8sWorker) GetApp(ctx context.Context, _ *worker0.Empty) (*worker0.AppStatus, error) {
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	cli, err := k.client(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "new k8s client")
+	}
+	return k.appStatus(cli, app)
+}
+
+// ListApps list apps which have all the tags
+func (k *K8sWorker) ListApps(ctx context.Context, req *worker0.ListAppsReq) (*worker0.ListAppsRes, error) {
+	cli, err := k.client(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "new k8s client")
+	}
+	res := &worker0.ListAppsRes{}
+	for _, app := range repo.list() {
+		if !hasTags(app.Tags, req.Tags) {
+			continue
+		}
+		st, err := k.appStatus(cli, app)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get status of app [%s]", app.UID)
+		}
+		res.Apps = append(res.Apps, st)
+	}
+	return res, nil
+}
+
+func (k *K8sWorker) appStatus(cli *base.Client, app *App) (*worker0.AppStatus, error) {
+	st := &worker0.AppStatus{App: k.workerApp(app)}
+	var (
+		replicas *int32
+		ready    int32
+	)
+	if app.Workload == WorkloadStatefulSet {
+		sts, err := cli.GetStatefulSetByName(stsName(app.UID), k.ns(app), metav1.GetOptions{})
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				st.StateType = worker0.AppStatus_Created
+				return st, nil
+			}
+			return nil, errors.Wrap(err, "get stateful set")
+		}
+		replicas, ready = sts.Spec.Replicas, sts.Status.ReadyReplicas
+	} else {
+		dep, err := cli.GetDeployment(fmt.Sprintf("%s-dep", app.UID), k.ns(app))
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				st.StateType = worker0.AppStatus_Created
+				return st, nil
+			}
+			return nil, errors.Wrap(err, "get deployment")
+		}
+		replicas, ready = dep.Spec.Replicas, dep.Status.ReadyReplicas
+	}
+	pods, err := cli.ListPodsByLabels(k.ns(app), map[string]string{"uuid": app.UID})
+	if err != nil {
+		return nil, errors.Wrap(err, "list pods of app")
+	}
+	workloadStatus(st, replicas, ready, pods)
+	return st, nil
+}
+
+// workloadStatus fill status by replicas of workload and its pods
+func workloadStatus(st *worker0.AppStatus, specReplicas *int32, readyReplicas int32, pods []corev1.Pod) {
+	var ready int32
+	for _, p := range pods {
+		st.Pods = append(st.Pods, p.Name)
+		for _, c := range p.Status.ContainerStatuses {
+			st.RestartCount += c.RestartCount
+			if c.Ready {
+				ready++
+			}
+			if w := c.State.Waiting; w != nil && len(st.Message) == 0 && w.Reason != "ContainerCreating" {
+				st.Message = fmt.Sprintf("container of pod [%s] waiting, reason [%s], message [%s]", p.Name, w.Reason, w.Message)
+			}
+		}
+	}
+	var replicas int32 = 1
+	if specReplicas != nil {
+		replicas = *specReplicas
+	}
+	st.Healthy = replicas > 0 && ready >= replicas
+	switch {
+	case replicas == 0:
+		st.StateType = worker0.AppStatus_Stopped
+	case readyReplicas >= replicas:
+		st.StateType = worker0.AppStatus_Running
+	case len(st.Message) != 0:
+		st.StateType = worker0.AppStatus_Failed
+	default:
+		st.StateType = worker0.AppStatus_Starting
+	}
+}
+
+func hasTags(tags map[string]string, want []*worker0.App_Tag) bool {
+	for _, t := range want {
+		if v, ok := tags[t.Key]; !ok || v != t.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// workerApp convert app in repo to worker0 app
+func (k *K8sWorker) workerApp(app *App) *worker0.App {
+	wa := &worker0.App{
+		UUID: app.UID,
+		MainP: &worker0.App_MainProcess{
+			Name:     app.Image,
+			Type:     worker0.App_MainProcess_Image,
+			Workdir:  app.WorkDir,
+			StartCMD: app.Command,
+		},
+	}
+	if i := strings.LastIndex(app.Image, ":"); i != -1 {
+		wa.MainP.Name, wa.MainP.Version = app.Image[:i], app.Image[i+1:]
+	}
+	for _, m := range app.FileMounts {
+		wa.FileMounts = append(wa.FileMounts, &worker0.App_FileMount{File: m.File, MountTo: m.MountTo, Volume: m.Volume})
+	}
+	for key, val := range app.Environments {
+		wa.EnvironmentVars = append(wa.EnvironmentVars, &worker0.App_EnvVar{Key: key, Value: val})
+	}
+	for _, p := range app.Ports {
+		n := &worker0.App_Network{
+			PortInfo: &worker0.App_Network_PortInf{
+				Port:         int32(p.Port),
+				Name:         p.Name,
+				ProtocolType: worker0.App_Network_PortInf_Protocol(worker0.App_Network_PortInf_Protocol_value[p.Protocol]),
+			},
+			RouteInfo: []*worker0.App_Network_RouteInf{
+				{RouteType: worker0.App_Network_RouteInf_IN, Router: fmt.Sprintf("%s:%d", p.ServiceName, p.Port)},
+			},
+			ExposeType: worker0.App_Network_Expose(worker0.App_Network_Expose_value[string(p.Expose)]),
+		}
+		if out := k.outRoute(p); len(out) != 0 {
+			n.RouteInfo = append(n.RouteInfo, &worker0.App_Network_RouteInf{RouteType: worker0.App_Network_RouteInf_OUT, Router: out})
+		}
+		wa.Networks = append(wa.Networks, n)
+	}
+	for _, f := range app.FilePremises {
+		wa.FilePremise = append(wa.FilePremise, &worker0.App_File{Name: f.Name, AcquireAddr: f.AcquireAddr, Shell: f.Shell, Sensitive: f.Sensitive, CheckSum: f.CheckSum, ExtractTo: f.ExtractTo, StripComponents: int32(f.StripComponents)})
+	}
+	if app.Limit != nil {
+		wa.LimitInfo = &worker0.App_Limit{CPU: int32(app.Limit.CPU), Memory: int32(app.Limit.Memory)}
+	}
+	if app.Health != nil {
+		wa.HealthInfo = &worker0.App_Health{Liveness: healthBasic(app.Health.Liveness), Readness: healthBasic(app.Health.Readness)}
+	}
+	if app.Log != nil {
+		wa.LogInfo = &worker0.App_Log{RealTimeFile: app.Log.RealTimeFile, FilePath: app.Log.CompressLogPath}
+	}
+	for key, val := range app.Tags {
+		wa.Tags = append(wa.Tags, &worker0.App_Tag{Key: key, Value: val})
+	}
+	if app.Workload == WorkloadStatefulSet {
+		wa.WorkloadType = worker0.App_StatefulSet
+	}
+	wa.Replicas = replicas(app)
+	for _, v := range app.Volumes {
+		wa.Volumes = append(wa.Volumes, &worker0.App_Volume{
+			Name:           v.Name,
+			Size:           v.Size,
+			AccessModeType: worker0.App_Volume_AccessMode(worker0.App_Volume_AccessMode_value[v.AccessMode]),
+			StorageClass:   v.StorageClass,
+			MountPath:      v.MountPath,
+		})
+	}
+	return wa
+}
+
+func healthBasic(h *HealthBasic) *worker0.App_Health_Basic {
+	if h == nil {
+		return nil
+	}
+	b := &worker0.App_Health_Basic{
+		Path:                h.Path,
+		Port:                int32(h.Port),
+		Command:             h.Command,
+		GRPCService:         h.GRPCService,
+		InitialDelaySeconds: int32(h.InitialDelay),
+		PeriodSeconds:       int32(h.Period),
+		TimeoutSeconds:      int32(h.Timeout),
+		FailureThreshold:    int32(h.FailureThreshold),
+		SuccessThreshold:    int32(h.SuccessThreshold),
+	}
+	if h.Method == HttpPost {
+		b.MethodType = worker0.App_Health_Basic_POST
+	}
+	switch h.Type {
+	case ProbeTCP:
+		b.ProbeType = worker0.App_Health_Basic_TCP
+	case ProbeExec:
+		b.ProbeType = worker0.App_Health_Basic_EXEC
+	case ProbeGRPC:
+		b.ProbeType = worker0.App_Health_Basic_GRPC
+	}
+	return b
+}
@@ -30,23 +30,31 @@ import (
 	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"sigs.k8s.io/yaml"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// podTerminateTimeout max time to wait pods of app terminated
+var podTerminateTimeout = 5 * time.Minute
+
 type K8sWorker struct {
 	Name         string
 	Namespace    string `validate:"required"`
 	StorageClass string `validate:"required"`
 	NodeIP       string `validate:"required,ip"`
-	KubeConfig   string `validate:"required"`
-	MachineID    int    `validate:"required"`
-	Domain       string
+	// KubeConfig kube config for worker out of cluster, service account of pod is used if empty
+	KubeConfig string
+	MachineID  int `validate:"required"`
+	Domain     string
+	// NamespaceTag apps with different value of this tag are placed in different namespaces,
+	// which are prepared by machine driver, all apps are placed in Namespace if empty
+	NamespaceTag string
 }
 
 func NewK8sWorker() *K8sWorker {
@@ -56,6 +64,7 @@ func NewK8sWorker() *K8sWorker {
 		Namespace:    agfw.Flags["NAMESPACE"].Value,
 		StorageClass: agfw.Flags["STORAGECLASS"].Value,
 		Domain:       agfw.Flags["DOMAIN"].Value,
+		NamespaceTag: agfw.Flags["NAMESPACE_TAG"].Value,
 	}
 	if len(agfw.Flags["MACHINE_ID"].Value) != 0 {
 		mid, err := strconv.Atoi(agfw.Flags["MACHINE_ID"].Value)
@@ -69,14 +78,30 @@ func NewK8sWorker() *K8sWorker {
 	if err != nil {
 		panic(err)
 	}
-	decode, err := base64.Decode(w.KubeConfig)
-	if err != nil {
-		panic(err)
+	if len(w.KubeConfig) != 0 {
+		decode, err := base64.Decode(w.KubeConfig)
+		if err != nil {
+			panic(err)
+		}
+		w.KubeConfig = string(decode)
 	}
-	w.KubeConfig = string(decode)
 	return w
 }
 
+// kubeClient new client by kube config, service account of pod is used if config is empty,
+// it is replaced in test
+var kubeClient = func(ctx context.Context, kubeConfig string) (*base.Client, error) {
+	if len(kubeConfig) == 0 {
+		return base.NewClientInCluster(ctx)
+	}
+	return base.NewClientByConfig(ctx, []byte(kubeConfig))
+}
+
+// client k8s client of worker, in cluster config is used if kube config is not set
+func (k *K8sWorker) client(ctx context.Context) (*base.Client, error) {
+	return kubeClient(ctx, k.KubeConfig)
+}
+
 func (k *K8sWorker) NewApp(ctx context.Context, req *worker0.NewAppReq) (*worker0.App, error) {
 	log.Infof(ctx, "new app [%s/%s]", req.Name, req.Version)
 	if len(req.Name) == 0 || len(req.Version) == 0 {
@@ -91,15 +116,22 @@ func (k *K8sWorker) NewApp(ctx context.Context, req *worker0.NewAppReq) (*worker
 		return nil, errors.Wrapf(err, "get uid from ctx")
 	}
 	app := &App{
-		UID:          uid,
-		Image:        fmt.Sprintf("%s:%s", pkg.Image.ImageName, pkg.Image.Tag),
-		WorkDir:      pkg.Image.WorkDir,
-		Command:      pkg.Image.StartCommands,
-		FileMounts:   make(map[string]FileMount),
-		Environments: make(map[string]string),
-		Ports:        make(map[int]PortInfo),
-		FilePremises: make(map[string]FilePremise),
-		Tags:         map[string]string{"uuid": uid, "machine_id": fmt.Sprintf("%d", k.MachineID)},
+		UID:           uid,
+		Image:         fmt.Sprintf("%s:%s", pkg.Image.ImageName, pkg.Image.Tag),
+		PullPolicy:    corev1.PullPolicy(pkg.Image.PullPolicy),
+		WorkDir:       pkg.Image.WorkDir,
+		Command:       pkg.Image.StartCommands,
+		FileMounts:    make(map[string]FileMount),
+		Environments:  make(map[string]string),
+		Ports:         make(map[int]PortInfo),
+		FilePremises:  make(map[string]FilePremise),
+		ReservedPorts: make(map[int]int),
+		Volumes:       make(map[string]VolumeSpec),
+		Tags:          map[string]string{"uuid": uid, "machine_id": fmt.Sprintf("%d", k.MachineID)},
+		Workload:      WorkloadDeployment,
+	}
+	if req.WorkloadType == worker0.App_StatefulSet {
+		app.Workload = WorkloadStatefulSet
 	}
 	err = repo.new(ctx, app)
 	if err != nil {
@@ -114,20 +146,118 @@ func (k *K8sWorker) NewApp(ctx context.Context, req *worker0.NewAppReq) (*worker
 			Workdir:  pkg.Image.WorkDir,
 			StartCMD: pkg.Image.StartCommands,
 		},
-		Workspace: &worker0.App_WorkspaceInfo{Workspace: app.UID},
+		Workspace:    &worker0.App_WorkspaceInfo{Workspace: app.UID},
+		WorkloadType: req.WorkloadType,
 	}
 	return wap, nil
 }
 
-// StartApp start app
-func (k *K8sWorker) StartApp(ctx context.Context, _ *worker0.App) (*worker0.Empty, error) {
+// StartApp apply objects of app, objects are only validated by the cluster if it is dry run
+func (k *K8sWorker) StartApp(ctx context.Context, wa *worker0.App) (*worker0.Empty, error) {
 	log.Debug(ctx, "Currently to start app")
 	app, err := repo.load(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "fail to load app from repo")
 	}
+	log.Debug(ctx, "Currently new k8s client")
+	cli, err := k.client(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "new k8s client")
+	}
+	if wa != nil && wa.DryRun {
+		err = k.dryRunApp(ctx, cli, app)
+		if err != nil {
+			return nil, errors.Wrap(err, "dry run app")
+		}
+		return &worker0.Empty{}, nil
+	}
+	err = k.placeApp(ctx, cli, app)
+	if err != nil {
+		return nil, errors.Wrap(err, "place app")
+	}
+	objs, err := k.render(ctx, app)
+	if err != nil {
+		return nil, errors.Wrap(err, "render app")
+	}
+	// service 在network的时候会创建, 这里需要添加tag
+	err = k.tagService(ctx, cli, app)
+	if err != nil {
+		return nil, err
+	}
+	err = objs.apply(ctx, cli)
+	if err != nil {
+		return nil, err
+	}
+	app.PullSecret = objs.pullSecretName()
+	log.Debug(ctx, "Currently app deploy success")
+	return &worker0.Empty{}, nil
+}
+
+// tagService set tags of app to services created by network, service is absent if app has
+// no port of its kind
+func (k *K8sWorker) tagService(ctx context.Context, cli *base.Client, app *App) error {
+	if len(app.Ports) == 0 {
+		return nil
+	}
+	for _, service := range []string{serviceName(app.UID), nodePortName(app.UID), lbName(app.UID)} {
+		getService, err := svcFind(ctx, cli, service, k.ns(app))
+		if err != nil {
+			return errors.Wrapf(err, "get service [%s]", service)
+		}
+		if getService == nil {
+			continue
+		}
+		getService.ObjectMeta.Labels = app.Tags
+		getService.Spec.Selector = app.Tags
+		err = cli.UpdateService(getService)
+		if err != nil {
+			return errors.Wrapf(err, "udpate service [%s]", service)
+		}
+	}
+	return nil
+}
+
+// deployment build deployment of app
+func (k *K8sWorker) deployment(ctx context.Context, app *App) (*v1.Deployment, error) {
+	var rep = replicas(app)
+	tpl, err := k.podTemplate(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range k.volumes(app) {
+		tpl.Spec.Volumes = append(tpl.Spec.Volumes, corev1.Volume{
+			Name: podVolumeName(app, v),
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName(app, v),
+				},
+			},
+		})
+	}
+	dep := v1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-dep", app.UID),
+			Namespace: k.ns(app),
+			Labels:    app.Tags,
+		},
+		Spec: v1.DeploymentSpec{
+			Replicas:        &rep,
+			Selector:        &metav1.LabelSelector{MatchLabels: app.Tags},
+			Template:        *tpl,
+			Strategy:        v1.DeploymentStrategy{Type: v1.RecreateDeploymentStrategyType},
+			MinReadySeconds: 10,
+		},
+	}
+	return &dep, nil
+}
+
+// podTemplate build pod template of app, volumes of claims are provided by workload
+func (k *K8sWorker) podTemplate(ctx context.Context, app *App) (*corev1.PodTemplateSpec, error) {
 	// 每个部分进行template之前的一些检查
-	var rep = int32(1)
 
 	// ports
 	var ports []corev1.ContainerPort
@@ -179,42 +309,20 @@ func (k *K8sWorker) StartApp(ctx context.Context, _ *worker0.App) (*worker0.Empt
 		MountPath: fmt.Sprintf("%s", app.WorkDir),
 		SubPath:   fmt.Sprintf("download"),
 	})
+	for _, v := range k.volumes(app)[1:] {
+		vmes = append(vmes, corev1.VolumeMount{
+			Name:      podVolumeName(app, v),
+			MountPath: v.MountPath,
+		})
+	}
 
 	// health
 	var readness *corev1.Probe
 	var liveness *corev1.Probe
 
 	if app.Health != nil {
-		if app.Health.Readness != nil {
-			readness = &corev1.Probe{
-				Handler: corev1.Handler{
-					HTTPGet: &corev1.HTTPGetAction{
-						Path: app.Health.Readness.Path,
-						Port: intstr.FromInt(app.Health.Readness.Port),
-					},
-				},
-				InitialDelaySeconds: 3,
-				TimeoutSeconds:      5,
-				PeriodSeconds:       1,
-				SuccessThreshold:    1,
-				FailureThreshold:    5,
-			}
-		}
-		if app.Health.Liveness != nil {
-			liveness = &corev1.Probe{
-				Handler: corev1.Handler{
-					HTTPGet: &corev1.HTTPGetAction{
-						Path: app.Health.Liveness.Path,
-						Port: intstr.FromInt(app.Health.Liveness.Port),
-					},
-				},
-				InitialDelaySeconds: 20,
-				TimeoutSeconds:      5,
-				PeriodSeconds:       1,
-				SuccessThreshold:    1,
-				FailureThreshold:    5,
-			}
-		}
+		readness = kubeProbe(app.Health.Readness, readyDefault)
+		liveness = kubeProbe(app.Health.Liveness, liveDefault)
 	}
 
 	// resources
@@ -232,15 +340,25 @@ func (k *K8sWorker) StartApp(ctx context.Context, _ *worker0.App) (*worker0.Empt
 		}
 	}
 
+	// small and sensitive files are mounted from config map and secret, the others are downloaded
+	pvols, pmounts := premiseVolumes(app)
+	vmes = append(vmes, pmounts...)
+
 	var initc []corev1.Container
-	if len(app.FilePremises) != 0 {
-		var commands []string
-		for _, premise := range app.FilePremises {
-			commands = append(commands, fmt.Sprintf("wget -O \"/%s/%s\" -c %s", app.UID, premise.Name, premise.AcquireAddr))
-			if len(premise.Shell) != 0 {
-				commands = append(commands, premise.Shell)
-			}
+	var commands []string
+	for _, premise := range app.FilePremises {
+		if premise.Content != nil || premise.Files != nil {
+			continue
 		}
+		commands = append(commands, fmt.Sprintf("wget -O \"/%s/%s\" -c %s", app.UID, premise.Name, premise.AcquireAddr))
+		if len(premise.CheckSum) != 0 {
+			commands = append(commands, fmt.Sprintf("echo \"%s  /%s/%s\" | sha256sum -c - || exit 1", premise.CheckSum, app.UID, premise.Name))
+		}
+		if len(premise.Shell) != 0 {
+			commands = append(commands, premise.Shell)
+		}
+	}
+	if len(commands) != 0 {
 		// get busy box image
 		pkg, err := core.PackageInfo(ctx, "busybox", "latest")
 		if err != nil {
@@ -257,20 +375,12 @@ func (k *K8sWorker) StartApp(ctx context.Context, _ *worker0.App) (*worker0.Empt
 					SubPath:   "download",
 				},
 			},
-			ImagePullPolicy: corev1.PullIfNotPresent,
+			ImagePullPolicy: corev1.PullPolicy(pkg.Image.PullPolicy),
 			WorkingDir:      fmt.Sprintf("/%s", app.UID),
 		})
 	}
 
 	var vols []corev1.Volume
-	vols = append(vols, corev1.Volume{
-		Name: fmt.Sprintf("%s-pvc", app.UID),
-		VolumeSource: corev1.VolumeSource{
-			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: fmt.Sprintf("%s-pvc", app.UID),
-			},
-		},
-	})
 	vols = append(vols, corev1.Volume{
 		Name: "run",
 		VolumeSource: corev1.VolumeSource{
@@ -279,232 +389,196 @@ func (k *K8sWorker) StartApp(ctx context.Context, _ *worker0.App) (*worker0.Empt
 			},
 		},
 	})
+	vols = append(vols, pvols...)
 
-	dep := v1.Deployment{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "Deployment",
-			APIVersion: "apps/v1",
-		},
+	tpl := corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-dep", app.UID),
-			Namespace: k.Namespace,
-			Labels:    app.Tags,
+			Labels: app.Tags,
 		},
-		Spec: v1.DeploymentSpec{
-			Replicas: &rep,
-			Selector: &metav1.LabelSelector{MatchLabels: app.Tags},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: app.Tags,
-				},
-				Spec: corev1.PodSpec{
-					Volumes:        vols,
-					InitContainers: initc,
-					Containers: []corev1.Container{
-						{
-							Name:            fmt.Sprintf("%s", app.UID),
-							Image:           app.Image,
-							Command:         app.Command,
-							Args:            nil,
-							WorkingDir:      app.WorkDir,
-							Ports:           ports,
-							Env:             envs,
-							VolumeMounts:    vmes,
-							LivenessProbe:   liveness,
-							ReadinessProbe:  readness,
-							Resources:       resourcereq,
-							ImagePullPolicy: corev1.PullIfNotPresent,
-						},
-					},
+		Spec: corev1.PodSpec{
+			Volumes:          vols,
+			InitContainers:   initc,
+			ImagePullSecrets: pullSecrets(app),
+			Containers: []corev1.Container{
+				{
+					Name:            fmt.Sprintf("%s", app.UID),
+					Image:           app.Image,
+					Command:         app.Command,
+					Args:            nil,
+					WorkingDir:      app.WorkDir,
+					Ports:           ports,
+					Env:             envs,
+					VolumeMounts:    vmes,
+					LivenessProbe:   liveness,
+					ReadinessProbe:  readness,
+					Resources:       resourcereq,
+					ImagePullPolicy: app.PullPolicy,
 				},
 			},
-			Strategy:        v1.DeploymentStrategy{Type: v1.RecreateDeploymentStrategyType},
-			MinReadySeconds: 10,
 		},
 	}
+	return &tpl, nil
+}
 
-	pvc := corev1.PersistentVolumeClaim{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "PersistentVolumeClaim",
-			APIVersion: "v1",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-pvc", app.UID),
-			Namespace: k.Namespace,
-			Labels:    app.Tags,
-		},
-		Spec: corev1.PersistentVolumeClaimSpec{
-			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
-			Resources: corev1.ResourceRequirements{
-				Requests: corev1.ResourceList{
-					corev1.ResourceStorage: resource.MustParse("1Gi"),
-				},
-			},
-			StorageClassName: &(k.StorageClass),
-		},
+// StopApp scale workload of app to zero and wait its pods terminated
+func (k *K8sWorker) StopApp(ctx context.Context, _ *worker0.App) (*worker0.Empty, error) {
+	log.Debug(ctx, "Currently to stop app")
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
 	}
+	cli, err := k.client(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "new k8s client")
+	}
+	found, err := k.scale(cli, app, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "scale app to zero")
+	}
+	if !found {
+		log.Info(ctx, "workload of app not found, app not started")
+		return &worker0.Empty{}, nil
+	}
+	err = waitPodsTerminated(ctx, cli, k.ns(app), app.UID)
+	if err != nil {
+		return nil, err
+	}
+	log.Info(ctx, "stop app success")
+	return &worker0.Empty{}, nil
+}
 
+// scale patch replicas of workload of app, false is returned if workload not found
+func (k *K8sWorker) scale(cli *base.Client, app *App, replicas int32) (bool, error) {
+	var err error
+	if app.Workload == WorkloadStatefulSet {
+		err = cli.ScaleStatefulSet(stsName(app.UID), k.ns(app), replicas)
+	} else {
+		err = cli.ScaleDeployment(fmt.Sprintf("%s-dep", app.UID), k.ns(app), replicas)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(errors.Cause(err)) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
 
-	//var srvp []corev1.ServicePort
-	////var igs []v1beta1.IngressRule
-	//for _, info := range app.Ports {
-	//	s := corev1.ServicePort{
-	//		Name:       info.Name,
-	//		Port:       int32(info.Port),
-	//		TargetPort: intstr.FromInt(info.Port),
-	//	}
-	//	switch corev1.Protocol(strings.ToUpper(info.Protocol)) {
-	//	case corev1.ProtocolTCP:
-	//		s.Protocol = corev1.ProtocolTCP
-	//	case corev1.ProtocolUDP:
-	//		s.Protocol = corev1.ProtocolUDP
-	//	case corev1.ProtocolSCTP:
-	//		s.Protocol = corev1.ProtocolSCTP
-	//	default:
-	//		return nil, errors.Errorf("port protocol [%s] not correct", info.Protocol)
-	//	}
-	//
-	//	srvp = append(srvp, s)
-	//
-	//	//igs = append(igs, v1beta1.IngressRule{
-	//	//	Host: info.IngressName,
-	//	//	IngressRuleValue: v1beta1.IngressRuleValue{
-	//	//		HTTP: &v1beta1.HTTPIngressRuleValue{
-	//	//			Paths: []v1beta1.HTTPIngressPath{
-	//	//				{
-	//	//					Backend: v1beta1.IngressBackend{
-	//	//						ServiceName: info.ServiceName,
-	//	//						ServicePort: intstr.FromInt(info.Port),
-	//	//					},
-	//	//				},
-	//	//			},
-	//	//		},
-	//	//	},
-	//	//})
-	//}
-	//
-	//if len(srvp) != 0 {
-	//
-	//}
-	//srv := corev1.Service{
-	//	TypeMeta: metav1.TypeMeta{
-	//		Kind:       "Service",
-	//		APIVersion: "v1",
-	//	},
-	//	ObjectMeta: metav1.ObjectMeta{
-	//		Name:      fmt.Sprintf("%s-service", app.UID),
-	//		Namespace: k.Namespace,
-	//		Labels:    app.Tags,
-	//	},
-	//	Spec: corev1.ServiceSpec{
-	//		Ports:    srvp,
-	//		Selector: app.Tags,
-	//		Type:     corev1.ServiceTypeClusterIP,
-	//	},
-	//}
-
-	marshal, err := yaml.Marshal(dep)
-	if err != nil {
-		return nil, errors.Wrap(err, "marshal dep")
-	}
-	fmt.Println(string(marshal))
-
-	marshal, err = yaml.Marshal(pvc)
-	if err != nil {
-		return nil, errors.Wrap(err, "marshal pvc")
-	}
-	fmt.Println(string(marshal))
-
-	//marshal, err = yaml.Marshal(srv)
-	//if err != nil {
-	//	return nil, errors.Wrap(err, "marshal srv")
-	//}
-	//fmt.Println(string(marshal))
-
-	log.Debug(ctx, "Currently new k8s client")
-	cli, err := base.NewClientByConfig(ctx, []byte(k.KubeConfig))
+// DestroyApp delete workload, service, ingress and pvc (unless data is kept) of app, then forget it
+func (k *K8sWorker) DestroyApp(ctx context.Context, wa *worker0.App) (*worker0.Empty, error) {
+	log.Debug(ctx, "Currently to destroy app")
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	cli, err := k.client(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "new k8s client")
 	}
+	// pods are deleted before deployment, so pvc is not in use when it is deleted
+	policy := metav1.DeletePropagationForeground
+	ops := metav1.DeleteOptions{PropagationPolicy: &policy}
+	meta := func(suffix string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s", app.UID, suffix), Namespace: k.ns(app)}
+	}
 
-	// service 在network的时候会创建, 这里需要添加tag
-	if len(app.Ports) != 0 {
-		service := fmt.Sprintf("%s-service", app.UID)
-		getService, err := cli.GetService(service, k.Namespace)
+	if app.Workload == WorkloadStatefulSet {
+		log.Debug(ctx, "Currently start to delete stateful set")
+		err = cli.DeleteStatefulSet(&v1.StatefulSet{ObjectMeta: meta("sts")}, ops)
 		if err != nil {
-			return nil, errors.Wrapf(err, "get service [%s]", service)
+			return nil, errors.Wrap(err, "delete stateful set")
 		}
-		getService.ObjectMeta.Labels = app.Tags
-		getService.Spec.Selector = app.Tags
-		err = cli.UpdateService(getService)
+		err = cli.DeleteService(&corev1.Service{ObjectMeta: meta("headless")}, ops)
 		if err != nil {
-			return nil, errors.Wrapf(err, "udpate service [%s]", service)
-		}
-	}
-
-	//log.Debug(ctx, "Currently start to create service")
-	//err = cli.CreateService(&srv)
-	//if err != nil {
-	//	return nil, errors.Wrap(err, "apply service")
-	//}
-
-	// 不实用ingress
-	//if len(k.Domain) != 0 {
-	//	igrs := v1beta1.Ingress{
-	//		TypeMeta: metav1.TypeMeta{
-	//			Kind:       "Ingress",
-	//			APIVersion: "extensions/v1beta1",
-	//		},
-	//		ObjectMeta: metav1.ObjectMeta{
-	//			Name:      fmt.Sprintf("%s-ingress", app.UID),
-	//			Namespace: k.Namespace,
-	//			Labels:    app.Tags,
-	//			Annotations: map[string]string{
-	//				"kubernetes.io/ingress.class":             "nginx",
-	//				"nginx.ingress.kubernetes.io/use-regex":   "true",
-	//				"nginx.ingress.kubernetes.io/enable-cors": "true",
-	//			},
-	//		},
-	//		Spec: v1beta1.IngressSpec{
-	//			Rules: igs,
-	//		},
-	//	}
-	//	log.Debug(ctx, "Currently start to create ingress")
-	//	err = cli.CreateIngress(&igrs)
-	//	if err != nil {
-	//		return nil, errors.Wrap(err, "apply ingress")
-	//	}
-	//
-	//	marshal, err = yaml.Marshal(igrs)
-	//	if err != nil {
-	//		return nil, errors.Wrap(err, "marshal ingress")
-	//	}
-	//	fmt.Println(string(marshal))
-	//}
-
-	log.Debug(ctx, "Currently start to create pvc")
-	err = cli.CreatePersistentVolumeClaim(&pvc)
-	if err != nil {
-		return nil, errors.Wrap(err, "apply pvc")
-	}
-
-	log.Debug(ctx, "Currently start to create deployment")
-	err = cli.CreateDeployment(&dep)
-	if err != nil {
-		return nil, errors.Wrap(err, "apply deployment")
+			return nil, errors.Wrap(err, "delete headless service")
+		}
+	} else {
+		log.Debug(ctx, "Currently start to delete deployment")
+		err = cli.DeleteDeployment(&v1.Deployment{ObjectMeta: meta("dep")}, ops)
+		if err != nil && !apierrors.IsNotFound(errors.Cause(err)) {
+			return nil, errors.Wrap(err, "delete deployment")
+		}
 	}
-	log.Debug(ctx, "Currently app deploy success")
+	err = waitPodsTerminated(ctx, cli, k.ns(app), app.UID)
+	if err != nil {
+		return nil, err
+	}
+	for _, suffix := range []string{"service", "nodeport", "lb"} {
+		log.Debugf(ctx, "Currently start to delete service [%s]", suffix)
+		err = cli.DeleteService(&corev1.Service{ObjectMeta: meta(suffix)}, ops)
+		if err != nil {
+			return nil, errors.Wrapf(err, "delete service [%s]", suffix)
+		}
+	}
+	for _, suffix := range []string{"ingress", "passthrough"} {
+		log.Debugf(ctx, "Currently start to delete ingress [%s]", suffix)
+		err = cli.DeleteIngress(&v1beta1.Ingress{ObjectMeta: meta(suffix)}, ops)
+		if err != nil {
+			return nil, errors.Wrapf(err, "delete ingress [%s]", suffix)
+		}
+	}
+	log.Debug(ctx, "Currently start to delete config map and secret of files")
+	err = cli.DeleteConfigMap(&corev1.ConfigMap{ObjectMeta: meta("files")}, ops)
+	if err != nil {
+		return nil, errors.Wrap(err, "delete config map of files")
+	}
+	err = cli.DeleteSecret(&corev1.Secret{ObjectMeta: meta("secret")}, ops)
+	if err != nil {
+		return nil, errors.Wrap(err, "delete secret of files")
+	}
+	err = cli.DeleteSecret(&corev1.Secret{ObjectMeta: meta("pull")}, ops)
+	if err != nil {
+		return nil, errors.Wrap(err, "delete pull secret")
+	}
+	// claims created by volume claim template are not deleted with stateful set,
+	// so claims of all the volumes are found by label
+	claims, err := cli.ListPersistentVolumeClaimsByLabels(k.ns(app), map[string]string{"uuid": app.UID})
+	if err != nil {
+		return nil, errors.Wrap(err, "list claims of app")
+	}
+	for i := range claims {
+		if wa != nil && wa.KeepData {
+			log.Infof(ctx, "keep pvc [%s] of app", claims[i].Name)
+			continue
+		}
+		log.Debugf(ctx, "Currently start to delete pvc [%s]", claims[i].Name)
+		err = cli.DeletePersistentVolumeClaim(&claims[i], ops)
+		if err != nil {
+			return nil, errors.Wrapf(err, "delete pvc [%s]", claims[i].Name)
+		}
+	}
+	if k.ns(app) != k.Namespace {
+		err = cli.DeleteNetworkPolicy(exposedPolicyName(app.UID), k.ns(app))
+		if err != nil {
+			return nil, errors.Wrap(err, "delete network policy of exposed ports")
+		}
+	}
+	repo.delete(app.UID)
+	log.Info(ctx, "destroy app success")
 	return &worker0.Empty{}, nil
 }
 
-func (k *K8sWorker) StopApp(ctx context.Context, app *worker0.App) (*worker0.Empty, error) {
-	// 将对应的app副本数量减为0
-	panic("implement me")
-}
-
-func (k *K8sWorker) DestroyApp(ctx context.Context, app *worker0.App) (*worker0.Empty, error) {
-	// 将对应app的所有资源删除
-	panic("implement me")
+// waitPodsTerminated wait until all the pods of app are gone
+func waitPodsTerminated(ctx context.Context, cli *base.Client, namespace, uid string) error {
+	toutctx, cancel := context.WithTimeout(ctx, podTerminateTimeout)
+	defer cancel()
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	for {
+		pods, err := cli.ListPodsByLabels(namespace, map[string]string{"uuid": uid})
+		if err != nil {
+			return errors.Wrap(err, "list pods of app")
+		}
+		if len(pods) == 0 {
+			return nil
+		}
+		log.Debugf(ctx, "Currently wait [%d] pods of app terminated", len(pods))
+		select {
+		case <-toutctx.Done():
+			return errors.Errorf("wait [%d] pods of app [%s] terminated timeout", len(pods), uid)
+		case <-ticker.C:
+		}
+	}
 }
 
 func (k *K8sWorker) FileMountEx(ctx context.Context, mount *worker0.App_FileMount) (*worker0.App_FileMount, error) {
@@ -546,6 +620,9 @@ func (k *K8sWorker) TagEx(ctx context.Context, tag *worker0.App_Tag) (*worker0.A
 	if tag.Key == "uuid" || tag.Key == "machine_id" {
 		return nil, errors.New("tag named 'uid' or 'machine_id' not support to set")
 	}
+	if tag.Key == k.NamespaceTag && len(app.Namespace) != 0 && tag.Value != app.Tags[tag.Key] {
+		return nil, errors.Errorf("app is placed in namespace [%s], tag [%s] can not be changed", app.Namespace, tag.Key)
+	}
 	app.Tags[tag.Key] = tag.Value
 	return tag, nil
 }
@@ -566,6 +643,7 @@ func (k *K8sWorker) EnvEx(ctx context.Context, envVar *worker0.App_EnvVar) (*wor
 	return &worker0.App_EnvVar{Key: envVar.Key, Value: envVar.Value}, nil
 }
 
+// NetworkEx add port to cluster ip service of app, and expose it by the mode of network
 func (k *K8sWorker) NetworkEx(ctx context.Context, network *worker0.App_Network) (*worker0.App_Network, error) {
 	log.Debug(ctx, "Currently start to execute network")
 	app, err := repo.load(ctx)
@@ -579,62 +657,71 @@ func (k *K8sWorker) NetworkEx(ctx context.Context, network *worker0.App_Network)
 	network.PortInfo.ProtocolType = worker0.App_Network_PortInf_TCP
 
 	// 内部service
-	service := fmt.Sprintf("%s-service", app.UID)
+	service := serviceName(app.UID)
 
-	// 外部ingress
-	//ingress := fmt.Sprintf("m%d-%s-%d.%s", k.MachineID, app.UID, network.PortInfo.Port, k.Domain)
 	pi := PortInfo{
 		Port:        int(network.PortInfo.Port),
 		Name:        network.PortInfo.Name,
 		Protocol:    worker0.App_Network_PortInf_Protocol_name[int32(network.PortInfo.ProtocolType)],
 		ServiceName: service,
-		//IngressName: ingress,
+		Expose:      exposeMode(network.ExposeType),
+	}
+	// use reserved node port if exist
+	if pi.Expose == ExposeNodePort {
+		pi.NodePort = app.ReservedPorts[pi.Port]
+	}
+	if network.DryRun {
+		external, err := k.plannedExternal(app, &pi)
+		if err != nil {
+			return nil, errors.Wrapf(err, "expose port [%d] by [%s]", pi.Port, pi.Expose)
+		}
+		network.RouteInfo = networkRoutes(service, pi.Port, external)
+		return network, nil
 	}
 
 	log.Debug(ctx, "Currently new k8s client")
-	cli, err := base.NewClientByConfig(ctx, []byte(k.KubeConfig))
+	cli, err := k.client(ctx)
 	//cli, err := base.NewClientInCluster(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "new k8s client")
 	}
+	err = k.placeApp(ctx, cli, app)
+	if err != nil {
+		return nil, errors.Wrap(err, "place app")
+	}
 
-	err = svcHandle(ctx, cli, service, k.Namespace, &pi)
+	_, err = svcHandle(ctx, cli, service, k.ns(app), corev1.ServiceTypeClusterIP, &pi)
 	if err != nil {
 		return nil, errors.Wrap(err, "svc handle")
 	}
-
-	time.Sleep(time.Second*2)
-
-	getService, err := cli.GetService(service, k.Namespace)
+	pi.External, err = k.expose(ctx, cli, app, &pi)
 	if err != nil {
-		return nil, errors.Wrapf(err, "find serive [%s]", service)
+		return nil, errors.Wrapf(err, "expose port [%d] by [%s]", pi.Port, pi.Expose)
 	}
 
-	var nodeport int
-	for _, port := range getService.Spec.Ports {
-		if port.Port == int32(pi.Port) {
-			nodeport = int(port.NodePort)
-			break
-		}
-	}
-	if nodeport == 0 {
-		return nil, errors.Errorf("find port [%d] target node port is nil", pi.Port)
+	network.RouteInfo = networkRoutes(service, pi.Port, pi.External)
+	app.Ports[int(network.PortInfo.Port)] = pi
+	err = k.allowExposed(ctx, cli, app)
+	if err != nil {
+		return nil, errors.Wrap(err, "allow traffic of exposed ports")
 	}
-	pi.NodePort = nodeport
 
-	inRoute := &worker0.App_Network_RouteInf{
+	return network, nil
+}
+
+// networkRoutes route IN by cluster ip service, and route OUT if port is exposed out of cluster
+func networkRoutes(service string, port int, external string) []*worker0.App_Network_RouteInf {
+	routes := []*worker0.App_Network_RouteInf{{
 		RouteType: worker0.App_Network_RouteInf_IN,
-		Router:    fmt.Sprintf("%s:%d", service, network.PortInfo.Port),
-	}
-	outRoute := &worker0.App_Network_RouteInf{
-		RouteType: worker0.App_Network_RouteInf_OUT,
-		Router:    fmt.Sprintf("%s:%d", k.NodeIP, nodeport),
+		Router:    fmt.Sprintf("%s:%d", service, port),
+	}}
+	if len(external) != 0 {
+		routes = append(routes, &worker0.App_Network_RouteInf{
+			RouteType: worker0.App_Network_RouteInf_OUT,
+			Router:    external,
+		})
 	}
-
-	network.RouteInfo = []*worker0.App_Network_RouteInf{inRoute, outRoute}
-	app.Ports[int(network.PortInfo.Port)] = pi
-
-	return network, nil
+	return routes
 }
 
 func svcFind(ctx context.Context, cli *base.Client, serviceName, namespace string) (*corev1.Service, error) {
@@ -649,33 +736,30 @@ func svcFind(ctx context.Context, cli *base.Client, serviceName, namespace strin
 	return service, nil
 }
 
-func svcHandle(ctx context.Context, cli *base.Client, serviceName, namespace string, port *PortInfo) error {
-	s := corev1.ServicePort{
-		Name:       port.Name,
-		Port:       int32(port.Port),
-		TargetPort: intstr.FromInt(port.Port),
-	}
-	switch corev1.Protocol(strings.ToUpper(port.Protocol)) {
-	case corev1.ProtocolTCP:
-		s.Protocol = corev1.ProtocolTCP
-	case corev1.ProtocolUDP:
-		s.Protocol = corev1.ProtocolUDP
-	case corev1.ProtocolSCTP:
-		s.Protocol = corev1.ProtocolSCTP
-	default:
-		return errors.Errorf("port protocol [%s] not correct", port.Protocol)
+// svcHandle add port to service of the type, service is created if not exist. port with the
+// same number is replaced
+func svcHandle(ctx context.Context, cli *base.Client, serviceName, namespace string, typ corev1.ServiceType, port *PortInfo) (*corev1.Service, error) {
+	s, err := servicePort(typ, port)
+	if err != nil {
+		return nil, err
 	}
 
 	findSvc, err := svcFind(ctx, cli, serviceName, namespace)
 	if err != nil {
-		return errors.Wrap(err, "find svc")
+		return nil, errors.Wrap(err, "find svc")
 	}
 	if findSvc != nil {
-		findSvc.Spec.Ports = append(findSvc.Spec.Ports, s)
-		log.Debug(ctx, "Currently start to update service")
+		var ports []corev1.ServicePort
+		for _, p := range findSvc.Spec.Ports {
+			if p.Port != s.Port {
+				ports = append(ports, p)
+			}
+		}
+		findSvc.Spec.Ports = append(ports, s)
+		log.Debugf(ctx, "Currently start to update service [%s]", serviceName)
 		err = cli.UpdateService(findSvc)
 		if err != nil {
-			return errors.Wrap(err, "update service")
+			return nil, errors.Wrap(err, "update service")
 		}
 	} else {
 		findSvc = &corev1.Service{
@@ -689,18 +773,46 @@ func svcHandle(ctx context.Context, cli *base.Client, serviceName, namespace str
 				//Labels:    tags,
 			},
 			Spec: corev1.ServiceSpec{
-				Ports:    []corev1.ServicePort{s},
+				Ports: []corev1.ServicePort{s},
 				//Selector: tags,
-				Type:     corev1.ServiceTypeNodePort,
+				Type: typ,
 			},
 		}
-		log.Debug(ctx, "Currently start to create service")
+		log.Debugf(ctx, "Currently start to create service [%s]", serviceName)
 		err = cli.CreateService(findSvc)
 		if err != nil {
-			return errors.Wrap(err, "apply service")
+			return nil, errors.Wrap(err, "apply service")
 		}
 	}
-	return nil
+	// ports of node port and load balancer are allocated by api server
+	svc, err := cli.GetService(serviceName, namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "find service [%s]", serviceName)
+	}
+	return svc, nil
+}
+
+// servicePort port of service with the type, node port is kept if it is reserved
+func servicePort(typ corev1.ServiceType, port *PortInfo) (corev1.ServicePort, error) {
+	s := corev1.ServicePort{
+		Name:       port.Name,
+		Port:       int32(port.Port),
+		TargetPort: intstr.FromInt(port.Port),
+	}
+	if typ == corev1.ServiceTypeNodePort {
+		s.NodePort = int32(port.NodePort)
+	}
+	switch corev1.Protocol(strings.ToUpper(port.Protocol)) {
+	case corev1.ProtocolTCP:
+		s.Protocol = corev1.ProtocolTCP
+	case corev1.ProtocolUDP:
+		s.Protocol = corev1.ProtocolUDP
+	case corev1.ProtocolSCTP:
+		s.Protocol = corev1.ProtocolSCTP
+	default:
+		return s, errors.Errorf("port protocol [%s] not correct", port.Protocol)
+	}
+	return s, nil
 }
 
 // FilePremiseEx file premise
@@ -720,10 +832,31 @@ func (k *K8sWorker) FilePremiseEx(ctx context.Context, file *worker0.App_File) (
 	if e, ok := app.FilePremises[key]; ok {
 		return nil, errors.Errorf("file premise exist [%#+v]", e)
 	}
+	if file.Sensitive && len(file.Shell) != 0 {
+		return nil, errors.Errorf("sensitive file [%s] is delivered by secret, shell [%s] not support", file.Name, file.Shell)
+	}
+	if len(file.ExtractTo) != 0 && !file.Sensitive {
+		return nil, errors.Errorf("file [%s] is extracted only if it is sensitive, use shell instead", file.Name)
+	}
 	premise := FilePremise{
-		Name:        file.Name,
-		AcquireAddr: file.AcquireAddr,
-		Shell:       file.Shell,
+		Name:            file.Name,
+		AcquireAddr:     file.AcquireAddr,
+		Shell:           file.Shell,
+		Sensitive:       file.Sensitive,
+		CheckSum:        file.CheckSum,
+		ExtractTo:       file.ExtractTo,
+		StripComponents: int(file.StripComponents),
+	}
+	premise.Content, err = fetchPremise(ctx, premise)
+	if err != nil {
+		return nil, err
+	}
+	if len(premise.ExtractTo) != 0 {
+		err = extractPremise(&premise, premise.Content)
+		if err != nil {
+			return nil, err
+		}
+		premise.Content = nil
 	}
 	app.FilePremises[key] = premise
 	return file, nil
@@ -755,37 +888,22 @@ func (k *K8sWorker) HealthEx(ctx context.Context, health *worker0.App_Health) (*
 		return nil, errors.Wrap(err, "fail to load app from repo")
 	}
 	var healthOpt HealthOption
-	if health.Readness != nil {
+	if !emptyProbe(health.Readness) {
 		log.Debugf(ctx, "Currently get read ness health info [%#+v]", health.Readness)
-		read := &HealthBasic{
-			Path: health.Readness.Path,
-			Port: int(health.Readness.Port),
-		}
-		switch health.Readness.MethodType {
-		case worker0.App_Health_Basic_GET:
-			read.Method = HttpGet
-		case worker0.App_Health_Basic_POST:
-			read.Method = HttpPost
-		default:
-			return nil, errors.Wrapf(err, "fail to parse method [%s]", health.Readness.MethodType)
+		healthOpt.Readness, err = probeOpt(health.Readness)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse readness")
 		}
-		healthOpt.Readness = read
 	}
-	if health.Liveness != nil {
+	if !emptyProbe(health.Liveness) {
 		log.Debugf(ctx, "Currently get live ness health info [%#+v]", health.Liveness)
-		live := HealthBasic{
-			Path: health.Liveness.Path,
-			Port: int(health.Liveness.Port),
+		healthOpt.Liveness, err = probeOpt(health.Liveness)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse liveness")
 		}
-		switch health.Liveness.MethodType {
-		case worker0.App_Health_Basic_GET:
-			live.Method = HttpGet
-		case worker0.App_Health_Basic_POST:
-			live.Method = HttpPost
-		default:
-			return nil, errors.Wrapf(err, "fail to parse method [%s]", health.Liveness.MethodType)
+		if healthOpt.Liveness.SuccessThreshold > 1 {
+			return nil, errors.Errorf("success threshold [%d] of liveness must be 1", healthOpt.Liveness.SuccessThreshold)
 		}
-		healthOpt.Liveness = &live
 	}
 	if healthOpt.Readness == nil && healthOpt.Liveness == nil {
 		log.Infof(ctx, "not set health info")
@@ -809,3 +927,12 @@ func (k *K8sWorker) LogEx(ctx context.Context, appLog *worker0.App_Log) (*worker
 
 	return appLog, nil
 }
+
+// RestartEx pods of deployment are always restarted by kubelet, other policies are not supported
+func (k *K8sWorker) RestartEx(ctx context.Context, restart *worker0.App_Restart) (*worker0.App_Restart, error) {
+	log.Debug(ctx, "Currently start to execute set restart policy")
+	if restart.PolicyType != worker0.App_Restart_Always {
+		return nil, errors.Errorf("restart policy [%s] not support, deployment only support [Always]", restart.PolicyType)
+	}
+	return restart, nil
+}
@@ -23,8 +23,17 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/zibuyu28/cmapp/common/base64"
 	"github.com/zibuyu28/cmapp/common/md5"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
 	"github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/core"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
 	"google.golang.org/grpc/metadata"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
 	"testing"
 )
 
@@ -139,20 +148,21 @@ func TestK8sWorker_StartApp(t *testing.T) {
 			Name:    "baseos",
 			Version: "latest",
 			Image: struct {
-				ImageName     string   `json:"image_name" validate:"required"`
-				Tag           string   `json:"tag" validate:"required"`
-				WorkDir       string   `json:"work_dir" validate:"required"`
-				StartCommands []string `json:"start_command" validate:"required"`
+				ImageName     string   `json:"image_name"`
+				Tag           string   `json:"tag"`
+				WorkDir       string   `json:"work_dir"`
+				StartCommands []string `json:"start_command"`
+				PullPolicy    string   `json:"pull_policy"`
 			}{
 				ImageName: "harbor.hyperchain.cn/platform/library/busybox",
 				Tag:       "latest",
 				WorkDir:   "/",
 			},
 			Binary: struct {
-				Download            string   `json:"download" validate:"required"`
-				CheckSum            string   `json:"check_sum" validate:"required"`
+				Download            string   `json:"download"`
+				CheckSum            string   `json:"check_sum"`
 				PackageHandleShells []string `json:"package_handle_shells"`
-				StartCommands       []string `json:"start_command" validate:"required"`
+				StartCommands       []string `json:"start_command"`
 			}{},
 		}, nil
 	})
@@ -184,4 +194,112 @@ func TestBase64(t *testing.T) {
 	decode, err := base64.Decode(bs)
 	assert.Nil(t, err)
 	assert.NotNil(t, decode)
-}
\ No newline at end of file
+}
+
+// useCluster replace client of worker with fake clientset which has the objects
+func useCluster(t *testing.T, objs ...runtime.Object) *fake.Clientset {
+	fc := fakeCluster()
+	for _, obj := range objs {
+		assert.Nil(t, fc.Tracker().Add(obj))
+	}
+	old := kubeClient
+	t.Cleanup(func() { kubeClient = old })
+	kubeClient = func(ctx context.Context, kubeConfig string) (*base.Client, error) {
+		return base.NewClientByInterface(ctx, fc), nil
+	}
+	return fc
+}
+
+// appContext context of app in repo
+func appContext(t *testing.T, app *App) context.Context {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{"MA_UUID": app.UID}))
+	assert.Nil(t, repo.new(ctx, app))
+	t.Cleanup(func() { repo.delete(app.UID) })
+	return ctx
+}
+
+func TestStopApp(t *testing.T) {
+	k := &K8sWorker{Namespace: "ns"}
+	replicas := int32(2)
+	meta := func(name string) metav1.ObjectMeta { return metav1.ObjectMeta{Name: name, Namespace: "ns"} }
+	fc := useCluster(t,
+		&v1.Deployment{ObjectMeta: meta("stop1-dep"), Spec: v1.DeploymentSpec{Replicas: &replicas}},
+		&v1.StatefulSet{ObjectMeta: meta("stop2-sts"), Spec: v1.StatefulSetSpec{Replicas: &replicas}},
+	)
+	ctx := context.Background()
+
+	_, err := k.StopApp(appContext(t, &App{UID: "stop1"}), nil)
+	assert.Nil(t, err)
+	dep, err := fc.AppsV1().Deployments("ns").Get(ctx, "stop1-dep", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), *dep.Spec.Replicas)
+
+	_, err = k.StopApp(appContext(t, &App{UID: "stop2", Workload: WorkloadStatefulSet}), nil)
+	assert.Nil(t, err)
+	sts, err := fc.AppsV1().StatefulSets("ns").Get(ctx, "stop2-sts", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), *sts.Spec.Replicas)
+
+	_, err = k.StopApp(appContext(t, &App{UID: "stop3"}), nil)
+	assert.Nil(t, err, "app not started is stopped")
+}
+
+func TestDestroyApp(t *testing.T) {
+	k := &K8sWorker{Namespace: "ns"}
+	meta := func(name, ns string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: ns, Labels: map[string]string{"uuid": "destroy1"}}
+	}
+	objects := func(ns string) []runtime.Object {
+		return []runtime.Object{
+			&v1.Deployment{ObjectMeta: meta("destroy1-dep", ns)},
+			&corev1.Service{ObjectMeta: meta("destroy1-service", ns)},
+			&corev1.ConfigMap{ObjectMeta: meta("destroy1-files", ns)},
+			&corev1.Secret{ObjectMeta: meta("destroy1-secret", ns)},
+			&corev1.PersistentVolumeClaim{ObjectMeta: meta("destroy1-data", ns)},
+		}
+	}
+	ctx := context.Background()
+
+	// data is kept, app placed in namespace of tag releases its exposed policy
+	policy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: exposedPolicyName("destroy1"), Namespace: "tag-ns"}}
+	fc := useCluster(t, append(objects("tag-ns"), policy)...)
+	actx := appContext(t, &App{UID: "destroy1", Namespace: "tag-ns"})
+	_, err := k.DestroyApp(actx, &worker0.App{KeepData: true})
+	assert.Nil(t, err)
+	_, err = fc.AppsV1().Deployments("tag-ns").Get(ctx, "destroy1-dep", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = fc.CoreV1().Services("tag-ns").Get(ctx, "destroy1-service", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = fc.CoreV1().ConfigMaps("tag-ns").Get(ctx, "destroy1-files", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = fc.CoreV1().Secrets("tag-ns").Get(ctx, "destroy1-secret", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = fc.NetworkingV1().NetworkPolicies("tag-ns").Get(ctx, policy.Name, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "exposed policy is deleted")
+	_, err = fc.CoreV1().PersistentVolumeClaims("tag-ns").Get(ctx, "destroy1-data", metav1.GetOptions{})
+	assert.Nil(t, err, "claim is kept")
+	_, err = repo.load(actx)
+	assert.NotNil(t, err, "app is forgotten")
+
+	// data is deleted
+	fc = useCluster(t, objects("ns")...)
+	_, err = k.DestroyApp(appContext(t, &App{UID: "destroy1"}), &worker0.App{})
+	assert.Nil(t, err)
+	_, err = fc.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "destroy1-data", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "claim is deleted")
+
+	// claims of stateful set are created by template, they are found by label
+	fc = useCluster(t,
+		&v1.StatefulSet{ObjectMeta: meta("destroy1-sts", "ns")},
+		&corev1.Service{ObjectMeta: meta("destroy1-headless", "ns")},
+		&corev1.PersistentVolumeClaim{ObjectMeta: meta("data-destroy1-sts-0", "ns")},
+	)
+	_, err = k.DestroyApp(appContext(t, &App{UID: "destroy1", Workload: WorkloadStatefulSet}), nil)
+	assert.Nil(t, err)
+	_, err = fc.AppsV1().StatefulSets("ns").Get(ctx, "destroy1-sts", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = fc.CoreV1().Services("ns").Get(ctx, "destroy1-headless", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = fc.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "data-destroy1-sts-0", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "claim of stateful set is deleted")
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"bufio"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sync"
+	"time"
+)
+
+// StreamLogs stream logs of all pods of app
+func (k *K8sWorker) StreamLogs(req *worker0.LogReq, stream worker0.Worker0_StreamLogsServer) error {
+	ctx := stream.Context()
+	app, err := repo.load(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fail to load app from repo")
+	}
+	cli, err := k.client(ctx)
+	if err != nil {
+		return errors.Wrap(err, "new k8s client")
+	}
+	pods, err := cli.ListPodsByLabels(k.ns(app), map[string]string{"uuid": app.UID})
+	if err != nil {
+		return errors.Wrap(err, "list pods of app")
+	}
+	if len(pods) == 0 {
+		return errors.Errorf("no pod of app [%s] found", app.UID)
+	}
+	opts := podLogOptions(app.UID, req)
+
+	// grpc stream is not safe to send concurrently
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(chan error, len(pods))
+	for _, p := range pods {
+		wg.Add(1)
+		go func(pod string) {
+			defer wg.Done()
+			rc, err := cli.PodLogs(k.ns(app), pod, opts)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer rc.Close()
+			s := bufio.NewScanner(rc)
+			s.Buffer(make([]byte, 64*1024), 1024*1024)
+			for s.Scan() {
+				mu.Lock()
+				err = stream.Send(&worker0.LogLine{Line: s.Text(), Source: pod})
+				mu.Unlock()
+				if err != nil {
+					errs <- errors.Wrap(err, "send log line")
+					return
+				}
+			}
+			if err = s.Err(); err != nil && ctx.Err() == nil {
+				errs <- errors.Wrapf(err, "read logs of pod [%s]", pod)
+			}
+		}(p.Name)
+	}
+	wg.Wait()
+	close(errs)
+	for e := range errs {
+		log.Errorf(ctx, "Manage err when stream logs of app. Err: [%v]", e)
+		err = e
+	}
+	return err
+}
+
+func podLogOptions(container string, req *worker0.LogReq) *corev1.PodLogOptions {
+	opts := &corev1.PodLogOptions{Container: container, Follow: req.Follow}
+	if req.Tail > 0 {
+		tail := int64(req.Tail)
+		opts.TailLines = &tail
+	}
+	if req.SinceTime > 0 {
+		since := metav1.NewTime(time.Unix(req.SinceTime, 0))
+		opts.SinceTime = &since
+	}
+	return opts
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sort"
+)
+
+// ns namespace of app, it is decided by namespace tag before app is placed
+func (k *K8sWorker) ns(app *App) string {
+	if len(app.Namespace) != 0 {
+		return app.Namespace
+	}
+	return k.tagNamespace(app.Tags)
+}
+
+// tagNamespace namespace derived from namespace tag, like '<namespace>-<tag value>'. Namespace
+// of worker is used if namespace tag is not enabled or app has no such tag
+func (k *K8sWorker) tagNamespace(tags map[string]string) string {
+	if len(k.NamespaceTag) == 0 || len(tags[k.NamespaceTag]) == 0 {
+		return k.Namespace
+	}
+	return base.TagNamespace(k.Namespace, tags[k.NamespaceTag])
+}
+
+// placeApp fix namespace of app before its first object is created. Namespace derived from tag
+// is prepared with its quota, network policy and permissions of worker by machine driver, the
+// worker can not create namespaces itself
+func (k *K8sWorker) placeApp(ctx context.Context, cli *base.Client, app *App) error {
+	if len(app.Namespace) != 0 {
+		return nil
+	}
+	ns := k.tagNamespace(app.Tags)
+	if ns != k.Namespace {
+		err := k.checkNamespace(cli, ns, app.Tags[k.NamespaceTag])
+		if err != nil {
+			return errors.Wrapf(err, "check namespace [%s]", ns)
+		}
+	}
+	log.Debugf(ctx, "Currently place app in namespace [%s]", ns)
+	app.Namespace = ns
+	return nil
+}
+
+// checkNamespace namespace of tag value must be prepared by machine driver
+func (k *K8sWorker) checkNamespace(cli *base.Client, ns, value string) error {
+	got, err := cli.GetNamespace(ns)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return errors.Errorf("namespace of tag [%s=%s] is not prepared, add [%s] to namespace tag values of machine", k.NamespaceTag, value, value)
+		}
+		return errors.Wrapf(err, "get namespace [%s]", ns)
+	}
+	if got.Labels[base.IsolatedLabel] != "true" {
+		return errors.Errorf("namespace [%s] is not prepared for tag [%s=%s]", ns, k.NamespaceTag, value)
+	}
+	return nil
+}
+
+// exposedPolicyName name of network policy which allows traffic to exposed ports of app
+func exposedPolicyName(uid string) string {
+	return fmt.Sprintf("%s-exposed", uid)
+}
+
+// exposedPolicy pods of app accept traffic from anywhere on ports exposed by node port or load
+// balancer. Source of such traffic is node after SNAT or external address, it is dropped by
+// isolation policy otherwise. nil is returned if no port is exposed in such way
+func exposedPolicy(app *App, ns string) *networkingv1.NetworkPolicy {
+	var ports []int
+	for port, pi := range app.Ports {
+		if pi.Expose == ExposeNodePort || pi.Expose == ExposeLoadBalancer {
+			ports = append(ports, port)
+		}
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+	sort.Ints(ports)
+	var nps []networkingv1.NetworkPolicyPort
+	for _, port := range ports {
+		protocol := corev1.Protocol(app.Ports[port].Protocol)
+		p := intstr.FromInt(port)
+		nps = append(nps, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &p})
+	}
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: exposedPolicyName(app.UID), Namespace: ns},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"uuid": app.UID}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			// rule without peers allows all sources on the ports
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{Ports: nps}},
+		},
+	}
+}
+
+// allowExposed let traffic to exposed ports of app pass isolation policy of namespace created for tag
+func (k *K8sWorker) allowExposed(ctx context.Context, cli *base.Client, app *App) error {
+	ns := k.ns(app)
+	if ns == k.Namespace {
+		return nil
+	}
+	np := exposedPolicy(app, ns)
+	if np == nil {
+		return nil
+	}
+	log.Debugf(ctx, "Currently allow traffic to exposed ports of app in namespace [%s]", ns)
+	return cli.CreateNetworkPolicy(np)
+}
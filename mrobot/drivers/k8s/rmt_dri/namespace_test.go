@@ -0,0 +1,90 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rmt_dri
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"strings"
+	"testing"
+)
+
+func TestTagNamespace(t *testing.T) {
+	k := &K8sWorker{Namespace: "cmapp"}
+	assert.Equal(t, "cmapp", k.tagNamespace(map[string]string{"chain": "c1"}))
+
+	k.NamespaceTag = "chain"
+	assert.Equal(t, "cmapp", k.tagNamespace(map[string]string{"other": "c1"}))
+	assert.Equal(t, "cmapp-fabric-c1", k.tagNamespace(map[string]string{"chain": "Fabric_C1"}))
+	long := k.tagNamespace(map[string]string{"chain": strings.Repeat("a", 70)})
+	assert.Len(t, long, 63)
+
+	app := &App{Tags: map[string]string{"chain": "c1"}, Namespace: "cmapp"}
+	assert.Equal(t, "cmapp", k.ns(app))
+	app.Namespace = ""
+	assert.Equal(t, "cmapp-c1", k.ns(app))
+}
+
+func TestPlaceApp(t *testing.T) {
+	ctx := context.Background()
+	k := &K8sWorker{Namespace: "cmapp", NamespaceTag: "chain"}
+	fc := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "cmapp-c1", Labels: map[string]string{base.IsolatedLabel: "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "cmapp-c2"}},
+	)
+	cli := base.NewClientByInterface(ctx, fc)
+
+	app := &App{Tags: map[string]string{"chain": "c1"}}
+	assert.Nil(t, k.placeApp(ctx, cli, app))
+	assert.Equal(t, "cmapp-c1", app.Namespace)
+
+	app = &App{Tags: map[string]string{"chain": "c2"}}
+	assert.NotNil(t, k.placeApp(ctx, cli, app), "namespace is not prepared for tag")
+	app = &App{Tags: map[string]string{"chain": "c3"}}
+	err := k.placeApp(ctx, cli, app)
+	assert.NotNil(t, err, "namespace does not exist")
+	assert.Contains(t, err.Error(), "add [c3]")
+	assert.Empty(t, app.Namespace)
+
+	app = &App{}
+	assert.Nil(t, k.placeApp(ctx, cli, app))
+	assert.Equal(t, "cmapp", app.Namespace)
+}
+
+func TestExposedPolicy(t *testing.T) {
+	app := &App{UID: "app1", Ports: map[int]PortInfo{
+		7050: {Port: 7050, Protocol: "TCP", Expose: ExposeNodePort},
+		9443: {Port: 9443, Protocol: "TCP", Expose: ExposeClusterIP},
+		8443: {Port: 8443, Protocol: "TCP", Expose: ExposeLoadBalancer},
+	}}
+	np := exposedPolicy(app, "cmapp-c1")
+	assert.Equal(t, "app1-exposed", np.Name)
+	assert.Equal(t, map[string]string{"uuid": "app1"}, np.Spec.PodSelector.MatchLabels)
+	assert.Len(t, np.Spec.Ingress, 1)
+	assert.Empty(t, np.Spec.Ingress[0].From, "all sources are allowed")
+	var ports []int
+	for _, p := range np.Spec.Ingress[0].Ports {
+		ports = append(ports, p.Port.IntValue())
+	}
+	assert.Equal(t, []int{7050, 8443}, ports)
+
+	app.Ports = map[int]PortInfo{9443: {Port: 9443, Expose: ExposeClusterIP}}
+	assert.Nil(t, exposedPolicy(app, "cmapp-c1"))
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"math/rand"
+	"sync"
+)
+
+// default node port range of kube-apiserver
+const (
+	nodePortMin = 30000
+	nodePortMax = 32767
+)
+
+// portMu serialize port check and reservation
+var portMu sync.Mutex
+
+// usedNodePorts node ports used or reserved by apps of this agent. Container ports are not
+// tracked since each pod has its own network, apps can listen on the same port
+func usedNodePorts() map[int]string {
+	nodePorts := make(map[int]string)
+	for _, a := range repo.list() {
+		for _, pi := range a.Ports {
+			if pi.NodePort != 0 {
+				nodePorts[pi.NodePort] = a.UID
+			}
+		}
+		for _, np := range a.ReservedPorts {
+			if np != 0 {
+				nodePorts[np] = a.UID
+			}
+		}
+	}
+	return nodePorts
+}
+
+// clusterNodePorts node ports used by all services in cluster
+func clusterNodePorts(cli *base.Client) (map[int]struct{}, error) {
+	svcs, err := cli.ListServices("")
+	if err != nil {
+		return nil, errors.Wrap(err, "list services")
+	}
+	nps := make(map[int]struct{})
+	for _, svc := range svcs {
+		for _, p := range svc.Spec.Ports {
+			if p.NodePort != 0 {
+				nps[int(p.NodePort)] = struct{}{}
+			}
+		}
+	}
+	return nps, nil
+}
+
+func (k *K8sWorker) checkPort(cli *base.Client, uid string, req *worker0.PortReq) (*worker0.PortRes, error) {
+	res := &worker0.PortRes{Port: req.Port, HostPort: req.HostPort}
+	nodePorts := usedNodePorts()
+	if req.HostPort != 0 {
+		if req.HostPort < nodePortMin || req.HostPort > nodePortMax {
+			res.Message = fmt.Sprintf("node port [%d] out of range [%d-%d]", req.HostPort, nodePortMin, nodePortMax)
+			return res, nil
+		}
+		if owner, ok := nodePorts[int(req.HostPort)]; ok && owner != uid {
+			res.Message = fmt.Sprintf("node port [%d] is used by app [%s]", req.HostPort, owner)
+			return res, nil
+		}
+		for _, e := range req.ExcludeHostPorts {
+			if e == req.HostPort {
+				res.Message = fmt.Sprintf("node port [%d] is reserved in core", req.HostPort)
+				return res, nil
+			}
+		}
+		if _, ok := nodePorts[int(req.HostPort)]; !ok {
+			cnps, err := clusterNodePorts(cli)
+			if err != nil {
+				return nil, errors.Wrap(err, "get node ports of cluster")
+			}
+			if _, ok := cnps[int(req.HostPort)]; ok {
+				res.Message = fmt.Sprintf("node port [%d] is used in cluster", req.HostPort)
+				return res, nil
+			}
+		}
+	}
+	res.Available = true
+	return res, nil
+}
+
+// CheckPort check whether container port and node port are available
+func (k *K8sWorker) CheckPort(ctx context.Context, req *worker0.PortReq) (*worker0.PortRes, error) {
+	uid, _ := guid(ctx)
+	cli, err := k.client(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "new k8s client")
+	}
+	portMu.Lock()
+	defer portMu.Unlock()
+	res, err := k.checkPort(cli, uid, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "check port")
+	}
+	log.Debugf(ctx, "Currently check port [%d], node port [%d], available [%t]", req.Port, req.HostPort, res.Available)
+	return res, nil
+}
+
+// ReservePort reserve container port for app, and node port if port is exposed by NodePort,
+// node port is picked if not set. Container port is only unique in app
+func (k *K8sWorker) ReservePort(ctx context.Context, req *worker0.PortReq) (*worker0.PortRes, error) {
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	if req.Port == 0 {
+		return nil, errors.New("port to reserve is nil")
+	}
+	cli, err := k.client(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "new k8s client")
+	}
+	portMu.Lock()
+	defer portMu.Unlock()
+	res, err := k.checkPort(cli, app.UID, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "check port")
+	}
+	if !res.Available {
+		return res, nil
+	}
+	// reserve again returns the node port already reserved, so core can record it once
+	if np, ok := app.ReservedPorts[int(res.Port)]; ok && req.HostPort == 0 && (np != 0) == (req.ExposeType == worker0.App_Network_NodePort) {
+		res.HostPort = int32(np)
+		log.Debugf(ctx, "Currently port [%d] node port [%d] is already reserved by app", res.Port, np)
+		return res, nil
+	}
+	if req.ExposeType != worker0.App_Network_NodePort {
+		res.HostPort = 0
+	} else if req.HostPort == 0 {
+		exclude, err := clusterNodePorts(cli)
+		if err != nil {
+			return nil, errors.Wrap(err, "get node ports of cluster")
+		}
+		for p := range usedNodePorts() {
+			exclude[p] = struct{}{}
+		}
+		for _, e := range req.ExcludeHostPorts {
+			exclude[int(e)] = struct{}{}
+		}
+		np, err := pickNodePort(exclude)
+		if err != nil {
+			return nil, errors.Wrap(err, "pick node port")
+		}
+		res.HostPort = int32(np)
+	}
+	app.ReservedPorts[int(res.Port)] = int(res.HostPort)
+	log.Infof(ctx, "Currently reserve port [%d] node port [%d] for app", res.Port, res.HostPort)
+	return res, nil
+}
+
+func pickNodePort(exclude map[int]struct{}) (int, error) {
+	for i := 0; i < 50; i++ {
+		p := nodePortMin + rand.Intn(nodePortMax-nodePortMin+1)
+		if _, ok := exclude[p]; !ok {
+			return p, nil
+		}
+	}
+	for p := nodePortMin; p <= nodePortMax; p++ {
+		if _, ok := exclude[p]; !ok {
+			return p, nil
+		}
+	}
+	return 0, errors.New("no node port available")
+}
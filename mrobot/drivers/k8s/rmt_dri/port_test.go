@@ -0,0 +1,66 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"google.golang.org/grpc/metadata"
+	"k8s.io/client-go/kubernetes/fake"
+	"testing"
+)
+
+func TestReservePort(t *testing.T) {
+	old := kubeClient
+	defer func() { kubeClient = old }()
+	kubeClient = func(ctx context.Context, kubeConfig string) (*base.Client, error) {
+		return base.NewClientByInterface(ctx, fake.NewSimpleClientset()), nil
+	}
+	k := &K8sWorker{Namespace: "ns"}
+	appCtx := func(uid string) context.Context {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{"MA_UUID": uid}))
+		assert.Nil(t, repo.new(ctx, &App{ReservedPorts: map[int]int{}}))
+		return ctx
+	}
+	c1, c2 := appCtx("port-app1"), appCtx("port-app2")
+	defer repo.delete("port-app1")
+	defer repo.delete("port-app2")
+
+	// pods have their own network, apps can use the same container port
+	res, err := k.ReservePort(c1, &worker0.PortReq{Port: 5984, ExposeType: worker0.App_Network_ClusterIP})
+	assert.Nil(t, err)
+	assert.True(t, res.Available)
+	res, err = k.ReservePort(c2, &worker0.PortReq{Port: 5984, ExposeType: worker0.App_Network_ClusterIP})
+	assert.Nil(t, err)
+	assert.True(t, res.Available)
+	assert.Zero(t, res.HostPort)
+
+	res, err = k.ReservePort(c1, &worker0.PortReq{Port: 7051, ExposeType: worker0.App_Network_NodePort})
+	assert.Nil(t, err)
+	assert.True(t, res.Available)
+	np := res.HostPort
+	assert.NotZero(t, np)
+	res, err = k.ReservePort(c1, &worker0.PortReq{Port: 7051, ExposeType: worker0.App_Network_NodePort})
+	assert.Nil(t, err)
+	assert.Equal(t, np, res.HostPort, "node port already reserved is returned")
+
+	res, err = k.ReservePort(c2, &worker0.PortReq{Port: 7051, HostPort: np, ExposeType: worker0.App_Network_NodePort})
+	assert.Nil(t, err)
+	assert.False(t, res.Available, "node port is unique in cluster")
+}
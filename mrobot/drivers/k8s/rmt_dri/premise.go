@@ -0,0 +1,269 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	agfw "github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/worker"
+	"io"
+	"io/ioutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// inlineFileLimit file not larger than it is delivered by config map, larger one is downloaded by init container
+var inlineFileLimit int64 = 256 * 1024
+
+// objectSizeLimit config map and secret can not be larger than 1MiB
+const objectSizeLimit = 1024 * 1024
+
+// secretFileLimit limit of sensitive file, it is carried by secret
+const secretFileLimit = objectSizeLimit
+
+// premiseClient client to acquire premise, slow server must not block the app
+var premiseClient = &http.Client{Timeout: time.Minute}
+
+// filesName name of config map which carries files of app
+func filesName(uid string) string {
+	return fmt.Sprintf("%s-files", uid)
+}
+
+// secretName name of secret which carries sensitive files of app
+func secretName(uid string) string {
+	return fmt.Sprintf("%s-secret", uid)
+}
+
+// fetchPremise acquire content of premise to deliver it by config map or secret. nil content
+// means the file is left to init container, because it is large or has shell to execute
+func fetchPremise(ctx context.Context, premise FilePremise) ([]byte, error) {
+	if len(premise.Shell) != 0 && !premise.Sensitive {
+		return nil, nil
+	}
+	limit := inlineFileLimit
+	if premise.Sensitive {
+		limit = secretFileLimit
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, premise.AcquireAddr, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "new request of file [%s]", premise.Name)
+	}
+	resp, err := premiseClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get file [%s]", premise.Name)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("get file [%s] with status [%s]", premise.Name, resp.Status)
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "read file [%s]", premise.Name)
+	}
+	if int64(len(content)) > limit {
+		if premise.Sensitive {
+			return nil, errors.Errorf("sensitive file [%s] is larger than [%d] bytes", premise.Name, limit)
+		}
+		log.Debugf(ctx, "Currently file [%s] is larger than [%d] bytes, download it by init container", premise.Name, limit)
+		return nil, nil
+	}
+	err = verifyCheckSum(content, premise.CheckSum)
+	if err != nil {
+		return nil, errors.Wrapf(err, "verify file [%s]", premise.Name)
+	}
+	return content, nil
+}
+
+// extractPremise extract the sensitive archive into files which are carried by secret
+func extractPremise(premise *FilePremise, content []byte) error {
+	files, err := agfw.ExtractTarGz(bytes.NewReader(content), premise.StripComponents, secretFileLimit)
+	if err != nil {
+		return errors.Wrapf(err, "extract file [%s]", premise.Name)
+	}
+	if len(files) == 0 {
+		return errors.Errorf("no file is extracted from [%s]", premise.Name)
+	}
+	premise.Files = files
+	return nil
+}
+
+// verifyCheckSum compare sha256 of content with the check sum, empty check sum is not verified
+func verifyCheckSum(content []byte, sum string) error {
+	if len(sum) == 0 {
+		return nil
+	}
+	h := sha256.Sum256(content)
+	if got := hex.EncodeToString(h[:]); !strings.EqualFold(got, sum) {
+		return errors.Errorf("check sum [%s] not match [%s]", got, sum)
+	}
+	return nil
+}
+
+// inlinePremises premises delivered by config map or secret, ordered by their keys
+func inlinePremises(app *App) (keys []string) {
+	for key, premise := range app.FilePremises {
+		if premise.Content != nil || premise.Files != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// premiseKey key of file in config map or secret, name of file is used if it is a valid key
+func premiseKey(key string, premise FilePremise) string {
+	if len(validation.IsConfigMapKey(premise.Name)) == 0 {
+		return premise.Name
+	}
+	return key
+}
+
+// premiseMountTo path where inline file is mounted, decided by mount of the file without volume,
+// relative path is under workdir. file is put in workdir as init container does by default
+func premiseMountTo(app *App, premise FilePremise) string {
+	for _, m := range app.FileMounts {
+		if len(m.Volume) == 0 && m.File == premise.Name {
+			if path.IsAbs(m.MountTo) {
+				return m.MountTo
+			}
+			return path.Join(app.WorkDir, m.MountTo)
+		}
+	}
+	return path.Join(app.WorkDir, premise.Name)
+}
+
+// premiseEntry file carried by config map or secret
+type premiseEntry struct {
+	Key       string
+	MountTo   string
+	Sensitive bool
+	Content   []byte
+}
+
+// premiseEntries inline files of app, files extracted from archive are mounted under its extract dir
+func premiseEntries(app *App) []premiseEntry {
+	var entries []premiseEntry
+	for _, key := range inlinePremises(app) {
+		premise := app.FilePremises[key]
+		if premise.Files == nil {
+			entries = append(entries, premiseEntry{
+				Key:       premiseKey(key, premise),
+				MountTo:   premiseMountTo(app, premise),
+				Sensitive: premise.Sensitive,
+				Content:   premise.Content,
+			})
+			continue
+		}
+		for i, f := range premise.Files {
+			entries = append(entries, premiseEntry{
+				Key:       fmt.Sprintf("%s-%d", premiseKey(key, premise), i),
+				MountTo:   agfw.WorkspacePath(app.WorkDir, path.Join(premise.ExtractTo, f.Path)),
+				Sensitive: true,
+				Content:   f.Content,
+			})
+		}
+	}
+	return entries
+}
+
+// premiseObjects build config map and secret which carry inline files, nil if there is no such file.
+// error is returned if any of them is larger than the limit of kube object
+func (k *K8sWorker) premiseObjects(app *App) (*corev1.ConfigMap, *corev1.Secret, error) {
+	var cm *corev1.ConfigMap
+	var se *corev1.Secret
+	var cmSize, seSize int
+	for _, e := range premiseEntries(app) {
+		if e.Sensitive {
+			if se == nil {
+				se = &corev1.Secret{
+					TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+					ObjectMeta: metav1.ObjectMeta{Name: secretName(app.UID), Namespace: k.ns(app), Labels: app.Tags},
+					Type:       corev1.SecretTypeOpaque,
+					Data:       map[string][]byte{},
+				}
+			}
+			se.Data[e.Key] = e.Content
+			seSize += len(e.Key) + len(e.Content)
+			continue
+		}
+		if cm == nil {
+			cm = &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: filesName(app.UID), Namespace: k.ns(app), Labels: app.Tags},
+				BinaryData: map[string][]byte{},
+			}
+		}
+		cm.BinaryData[e.Key] = e.Content
+		cmSize += len(e.Key) + len(e.Content)
+	}
+	if cmSize > objectSizeLimit {
+		return nil, nil, errors.Errorf("files of config map [%s] are larger than [%d] bytes", filesName(app.UID), objectSizeLimit)
+	}
+	if seSize > objectSizeLimit {
+		return nil, nil, errors.Errorf("files of secret [%s] are larger than [%d] bytes", secretName(app.UID), objectSizeLimit)
+	}
+	return cm, se, nil
+}
+
+// premiseVolumes volumes of config map and secret, and mounts of inline files
+func premiseVolumes(app *App) ([]corev1.Volume, []corev1.VolumeMount) {
+	var vols []corev1.Volume
+	var mounts []corev1.VolumeMount
+	var hasFiles, hasSecret bool
+	for _, e := range premiseEntries(app) {
+		name := filesName(app.UID)
+		if e.Sensitive {
+			name, hasSecret = secretName(app.UID), true
+		} else {
+			hasFiles = true
+		}
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: e.MountTo,
+			SubPath:   e.Key,
+			ReadOnly:  true,
+		})
+	}
+	if hasFiles {
+		vols = append(vols, corev1.Volume{
+			Name: filesName(app.UID),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: filesName(app.UID)}},
+			},
+		})
+	}
+	if hasSecret {
+		vols = append(vols, corev1.Volume{
+			Name: secretName(app.UID),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: secretName(app.UID)},
+			},
+		})
+	}
+	return vols, mounts
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	agfw "github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/worker"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchPremise(t *testing.T) {
+	big := strings.Repeat("x", int(inlineFileLimit)+1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/big" {
+			_, _ = w.Write([]byte(big))
+			return
+		}
+		_, _ = w.Write([]byte("key"))
+	}))
+	defer srv.Close()
+	sum := sha256.Sum256([]byte("key"))
+	ctx := context.Background()
+
+	content, err := fetchPremise(ctx, FilePremise{Name: "a.key", AcquireAddr: srv.URL + "/small", CheckSum: hex.EncodeToString(sum[:])})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("key"), content)
+
+	_, err = fetchPremise(ctx, FilePremise{Name: "a.key", AcquireAddr: srv.URL + "/small", CheckSum: "00"})
+	assert.NotNil(t, err)
+
+	content, err = fetchPremise(ctx, FilePremise{Name: "big", AcquireAddr: srv.URL + "/big"})
+	assert.Nil(t, err)
+	assert.Nil(t, content, "large file is left to init container")
+
+	content, err = fetchPremise(ctx, FilePremise{Name: "a.tar", AcquireAddr: srv.URL + "/small", Shell: "tar -xf a.tar"})
+	assert.Nil(t, err)
+	assert.Nil(t, content, "file with shell is left to init container")
+
+	content, err = fetchPremise(ctx, FilePremise{Name: "big", AcquireAddr: srv.URL + "/big", Sensitive: true})
+	assert.Nil(t, err)
+	assert.Equal(t, len(big), len(content), "sensitive file is up to limit of secret")
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = fetchPremise(cctx, FilePremise{Name: "a.key", AcquireAddr: srv.URL + "/small"})
+	assert.NotNil(t, err, "request is canceled with context")
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		assert.Nil(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, tw.Close())
+	assert.Nil(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestExtractPremise(t *testing.T) {
+	premise := FilePremise{Name: "msp.tar.gz", Sensitive: true, ExtractTo: "config", StripComponents: 1}
+	err := extractPremise(&premise, tarGz(t, map[string]string{"peer0/msp/keystore/key.pem": "key"}))
+	assert.Nil(t, err)
+	assert.Len(t, premise.Files, 1)
+	assert.Equal(t, "msp/keystore/key.pem", premise.Files[0].Path)
+
+	err = extractPremise(&premise, tarGz(t, map[string]string{"peer0/../../etc/passwd": "x"}))
+	assert.NotNil(t, err, "entry escaping the dir is rejected")
+
+	k := &K8sWorker{Namespace: "ns"}
+	app := &App{UID: "app1", WorkDir: "/opt/app", FilePremises: map[string]FilePremise{"k1": premise}}
+	_, se, err := k.premiseObjects(app)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]byte{"msp.tar.gz-0": []byte("key")}, se.Data)
+	_, mounts := premiseVolumes(app)
+	assert.Equal(t, "/opt/app/config/msp/keystore/key.pem", mounts[0].MountPath)
+	assert.Equal(t, "msp.tar.gz-0", mounts[0].SubPath)
+
+	premise.Files = append(premise.Files, agfw.ArchiveFile{Path: "big", Content: make([]byte, objectSizeLimit)})
+	app.FilePremises["k1"] = premise
+	_, _, err = k.premiseObjects(app)
+	assert.NotNil(t, err, "secret larger than limit of object is rejected")
+}
+
+func TestPremiseObjects(t *testing.T) {
+	k := &K8sWorker{Namespace: "ns"}
+	app := &App{
+		UID:     "app1",
+		WorkDir: "/opt/app",
+		FilePremises: map[string]FilePremise{
+			"k1": {Name: "core.yaml", Content: []byte("a: b")},
+			"k2": {Name: "tls/server.key", Sensitive: true, Content: []byte("key")},
+			"k3": {Name: "ledger.tar.gz"},
+		},
+		FileMounts: map[string]FileMount{"m": {File: "tls/server.key", MountTo: "/etc/tls/server.key"}},
+	}
+	cm, se, err := k.premiseObjects(app)
+	assert.Nil(t, err)
+	assert.Equal(t, "app1-files", cm.Name)
+	assert.Equal(t, map[string][]byte{"core.yaml": []byte("a: b")}, cm.BinaryData)
+	assert.Equal(t, "app1-secret", se.Name)
+	assert.Equal(t, map[string][]byte{"k2": []byte("key")}, se.Data)
+
+	vols, mounts := premiseVolumes(app)
+	assert.Len(t, vols, 2)
+	assert.Equal(t, "/opt/app/core.yaml", mounts[0].MountPath)
+	assert.Equal(t, "core.yaml", mounts[0].SubPath)
+	assert.Equal(t, "app1-secret", mounts[1].Name)
+	assert.Equal(t, "/etc/tls/server.key", mounts[1].MountPath)
+	assert.Equal(t, "k2", mounts[1].SubPath)
+}
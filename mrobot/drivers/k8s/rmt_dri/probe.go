@@ -0,0 +1,135 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rmt_dri
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// grpcProbeBin health probe binary in image for grpc probe, k8s of this version has no
+// native grpc probe
+const grpcProbeBin = "grpc_health_probe"
+
+// readyDefault timings of readness probe if not set
+var readyDefault = corev1.Probe{
+	InitialDelaySeconds: 3,
+	TimeoutSeconds:      5,
+	PeriodSeconds:       1,
+	SuccessThreshold:    1,
+	FailureThreshold:    5,
+}
+
+// liveDefault timings of liveness probe if not set
+var liveDefault = corev1.Probe{
+	InitialDelaySeconds: 20,
+	TimeoutSeconds:      5,
+	PeriodSeconds:       1,
+	SuccessThreshold:    1,
+	FailureThreshold:    5,
+}
+
+// emptyProbe probe without port and command is treated as not set
+func emptyProbe(b *worker0.App_Health_Basic) bool {
+	return b == nil || (b.Port == 0 && len(b.Command) == 0)
+}
+
+// probeOpt convert probe of worker0 to probe of app
+func probeOpt(b *worker0.App_Health_Basic) (*HealthBasic, error) {
+	h := &HealthBasic{
+		Path:             b.Path,
+		Port:             int(b.Port),
+		Command:          b.Command,
+		GRPCService:      b.GRPCService,
+		InitialDelay:     int(b.InitialDelaySeconds),
+		Period:           int(b.PeriodSeconds),
+		Timeout:          int(b.TimeoutSeconds),
+		FailureThreshold: int(b.FailureThreshold),
+		SuccessThreshold: int(b.SuccessThreshold),
+	}
+	if h.InitialDelay < 0 || h.Period < 0 || h.Timeout < 0 || h.FailureThreshold < 0 || h.SuccessThreshold < 0 {
+		return nil, errors.Errorf("timings of probe [%+v] can not be negative", b)
+	}
+	switch b.ProbeType {
+	case worker0.App_Health_Basic_HTTP:
+		h.Type = ProbeHTTP
+		switch b.MethodType {
+		case worker0.App_Health_Basic_GET:
+			h.Method = HttpGet
+		case worker0.App_Health_Basic_POST:
+			return nil, errors.New("http probe of k8s only supports method GET")
+		default:
+			return nil, errors.Errorf("fail to parse method [%s]", b.MethodType)
+		}
+	case worker0.App_Health_Basic_TCP:
+		h.Type = ProbeTCP
+	case worker0.App_Health_Basic_EXEC:
+		h.Type = ProbeExec
+		if len(b.Command) == 0 {
+			return nil, errors.New("command of exec probe is empty")
+		}
+	case worker0.App_Health_Basic_GRPC:
+		h.Type = ProbeGRPC
+	default:
+		return nil, errors.Errorf("fail to parse probe type [%s]", b.ProbeType)
+	}
+	if h.Type != ProbeExec && b.Port <= 0 {
+		return nil, errors.Errorf("port [%d] of probe not correct", b.Port)
+	}
+	return h, nil
+}
+
+// kubeProbe convert probe of app to probe of container, timings not set are taken from def.
+// grpc probe is executed by grpc_health_probe in container
+func kubeProbe(h *HealthBasic, def corev1.Probe) *corev1.Probe {
+	if h == nil {
+		return nil
+	}
+	p := def
+	switch h.Type {
+	case ProbeTCP:
+		p.Handler = corev1.Handler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(h.Port)}}
+	case ProbeExec:
+		p.Handler = corev1.Handler{Exec: &corev1.ExecAction{Command: h.Command}}
+	case ProbeGRPC:
+		command := []string{grpcProbeBin, fmt.Sprintf("-addr=127.0.0.1:%d", h.Port)}
+		if len(h.GRPCService) != 0 {
+			command = append(command, fmt.Sprintf("-service=%s", h.GRPCService))
+		}
+		p.Handler = corev1.Handler{Exec: &corev1.ExecAction{Command: command}}
+	default:
+		p.Handler = corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: h.Path, Port: intstr.FromInt(h.Port)}}
+	}
+	if h.InitialDelay > 0 {
+		p.InitialDelaySeconds = int32(h.InitialDelay)
+	}
+	if h.Period > 0 {
+		p.PeriodSeconds = int32(h.Period)
+	}
+	if h.Timeout > 0 {
+		p.TimeoutSeconds = int32(h.Timeout)
+	}
+	if h.FailureThreshold > 0 {
+		p.FailureThreshold = int32(h.FailureThreshold)
+	}
+	if h.SuccessThreshold > 0 {
+		p.SuccessThreshold = int32(h.SuccessThreshold)
+	}
+	return &p
+}
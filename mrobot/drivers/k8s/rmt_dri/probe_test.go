@@ -0,0 +1,55 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rmt_dri
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"testing"
+)
+
+func TestKubeProbe(t *testing.T) {
+	assert.Nil(t, kubeProbe(nil, readyDefault))
+
+	p := kubeProbe(&HealthBasic{Type: ProbeHTTP, Path: "/healthz", Port: 8080}, readyDefault)
+	assert.Equal(t, "/healthz", p.HTTPGet.Path)
+	assert.Equal(t, readyDefault.InitialDelaySeconds, p.InitialDelaySeconds)
+
+	p = kubeProbe(&HealthBasic{Type: ProbeTCP, Port: 8080, Period: 10, FailureThreshold: 2}, liveDefault)
+	assert.Equal(t, 8080, p.TCPSocket.Port.IntValue())
+	assert.Equal(t, int32(10), p.PeriodSeconds)
+	assert.Equal(t, int32(2), p.FailureThreshold)
+	assert.Equal(t, liveDefault.InitialDelaySeconds, p.InitialDelaySeconds)
+
+	p = kubeProbe(&HealthBasic{Type: ProbeExec, Command: []string{"test", "-f", "ready"}}, readyDefault)
+	assert.Equal(t, []string{"test", "-f", "ready"}, p.Exec.Command, "argv of command is kept")
+
+	p = kubeProbe(&HealthBasic{Type: ProbeGRPC, Port: 9090, GRPCService: "app"}, readyDefault)
+	assert.Equal(t, []string{grpcProbeBin, "-addr=127.0.0.1:9090", "-service=app"}, p.Exec.Command)
+}
+
+func TestProbeOpt(t *testing.T) {
+	_, err := probeOpt(&worker0.App_Health_Basic{ProbeType: worker0.App_Health_Basic_GRPC})
+	assert.NotNil(t, err, "port of grpc probe is required")
+	_, err = probeOpt(&worker0.App_Health_Basic{ProbeType: worker0.App_Health_Basic_TCP, Port: 80, TimeoutSeconds: -1})
+	assert.NotNil(t, err, "negative timing is rejected")
+	_, err = probeOpt(&worker0.App_Health_Basic{ProbeType: worker0.App_Health_Basic_HTTP, MethodType: worker0.App_Health_Basic_POST, Port: 80})
+	assert.NotNil(t, err, "post is not supported by http probe of k8s")
+	h, err := probeOpt(&worker0.App_Health_Basic{ProbeType: worker0.App_Health_Basic_EXEC, Command: []string{"true"}, PeriodSeconds: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, &HealthBasic{Type: ProbeExec, Command: []string{"true"}, Period: 3}, h)
+	assert.True(t, emptyProbe(&worker0.App_Health_Basic{}))
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	"github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/core"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// pullSecretName secret of registry credential for pulling image of app
+func pullSecretName(uid string) string {
+	return fmt.Sprintf("%s-pull", uid)
+}
+
+// registryCredential get credential of registry from core, it is replaced in test
+var registryCredential = core.RegistryCredential
+
+// pullSecret build secret of registry credential stored in core for image of app, nil is
+// returned if the registry is not registered, app pulls image anonymously then
+func (k *K8sWorker) pullSecret(ctx context.Context, app *App) (*corev1.Secret, error) {
+	cred, err := registryCredential(ctx, app.Image)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get registry credential of image [%s]", app.Image)
+	}
+	if cred == nil {
+		log.Debugf(ctx, "Currently registry of image [%s] not registered, pull anonymously", app.Image)
+		return nil, nil
+	}
+	se, err := base.PullSecret(pullSecretName(app.UID), k.ns(app), app.Tags, cred.Server, cred.Username, cred.Password, cred.Email)
+	if err != nil {
+		return nil, errors.Wrap(err, "build pull secret")
+	}
+	return se, nil
+}
+
+// applyPullSecret create pull secret of app, name of secret is set to app
+func (k *K8sWorker) applyPullSecret(ctx context.Context, cli *base.Client, app *App) error {
+	se, err := k.pullSecret(ctx, app)
+	if err != nil {
+		return err
+	}
+	app.PullSecret = ""
+	if se == nil {
+		return nil
+	}
+	log.Debugf(ctx, "Currently start to create pull secret [%s]", se.Name)
+	err = cli.CreateSecret(se)
+	if err != nil {
+		return errors.Wrap(err, "apply pull secret")
+	}
+	app.PullSecret = se.Name
+	return nil
+}
+
+// pullSecrets image pull secrets of pod
+func pullSecrets(app *App) []corev1.LocalObjectReference {
+	if len(app.PullSecret) == 0 {
+		return nil
+	}
+	return []corev1.LocalObjectReference{{Name: app.PullSecret}}
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rmt_dri
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestPullSecret(t *testing.T) {
+	se, err := base.PullSecret(pullSecretName("app1"), "ns", nil, "harbor.example.com", "user", "pass", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "app1-pull", se.Name)
+	assert.Equal(t, corev1.SecretTypeDockerConfigJson, se.Type)
+	var cfg struct {
+		Auths map[string]map[string]string `json:"auths"`
+	}
+	assert.Nil(t, json.Unmarshal(se.Data[corev1.DockerConfigJsonKey], &cfg))
+	assert.Equal(t, "dXNlcjpwYXNz", cfg.Auths["harbor.example.com"]["auth"])
+
+	assert.Nil(t, pullSecrets(&App{}))
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "app1-pull"}}, pullSecrets(&App{PullSecret: "app1-pull"}))
+}
@@ -0,0 +1,342 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rmt_dri
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+	"sort"
+)
+
+// redacted value of secret data in rendered manifest
+const redacted = "<redacted>"
+
+// appObjects objects of app rendered from metadata. Services and ingresses are applied by
+// NetworkEx when port is added, the others are applied by StartApp
+type appObjects struct {
+	ConfigMap   *corev1.ConfigMap
+	Secret      *corev1.Secret
+	PullSecret  *corev1.Secret
+	Claims      []*corev1.PersistentVolumeClaim
+	Services    []*corev1.Service
+	Ingresses   []*v1beta1.Ingress
+	Headless    *corev1.Service
+	Deployment  *v1.Deployment
+	StatefulSet *v1.StatefulSet
+}
+
+// object kube object which has type and object meta
+type object interface {
+	runtime.Object
+	metav1.Object
+}
+
+// render build all the objects of app without touching the cluster, credential of registry
+// is got from core for pull secret
+func (k *K8sWorker) render(ctx context.Context, app *App) (*appObjects, error) {
+	objs := &appObjects{}
+	var err error
+	objs.ConfigMap, objs.Secret, err = k.premiseObjects(app)
+	if err != nil {
+		return nil, err
+	}
+	pull, err := k.pullSecret(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	objs.PullSecret = pull
+	// pod refers to the pull secret rendered with it, app in repo is set once it is applied
+	rendered := *app
+	rendered.PullSecret = objs.pullSecretName()
+	app = &rendered
+	svcs, err := k.portServices(app)
+	if err != nil {
+		return nil, err
+	}
+	objs.Services = svcs
+	objs.Ingresses = k.portIngresses(app)
+	if app.Workload == WorkloadStatefulSet {
+		objs.Headless = k.headlessService(app)
+		objs.StatefulSet, err = k.statefulSet(ctx, app)
+		if err != nil {
+			return nil, err
+		}
+		return objs, nil
+	}
+	for _, v := range k.volumes(app) {
+		objs.Claims = append(objs.Claims, k.claim(app, v))
+	}
+	objs.Deployment, err = k.deployment(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	return objs, nil
+}
+
+// pullSecretName name of rendered pull secret, empty if image is pulled anonymously
+func (o *appObjects) pullSecretName() string {
+	if o.PullSecret == nil {
+		return ""
+	}
+	return o.PullSecret.Name
+}
+
+// sortedPorts ports of app in order of port number
+func sortedPorts(app *App) []PortInfo {
+	var ports []PortInfo
+	for _, p := range app.Ports {
+		ports = append(ports, p)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i].Port < ports[j].Port })
+	return ports
+}
+
+// portServices cluster ip service with all the ports, node port and load balancer services
+// with ports of their mode
+func (k *K8sWorker) portServices(app *App) ([]*corev1.Service, error) {
+	ports := sortedPorts(app)
+	if len(ports) == 0 {
+		return nil, nil
+	}
+	var svcs []*corev1.Service
+	for _, kind := range []struct {
+		name string
+		typ  corev1.ServiceType
+		mode ExposeMode
+	}{
+		{name: serviceName(app.UID), typ: corev1.ServiceTypeClusterIP},
+		{name: nodePortName(app.UID), typ: corev1.ServiceTypeNodePort, mode: ExposeNodePort},
+		{name: lbName(app.UID), typ: corev1.ServiceTypeLoadBalancer, mode: ExposeLoadBalancer},
+	} {
+		var sps []corev1.ServicePort
+		for i := range ports {
+			if len(kind.mode) != 0 && ports[i].Expose != kind.mode {
+				continue
+			}
+			sp, err := servicePort(kind.typ, &ports[i])
+			if err != nil {
+				return nil, err
+			}
+			sps = append(sps, sp)
+		}
+		if len(sps) == 0 {
+			continue
+		}
+		svcs = append(svcs, &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: kind.name, Namespace: k.ns(app), Labels: app.Tags},
+			Spec:       corev1.ServiceSpec{Ports: sps, Selector: app.Tags, Type: kind.typ},
+		})
+	}
+	return svcs, nil
+}
+
+// portIngresses ingresses of ports exposed by ingress and tls passthrough
+func (k *K8sWorker) portIngresses(app *App) []*v1beta1.Ingress {
+	var igs []*v1beta1.Ingress
+	for _, mode := range []ExposeMode{ExposeIngress, ExposeTLSPassthrough} {
+		var ports []PortInfo
+		for _, p := range sortedPorts(app) {
+			if p.Expose == mode {
+				ports = append(ports, p)
+			}
+		}
+		if len(ports) != 0 {
+			igs = append(igs, k.ingress(app, mode, ports))
+		}
+	}
+	return igs
+}
+
+// apply create objects of app applied by StartApp, config map and secret of files and claims
+// are created before workload which uses them
+func (o *appObjects) apply(ctx context.Context, cli *base.Client) error {
+	if o.ConfigMap != nil {
+		log.Debugf(ctx, "Currently start to create config map [%s]", o.ConfigMap.Name)
+		err := cli.CreateConfigMap(o.ConfigMap)
+		if err != nil {
+			return errors.Wrap(err, "apply config map of files")
+		}
+	}
+	if o.Secret != nil {
+		log.Debugf(ctx, "Currently start to create secret [%s]", o.Secret.Name)
+		err := cli.CreateSecret(o.Secret)
+		if err != nil {
+			return errors.Wrap(err, "apply secret of files")
+		}
+	}
+	if o.PullSecret != nil {
+		log.Debugf(ctx, "Currently start to create pull secret [%s]", o.PullSecret.Name)
+		err := cli.CreateSecret(o.PullSecret)
+		if err != nil {
+			return errors.Wrap(err, "apply pull secret")
+		}
+	}
+	for _, pvc := range o.Claims {
+		log.Debugf(ctx, "Currently start to create pvc [%s]", pvc.Name)
+		err := cli.CreatePersistentVolumeClaim(pvc)
+		if err != nil {
+			return errors.Wrapf(err, "apply pvc [%s]", pvc.Name)
+		}
+	}
+	if o.StatefulSet != nil {
+		log.Debug(ctx, "Currently start to create headless service")
+		err := cli.CreateService(o.Headless)
+		if err != nil {
+			return errors.Wrap(err, "apply headless service")
+		}
+		log.Debug(ctx, "Currently start to create stateful set")
+		err = cli.CreateStatefulSet(o.StatefulSet)
+		if err != nil {
+			return errors.Wrap(err, "apply stateful set")
+		}
+		return nil
+	}
+	log.Debug(ctx, "Currently start to create deployment")
+	err := cli.CreateDeployment(o.Deployment)
+	if err != nil {
+		return errors.Wrap(err, "apply deployment")
+	}
+	return nil
+}
+
+// dryRun validate objects created by apply against the cluster, nothing is persisted
+func (o *appObjects) dryRun(ctx context.Context, cli *base.Client) error {
+	var objs []object
+	if o.ConfigMap != nil {
+		objs = append(objs, o.ConfigMap)
+	}
+	for _, se := range []*corev1.Secret{o.Secret, o.PullSecret} {
+		if se != nil {
+			objs = append(objs, se)
+		}
+	}
+	for _, pvc := range o.Claims {
+		objs = append(objs, pvc)
+	}
+	if o.StatefulSet != nil {
+		objs = append(objs, o.Headless, o.StatefulSet)
+	} else {
+		objs = append(objs, o.Deployment)
+	}
+	for _, obj := range objs {
+		log.Debugf(ctx, "Currently start to validate [%s] by dry run", obj.GetName())
+		err := cli.DryRun(obj)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dryRunApp render objects of app and validate them by the cluster, neither app in repo
+// nor the cluster is changed
+func (k *K8sWorker) dryRunApp(ctx context.Context, cli *base.Client, app *App) error {
+	placed := *app
+	err := k.placeApp(ctx, cli, &placed)
+	if err != nil {
+		return errors.Wrap(err, "place app")
+	}
+	objs, err := k.render(ctx, &placed)
+	if err != nil {
+		return errors.Wrap(err, "render app")
+	}
+	return objs.dryRun(ctx, cli)
+}
+
+// objects all the objects in the order they are applied
+func (o *appObjects) objects() []object {
+	var objs []object
+	if o.ConfigMap != nil {
+		objs = append(objs, o.ConfigMap)
+	}
+	for _, se := range []*corev1.Secret{o.Secret, o.PullSecret} {
+		if se != nil {
+			objs = append(objs, redactSecret(se))
+		}
+	}
+	for _, pvc := range o.Claims {
+		objs = append(objs, pvc)
+	}
+	for _, svc := range o.Services {
+		objs = append(objs, svc)
+	}
+	for _, igs := range o.Ingresses {
+		objs = append(objs, igs)
+	}
+	if o.StatefulSet != nil {
+		objs = append(objs, o.Headless, o.StatefulSet)
+	}
+	if o.Deployment != nil {
+		objs = append(objs, o.Deployment)
+	}
+	return objs
+}
+
+// redactSecret copy of secret whose data is redacted
+func redactSecret(se *corev1.Secret) *corev1.Secret {
+	rs := se.DeepCopy()
+	rs.StringData = map[string]string{}
+	for key := range rs.Data {
+		rs.StringData[key] = redacted
+	}
+	rs.Data = nil
+	return rs
+}
+
+// manifests objects in yaml, data of secret is redacted
+func (o *appObjects) manifests() ([]*worker0.RenderAppRes_Manifest, error) {
+	var mfs []*worker0.RenderAppRes_Manifest
+	for _, obj := range o.objects() {
+		bs, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, errors.Wrapf(err, "marshal [%s]", obj.GetName())
+		}
+		mfs = append(mfs, &worker0.RenderAppRes_Manifest{
+			Kind:      obj.GetObjectKind().GroupVersionKind().Kind,
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+			Yaml:      string(bs),
+		})
+	}
+	return mfs, nil
+}
+
+// RenderApp render manifests of app in metadata, nothing is applied to the cluster
+func (k *K8sWorker) RenderApp(ctx context.Context, _ *worker0.Empty) (*worker0.RenderAppRes, error) {
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	objs, err := k.render(ctx, app)
+	if err != nil {
+		return nil, errors.Wrap(err, "render app")
+	}
+	mfs, err := objs.manifests()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal manifests")
+	}
+	return &worker0.RenderAppRes{Manifests: mfs}, nil
+}
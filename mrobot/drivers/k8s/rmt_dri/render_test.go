@@ -0,0 +1,176 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rmt_dri
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	"github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/core"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	ctx := context.Background()
+	k := &K8sWorker{Namespace: "ns", StorageClass: "sc", MachineID: 3, Domain: "example.com", NodeIP: "10.0.0.1"}
+	app := &App{
+		UID:     "app1",
+		Image:   "peer:2.2",
+		WorkDir: "/opt/peer",
+		Ports: map[int]PortInfo{
+			7051: {Port: 7051, Name: "grpc", Protocol: "TCP", Expose: ExposeTLSPassthrough},
+			9443: {Port: 9443, Name: "ops", Protocol: "TCP", Expose: ExposeNodePort, NodePort: 30443},
+		},
+		FilePremises: map[string]FilePremise{
+			"core": {Name: "core.yaml", Content: []byte("peer:")},
+			"key":  {Name: "tls.key", Content: []byte("private"), Sensitive: true},
+		},
+		Tags: map[string]string{"uuid": "app1"},
+	}
+	old := registryCredential
+	defer func() { registryCredential = old }()
+	registryCredential = func(context.Context, string) (*core.Credential, error) {
+		return &core.Credential{Server: "harbor.example.com", Username: "user", Password: "pass"}, nil
+	}
+	objs, err := k.render(ctx, app)
+	assert.Nil(t, err)
+	assert.NotNil(t, objs.Deployment)
+	assert.Equal(t, "app1-pull", objs.PullSecret.Name)
+	assert.Equal(t, pullSecrets(&App{PullSecret: "app1-pull"}), objs.Deployment.Spec.Template.Spec.ImagePullSecrets)
+	assert.Empty(t, app.PullSecret, "app is set once pull secret is applied")
+	assert.Len(t, objs.Services, 2, "cluster ip and node port, no load balancer port")
+	assert.Equal(t, int32(30443), objs.Services[1].Spec.Ports[0].NodePort)
+	assert.Len(t, objs.Ingresses, 1)
+
+	mfs, err := objs.manifests()
+	assert.Nil(t, err)
+	var kinds []string
+	for _, mf := range mfs {
+		kinds = append(kinds, mf.Kind)
+		assert.Equal(t, "ns", mf.Namespace)
+		if mf.Kind == "Secret" {
+			assert.NotContains(t, mf.Yaml, "private")
+			assert.NotContains(t, mf.Yaml, "dXNlcjpwYXNz")
+			assert.Contains(t, mf.Yaml, redacted)
+		}
+	}
+	assert.Equal(t, "ConfigMap", kinds[0])
+	assert.Equal(t, "Deployment", kinds[len(kinds)-1])
+	assert.Contains(t, kinds, "Ingress")
+	assert.Equal(t, []byte("private"), objs.Secret.Data[premiseKey("key", app.FilePremises["key"])], "secret to apply is not redacted")
+
+	fc := fakeCluster()
+	assert.Nil(t, objs.apply(ctx, base.NewClientByInterface(ctx, fc)))
+	_, err = fc.AppsV1().Deployments("ns").Get(ctx, "app1-dep", metav1.GetOptions{})
+	assert.Nil(t, err)
+	for _, pvc := range objs.Claims {
+		_, err = fc.CoreV1().PersistentVolumeClaims("ns").Get(ctx, pvc.Name, metav1.GetOptions{})
+		assert.Nil(t, err)
+	}
+	_, err = fc.CoreV1().ConfigMaps("ns").Get(ctx, objs.ConfigMap.Name, metav1.GetOptions{})
+	assert.Nil(t, err)
+	_, err = fc.CoreV1().Services("ns").Get(ctx, serviceName(app.UID), metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "services are applied by network")
+}
+
+func TestRenderAnonymousPull(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"), "agent token is not configured")
+		assert.Equal(t, "busybox:latest", r.URL.Query().Get("image"))
+		_, _ = w.Write([]byte(`{"code":200,"data":null}`))
+	}))
+	defer srv.Close()
+	old := registryCredential
+	defer func() { registryCredential = old }()
+	registryCredential = func(ctx context.Context, image string) (*core.Credential, error) {
+		return core.RegistryCredentialByAddr(ctx, srv.URL, "", image)
+	}
+
+	k := &K8sWorker{Namespace: "ns", StorageClass: "sc", MachineID: 3}
+	objs, err := k.render(ctx, &App{UID: "app1", Image: "busybox:latest", Tags: map[string]string{"uuid": "app1"}})
+	assert.Nil(t, err)
+	assert.Nil(t, objs.PullSecret)
+	assert.Empty(t, objs.Deployment.Spec.Template.Spec.ImagePullSecrets)
+}
+
+func TestPlannedExternal(t *testing.T) {
+	k := &K8sWorker{Namespace: "ns", MachineID: 3, Domain: "example.com", NodeIP: "10.0.0.1"}
+	app := &App{UID: "app1"}
+	addr, err := k.plannedExternal(app, &PortInfo{Port: 7051, Protocol: string(corev1.ProtocolTCP), Expose: ExposeTLSPassthrough})
+	assert.Nil(t, err)
+	assert.Equal(t, "m3-app1-7051.example.com:443", addr)
+	addr, err = k.plannedExternal(app, &PortInfo{Port: 9443, Expose: ExposeNodePort})
+	assert.Nil(t, err)
+	assert.Empty(t, addr, "node port is picked by cluster")
+	_, err = (&K8sWorker{}).plannedExternal(app, &PortInfo{Port: 80, Protocol: string(corev1.ProtocolTCP), Expose: ExposeIngress})
+	assert.NotNil(t, err, "ingress needs domain")
+}
+
+func TestDryRunApp(t *testing.T) {
+	ctx := context.Background()
+	old := registryCredential
+	defer func() { registryCredential = old }()
+	registryCredential = func(context.Context, string) (*core.Credential, error) { return nil, nil }
+	k := &K8sWorker{Namespace: "ns", StorageClass: "sc"}
+	app := &App{
+		UID:          "app1",
+		Image:        "peer:2.2",
+		FilePremises: map[string]FilePremise{"core": {Name: "core.yaml", Content: []byte("peer:")}},
+		Tags:         map[string]string{"uuid": "app1"},
+	}
+
+	var validated []string
+	fc := fake.NewSimpleClientset()
+	fc.PrependReactor("create", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		validated = append(validated, action.GetResource().Resource)
+		return false, nil, nil
+	})
+	assert.Nil(t, k.dryRunApp(ctx, base.NewClientByInterface(ctx, fc), app))
+	assert.Equal(t, []string{"configmaps", "persistentvolumeclaims", "deployments"}, validated)
+	assert.Empty(t, app.Namespace, "app in repo is not placed by dry run")
+
+	fc = fake.NewSimpleClientset()
+	fc.PrependReactor("create", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewBadRequest("invalid deployment")
+	})
+	assert.NotNil(t, k.dryRunApp(ctx, base.NewClientByInterface(ctx, fc), app), "error of validation is returned")
+}
+
+// fakeCluster fake clientset without controllers, claim is bound and deployment is available
+// once it is created
+func fakeCluster() *fake.Clientset {
+	fc := fake.NewSimpleClientset()
+	fc.PrependReactor("create", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		switch obj := action.(k8stesting.CreateAction).GetObject().(type) {
+		case *corev1.PersistentVolumeClaim:
+			obj.Status.Phase = corev1.ClaimBound
+		case *v1.Deployment:
+			obj.Status.AvailableReplicas = *obj.Spec.Replicas
+		}
+		return false, nil, nil
+	})
+	return fc
+}
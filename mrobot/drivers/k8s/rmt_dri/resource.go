@@ -0,0 +1,68 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	agfw "github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/worker"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const mb = 1024 * 1024
+
+// Resource allocatable of schedulable nodes and requests of running pods in cluster
+func (k *K8sWorker) Resource(ctx context.Context) (*agfw.Resource, error) {
+	cli, err := k.client(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "build k8s client")
+	}
+	nodes, err := cli.ListNodes()
+	if err != nil {
+		return nil, errors.Wrap(err, "list nodes")
+	}
+	pods, err := cli.ListPods("")
+	if err != nil {
+		return nil, errors.Wrap(err, "list pods")
+	}
+	return clusterResource(nodes, pods), nil
+}
+
+func clusterResource(nodes []corev1.Node, pods []corev1.Pod) *agfw.Resource {
+	res := &agfw.Resource{}
+	for _, n := range nodes {
+		if n.Spec.Unschedulable {
+			continue
+		}
+		al := n.Status.Allocatable
+		res.CPUAllocatable += int(al.Cpu().MilliValue())
+		res.MemoryAllocatable += int(al.Memory().Value() / mb)
+		res.DiskAllocatable += int(al.StorageEphemeral().Value() / mb)
+	}
+	for _, p := range pods {
+		if p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, c := range p.Spec.Containers {
+			rq := c.Resources.Requests
+			res.CPUUsed += int(rq.Cpu().MilliValue())
+			res.MemoryUsed += int(rq.Memory().Value() / mb)
+			res.DiskUsed += int(rq.StorageEphemeral().Value() / mb)
+		}
+	}
+	return res
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScaleApp scale app in metadata to desired replicas. workload of started app is patched,
+// stopped or not started app only records the replicas for its next deploy
+func (k *K8sWorker) ScaleApp(ctx context.Context, req *worker0.ScaleReq) (*worker0.App, error) {
+	log.Debugf(ctx, "Currently to scale app to [%d] replicas", req.Replicas)
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	err = k.checkReplicas(app, req.Replicas)
+	if err != nil {
+		return nil, err
+	}
+	cli, err := k.client(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "new k8s client")
+	}
+	current, err := k.workloadReplicas(cli, app)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		log.Debug(ctx, "Currently app not started, only record replicas")
+		app.Replicas = req.Replicas
+		return k.workerApp(app), nil
+	}
+	if *current == 0 {
+		log.Infof(ctx, "app is stopped, only record replicas [%d]", req.Replicas)
+		app.Replicas = req.Replicas
+		return k.workerApp(app), nil
+	}
+	_, err = k.scale(cli, app, req.Replicas)
+	if err != nil {
+		return nil, errors.Wrapf(err, "scale app to [%d] replicas", req.Replicas)
+	}
+	app.Replicas = req.Replicas
+	log.Infof(ctx, "scale app from [%d] to [%d] replicas", *current, req.Replicas)
+	return k.workerApp(app), nil
+}
+
+// replicas desired replicas of app
+func replicas(app *App) int32 {
+	if app.Replicas < 1 {
+		return 1
+	}
+	return app.Replicas
+}
+
+// checkReplicas whether app can run desired replicas, pods of deployment share claims of
+// app, so ReadWriteOnce volume can not be mounted by more than one of them
+func (k *K8sWorker) checkReplicas(app *App, replicas int32) error {
+	if replicas < 1 {
+		return errors.Errorf("replicas [%d] should be at least 1, stop app to scale it to zero", replicas)
+	}
+	if replicas == 1 || app.Workload == WorkloadStatefulSet {
+		return nil
+	}
+	for _, v := range k.volumes(app) {
+		if v.AccessMode == string(corev1.ReadWriteOnce) {
+			return errors.Errorf("volume [%s] is ReadWriteOnce and shared by pods of deployment, "+
+				"use ReadWriteMany or StatefulSet workload to run [%d] replicas", v.Name, replicas)
+		}
+	}
+	return nil
+}
+
+// workloadReplicas replicas in spec of workload of app, nil is returned if workload not found
+func (k *K8sWorker) workloadReplicas(cli *base.Client, app *App) (*int32, error) {
+	var rep *int32
+	if app.Workload == WorkloadStatefulSet {
+		sts, err := cli.GetStatefulSetByName(stsName(app.UID), k.ns(app), metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(errors.Cause(err)) {
+				return nil, nil
+			}
+			return nil, errors.Wrapf(err, "get stateful set [%s]", stsName(app.UID))
+		}
+		rep = sts.Spec.Replicas
+	} else {
+		name := fmt.Sprintf("%s-dep", app.UID)
+		dep, err := cli.GetDeployment(name, k.ns(app))
+		if err != nil {
+			if apierrors.IsNotFound(errors.Cause(err)) {
+				return nil, nil
+			}
+			return nil, errors.Wrapf(err, "get deployment [%s]", name)
+		}
+		rep = dep.Spec.Replicas
+	}
+	if rep == nil {
+		one := int32(1)
+		rep = &one
+	}
+	return rep, nil
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestCheckReplicas(t *testing.T) {
+	k := &K8sWorker{StorageClass: "sc"}
+	app := &App{UID: "app1", Volumes: map[string]VolumeSpec{
+		"data": {Name: "data", Size: "1Gi", AccessMode: string(corev1.ReadWriteOnce), MountPath: "/data"},
+	}}
+	assert.NotNil(t, k.checkReplicas(app, 0), "scale to zero by stop")
+	assert.Nil(t, k.checkReplicas(app, 1))
+	assert.NotNil(t, k.checkReplicas(app, 3), "ReadWriteOnce claim shared by pods of deployment")
+	app.Workload = WorkloadStatefulSet
+	assert.Nil(t, k.checkReplicas(app, 3), "each pod of stateful set has its own claim")
+}
+
+func TestScale(t *testing.T) {
+	ctx := context.Background()
+	k := &K8sWorker{Namespace: "ns"}
+	app := &App{UID: "app1", Tags: map[string]string{"uuid": "app1"}}
+	fc := fakeCluster()
+	cli := base.NewClientByInterface(ctx, fc)
+
+	rep, err := k.workloadReplicas(cli, app)
+	assert.Nil(t, err)
+	assert.Nil(t, rep, "app not started")
+	found, err := k.scale(cli, app, 2)
+	assert.Nil(t, err)
+	assert.False(t, found)
+
+	app.Replicas = 2
+	_, err = fc.AppsV1().Deployments("ns").Create(ctx, &v1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app1-dep", Namespace: "ns"},
+		Spec:       v1.DeploymentSpec{Replicas: &app.Replicas},
+	}, metav1.CreateOptions{})
+	assert.Nil(t, err)
+	found, err = k.scale(cli, app, 3)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	rep, err = k.workloadReplicas(cli, app)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), *rep)
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+)
+
+// stsName name of stateful set of app
+func stsName(uid string) string {
+	return fmt.Sprintf("%s-sts", uid)
+}
+
+// headlessName name of headless service which governs stateful set of app
+func headlessName(uid string) string {
+	return fmt.Sprintf("%s-headless", uid)
+}
+
+// statefulSet build stateful set of app, volumes are claimed by volume claim templates,
+// so each pod keeps its storage and name '<uid>-sts-<ordinal>' across restarts
+func (k *K8sWorker) statefulSet(ctx context.Context, app *App) (*v1.StatefulSet, error) {
+	var rep = replicas(app)
+	tpl, err := k.podTemplate(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	var templates []corev1.PersistentVolumeClaim
+	for _, v := range k.volumes(app) {
+		templates = append(templates, corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: podVolumeName(app, v), Labels: app.Tags},
+			Spec:       k.claim(app, v).Spec,
+		})
+	}
+	sts := v1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "StatefulSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      stsName(app.UID),
+			Namespace: k.ns(app),
+			Labels:    app.Tags,
+		},
+		Spec: v1.StatefulSetSpec{
+			Replicas:             &rep,
+			Selector:             &metav1.LabelSelector{MatchLabels: app.Tags},
+			Template:             *tpl,
+			VolumeClaimTemplates: templates,
+			ServiceName:          headlessName(app.UID),
+			PodManagementPolicy:  v1.OrderedReadyPodManagement,
+			UpdateStrategy:       v1.StatefulSetUpdateStrategy{Type: v1.RollingUpdateStatefulSetStrategyType},
+		},
+	}
+	return &sts, nil
+}
+
+// headlessService build headless service of stateful set, pod is resolved as '<uid>-sts-<ordinal>.<uid>-headless'
+func (k *K8sWorker) headlessService(app *App) *corev1.Service {
+	var ports []corev1.ServicePort
+	for port, info := range app.Ports {
+		ports = append(ports, corev1.ServicePort{
+			Name:     info.Name,
+			Port:     int32(port),
+			Protocol: corev1.Protocol(strings.ToUpper(info.Protocol)),
+		})
+	}
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      headlessName(app.UID),
+			Namespace: k.ns(app),
+			Labels:    app.Tags,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:                corev1.ClusterIPNone,
+			Selector:                 map[string]string{"uuid": app.UID},
+			Ports:                    ports,
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
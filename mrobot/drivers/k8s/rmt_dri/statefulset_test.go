@@ -0,0 +1,91 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	corev1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestStatefulSet(t *testing.T) {
+	k := &K8sWorker{Namespace: "ns", StorageClass: "sc"}
+	app := &App{
+		UID:      "app1",
+		Image:    "couchdb:3.1",
+		WorkDir:  "/opt/couchdb",
+		Ports:    map[int]PortInfo{5984: {Port: 5984, Name: "http", Protocol: "tcp"}},
+		Tags:     map[string]string{"uuid": "app1"},
+		Workload: WorkloadStatefulSet,
+		Volumes: map[string]VolumeSpec{
+			"ledger": {Name: "ledger", Size: "10Gi", AccessMode: "ReadWriteOnce", StorageClass: "fast", MountPath: "/var/ledger"},
+		},
+	}
+	sts, err := k.statefulSet(context.Background(), app)
+	assert.Nil(t, err)
+	assert.Equal(t, "app1-sts", sts.Name)
+	assert.Equal(t, "app1-headless", sts.Spec.ServiceName)
+	assert.Len(t, sts.Spec.VolumeClaimTemplates, 2)
+	assert.Equal(t, "app1-pvc", sts.Spec.VolumeClaimTemplates[0].Name)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, sts.Spec.VolumeClaimTemplates[0].Spec.AccessModes)
+	assert.Equal(t, "ledger", sts.Spec.VolumeClaimTemplates[1].Name)
+	assert.Equal(t, "fast", *sts.Spec.VolumeClaimTemplates[1].Spec.StorageClassName)
+	for _, v := range sts.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, "app1-pvc", v.Name, "workspace is claimed by template")
+	}
+	assert.Contains(t, sts.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "ledger", MountPath: "/var/ledger"})
+	assert.True(t, ownClaim(app, k.volumes(app)[0], "app1-pvc-app1-sts-0"))
+	assert.True(t, ownClaim(app, k.volumes(app)[1], "ledger-app1-sts-1"))
+	assert.False(t, ownClaim(app, k.volumes(app)[1], "app1-pvc-app1-sts-0"))
+
+	svc := k.headlessService(app)
+	assert.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+	assert.Equal(t, map[string]string{"uuid": "app1"}, svc.Spec.Selector)
+	assert.Equal(t, corev1.ProtocolTCP, svc.Spec.Ports[0].Protocol)
+
+	app.Workload = WorkloadDeployment
+	dep, err := k.deployment(context.Background(), app)
+	assert.Nil(t, err)
+	vols := dep.Spec.Template.Spec.Volumes
+	assert.Equal(t, "app1-pvc", vols[len(vols)-2].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, "app1-ledger", vols[len(vols)-1].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, "1Gi", k.volumes(app)[0].Size)
+}
+
+func TestVolumeSpec(t *testing.T) {
+	spec, err := volumeSpec(&worker0.App_Volume{Name: "workspace", Size: "5Gi", MountPath: "/ignored"})
+	assert.Nil(t, err)
+	assert.Equal(t, VolumeSpec{Name: "workspace", Size: "5Gi", AccessMode: "ReadWriteOnce"}, spec)
+
+	spec, err = volumeSpec(&worker0.App_Volume{Name: "data", Size: "2Gi", AccessModeType: worker0.App_Volume_ReadWriteMany, MountPath: "/data/"})
+	assert.Nil(t, err)
+	assert.Equal(t, "ReadWriteMany", spec.AccessMode)
+	assert.Equal(t, "/data", spec.MountPath)
+
+	for _, v := range []*worker0.App_Volume{
+		{Name: "Data", Size: "1Gi", MountPath: "/data"},
+		{Name: "run", Size: "1Gi", MountPath: "/data"},
+		{Name: "data", Size: "1x", MountPath: "/data"},
+		{Name: "data", Size: "0", MountPath: "/data"},
+		{Name: "data", Size: "1Gi", MountPath: "data"},
+	} {
+		_, err = volumeSpec(v)
+		assert.NotNil(t, err, "volume [%+v]", v)
+	}
+}
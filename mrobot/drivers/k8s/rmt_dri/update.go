@@ -0,0 +1,193 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/common/md5"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	"github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/core"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// UpdateApp apply changed spec to app, deployment of started app is updated with the strategy
+func (k *K8sWorker) UpdateApp(ctx context.Context, req *worker0.UpdateAppReq) (*worker0.App, error) {
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	next, err := updateApp(ctx, app, req)
+	if err != nil {
+		return nil, err
+	}
+	cli, err := k.client(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "new k8s client")
+	}
+	// registry may be changed with image
+	if next.Image != app.Image {
+		err = k.applyPullSecret(ctx, cli, next)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if app.Workload == WorkloadStatefulSet {
+		err = k.updateStatefulSet(ctx, cli, next)
+		if err != nil {
+			return nil, err
+		}
+		*app = *next
+		return k.workerApp(app), nil
+	}
+	name := fmt.Sprintf("%s-dep", app.UID)
+	old, err := cli.GetDeployment(name, k.ns(app))
+	if err != nil {
+		if !apierrors.IsNotFound(errors.Cause(err)) {
+			return nil, errors.Wrapf(err, "get deployment [%s]", name)
+		}
+		log.Debug(ctx, "Currently app not started, only update spec")
+		*app = *next
+		return k.workerApp(app), nil
+	}
+	dep, err := k.deployment(ctx, next)
+	if err != nil {
+		return nil, err
+	}
+	// keep replicas, app may be scaled or stopped
+	dep.Spec.Replicas = old.Spec.Replicas
+	dep.Spec.Selector = keepSelector(old.Spec.Selector, &dep.Spec.Template)
+	dep.Spec.Strategy = updateStrategy(req.UpdateStrategy)
+	log.Infof(ctx, "update deployment [%s] with image [%s], strategy [%s]", name, next.Image, dep.Spec.Strategy.Type)
+	err = cli.UpdateDeployment(dep)
+	if err != nil {
+		return nil, errors.Wrapf(err, "update deployment [%s]", name)
+	}
+	*app = *next
+	return k.workerApp(app), nil
+}
+
+// updateApp apply update request to a copy of app
+func updateApp(ctx context.Context, app *App, req *worker0.UpdateAppReq) (*App, error) {
+	next := *app
+	next.Environments = make(map[string]string)
+	for key, val := range app.Environments {
+		next.Environments[key] = val
+	}
+	next.FileMounts = make(map[string]FileMount)
+	for key, val := range app.FileMounts {
+		next.FileMounts[key] = val
+	}
+	if len(req.Name) != 0 || len(req.Version) != 0 {
+		if len(req.Name) == 0 || len(req.Version) == 0 {
+			return nil, errors.Errorf("fail to get name [%s] or version [%s] info", req.Name, req.Version)
+		}
+		pkg, err := core.PackageInfo(ctx, req.Name, req.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get package info")
+		}
+		next.Image = fmt.Sprintf("%s:%s", pkg.Image.ImageName, pkg.Image.Tag)
+		next.PullPolicy = corev1.PullPolicy(pkg.Image.PullPolicy)
+		next.WorkDir = pkg.Image.WorkDir
+		next.Command = pkg.Image.StartCommands
+	}
+	for _, e := range req.Envs {
+		if len(e.Key) == 0 {
+			return nil, errors.New("env got empty key")
+		}
+		if len(e.Value) == 0 {
+			delete(next.Environments, e.Key)
+			continue
+		}
+		next.Environments[e.Key] = e.Value
+	}
+	if len(req.FileMounts) != 0 {
+		next.FileMounts = make(map[string]FileMount)
+		for _, m := range req.FileMounts {
+			if len(m.File) == 0 {
+				return nil, errors.New("file of mount is empty")
+			}
+			key := md5.MD5(fmt.Sprintf("%s:%s:%s", m.File, m.MountTo, m.Volume))
+			next.FileMounts[key] = FileMount{File: m.File, MountTo: m.MountTo, Volume: m.Volume}
+		}
+	}
+	return &next, nil
+}
+
+// keepSelector selector of workload is immutable, so the old one is kept, and its labels are
+// added to pod template in case tags are changed, pods must still be selected by it
+func keepSelector(old *metav1.LabelSelector, tpl *corev1.PodTemplateSpec) *metav1.LabelSelector {
+	labels := make(map[string]string)
+	for key, val := range tpl.Labels {
+		labels[key] = val
+	}
+	for key, val := range old.MatchLabels {
+		labels[key] = val
+	}
+	tpl.Labels = labels
+	return old
+}
+
+// updateStrategy rolling update keeps old pod until the new one is ready
+func updateStrategy(s worker0.UpdateAppReq_Strategy) v1.DeploymentStrategy {
+	if s != worker0.UpdateAppReq_RollingUpdate {
+		return v1.DeploymentStrategy{Type: v1.RecreateDeploymentStrategyType}
+	}
+	unavailable, surge := intstr.FromInt(0), intstr.FromInt(1)
+	return v1.DeploymentStrategy{
+		Type: v1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &v1.RollingUpdateDeployment{
+			MaxUnavailable: &unavailable,
+			MaxSurge:       &surge,
+		},
+	}
+}
+
+// updateStatefulSet update pod template of started stateful set, stateful set is always
+// updated by rolling since each pod must be replaced to take its claim
+func (k *K8sWorker) updateStatefulSet(ctx context.Context, cli *base.Client, next *App) error {
+	name := stsName(next.UID)
+	old, err := cli.GetStatefulSetByName(name, k.ns(next), metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "get stateful set [%s]", name)
+		}
+		log.Debug(ctx, "Currently app not started, only update spec")
+		return nil
+	}
+	sts, err := k.statefulSet(ctx, next)
+	if err != nil {
+		return err
+	}
+	// keep replicas, app may be stopped. claim templates can not be updated
+	sts.Spec.Replicas = old.Spec.Replicas
+	sts.Spec.VolumeClaimTemplates = old.Spec.VolumeClaimTemplates
+	sts.Spec.Selector = keepSelector(old.Spec.Selector, &sts.Spec.Template)
+	log.Infof(ctx, "update stateful set [%s] with image [%s]", name, next.Image)
+	err = cli.UpdateStatefulSet(sts)
+	if err != nil {
+		return errors.Wrapf(err, "update stateful set [%s]", name)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestKeepSelector(t *testing.T) {
+	tags := map[string]string{"uuid": "app1", "env": "prod"}
+	tpl := &corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: tags}}
+	old := &metav1.LabelSelector{MatchLabels: map[string]string{"uuid": "app1", "tier": "db"}}
+	sel := keepSelector(old, tpl)
+	assert.Equal(t, old, sel)
+	assert.Equal(t, map[string]string{"uuid": "app1", "env": "prod", "tier": "db"}, tpl.Labels)
+	assert.Len(t, tags, 2, "tags of app not changed")
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/k8s/kube_driver/base"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"path"
+	"sort"
+	"strings"
+)
+
+// workspaceVolume name of volume which configures claim of workspace
+const workspaceVolume = "workspace"
+
+// defaultWorkspaceSize size of workspace claim if it is not configured
+const defaultWorkspaceSize = "1Gi"
+
+// volumes specs of all the volumes of app, workspace is the first
+func (k *K8sWorker) volumes(app *App) []VolumeSpec {
+	ws := VolumeSpec{
+		Name:         workspaceVolume,
+		Size:         defaultWorkspaceSize,
+		AccessMode:   string(corev1.ReadWriteMany),
+		StorageClass: k.StorageClass,
+	}
+	// claim of stateful set is only used by its own pod
+	if app.Workload == WorkloadStatefulSet {
+		ws.AccessMode = string(corev1.ReadWriteOnce)
+	}
+	if v, ok := app.Volumes[workspaceVolume]; ok {
+		ws = v
+	}
+	var names []string
+	for name := range app.Volumes {
+		if name != workspaceVolume {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	vs := []VolumeSpec{ws}
+	for _, name := range names {
+		vs = append(vs, app.Volumes[name])
+	}
+	return vs
+}
+
+// podVolumeName name of volume in pod, workspace is '<uid>-pvc', the others keep their names so
+// that FileMount can refer them
+func podVolumeName(app *App, v VolumeSpec) string {
+	if v.Name == workspaceVolume {
+		return fmt.Sprintf("%s-pvc", app.UID)
+	}
+	return v.Name
+}
+
+// claimName name of claim which backs volume of deployment
+func claimName(app *App, v VolumeSpec) string {
+	if v.Name == workspaceVolume {
+		return fmt.Sprintf("%s-pvc", app.UID)
+	}
+	return fmt.Sprintf("%s-%s", app.UID, v.Name)
+}
+
+// ownClaim whether the claim backs the volume, claims of stateful set are named
+// '<volume>-<sts>-<ordinal>' by k8s
+func ownClaim(app *App, v VolumeSpec, claim string) bool {
+	if app.Workload == WorkloadStatefulSet {
+		return strings.HasPrefix(claim, fmt.Sprintf("%s-%s-", podVolumeName(app, v), stsName(app.UID)))
+	}
+	return claim == claimName(app, v)
+}
+
+// claim build claim of volume
+func (k *K8sWorker) claim(app *App, v VolumeSpec) *corev1.PersistentVolumeClaim {
+	sc := v.StorageClass
+	return &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName(app, v),
+			Namespace: k.ns(app),
+			Labels:    app.Tags,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.PersistentVolumeAccessMode(v.AccessMode)},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(v.Size),
+				},
+			},
+			StorageClassName: &sc,
+		},
+	}
+}
+
+// volumeSpec check volume from request
+func volumeSpec(vol *worker0.App_Volume) (VolumeSpec, error) {
+	if errs := validation.IsDNS1123Label(vol.Name); len(errs) != 0 {
+		return VolumeSpec{}, errors.Errorf("volume name [%s] not correct, %s", vol.Name, strings.Join(errs, ", "))
+	}
+	if vol.Name == "run" {
+		return VolumeSpec{}, errors.New("volume named 'run' is reserved")
+	}
+	size, err := resource.ParseQuantity(vol.Size)
+	if err != nil {
+		return VolumeSpec{}, errors.Wrapf(err, "parse size [%s] of volume", vol.Size)
+	}
+	if size.Sign() <= 0 {
+		return VolumeSpec{}, errors.Errorf("size [%s] of volume must be positive", vol.Size)
+	}
+	spec := VolumeSpec{
+		Name:         vol.Name,
+		Size:         vol.Size,
+		AccessMode:   vol.AccessModeType.String(),
+		StorageClass: vol.StorageClass,
+	}
+	// workspace is always mounted to workdir of app
+	if vol.Name != workspaceVolume {
+		if !path.IsAbs(vol.MountPath) {
+			return VolumeSpec{}, errors.Errorf("mount path [%s] of volume must be absolute", vol.MountPath)
+		}
+		spec.MountPath = path.Clean(vol.MountPath)
+	}
+	return spec, nil
+}
+
+// VolumeEx set volume of app, storage class of volume must exist in cluster. After app started, new
+// volume can not be added, and only size of volume can be increased which expands its claims
+func (k *K8sWorker) VolumeEx(ctx context.Context, vol *worker0.App_Volume) (*worker0.App_Volume, error) {
+	log.Debug(ctx, "Currently start to execute set volume")
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	spec, err := volumeSpec(vol)
+	if err != nil {
+		return nil, err
+	}
+	if len(spec.StorageClass) == 0 {
+		spec.StorageClass = k.StorageClass
+	}
+	cli, err := k.client(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "new k8s client")
+	}
+	sc, err := cli.GetStorageClass(spec.StorageClass)
+	if err != nil {
+		return nil, errors.Wrapf(err, "check storage class [%s] of volume", spec.StorageClass)
+	}
+	claims, err := cli.ListPersistentVolumeClaimsByLabels(k.ns(app), map[string]string{"uuid": app.UID})
+	if err != nil {
+		return nil, errors.Wrap(err, "list claims of app")
+	}
+	err = k.expandVolume(ctx, cli, app, spec, sc, claims)
+	if err != nil {
+		return nil, err
+	}
+	if app.Volumes == nil {
+		app.Volumes = make(map[string]VolumeSpec)
+	}
+	app.Volumes[spec.Name] = spec
+	vol.StorageClass = spec.StorageClass
+	vol.MountPath = spec.MountPath
+	return vol, nil
+}
+
+// expandVolume expand claims of volume to the size of spec, claims of volume which is
+// not claimed yet are created when app starts. claim template of stateful set can not be
+// updated, claims created by it later keep the former size
+func (k *K8sWorker) expandVolume(ctx context.Context, cli *base.Client, app *App, spec VolumeSpec, sc *storagev1.StorageClass, claims []corev1.PersistentVolumeClaim) error {
+	var cur *VolumeSpec
+	for _, v := range k.volumes(app) {
+		if v.Name == spec.Name {
+			v := v
+			cur = &v
+		}
+	}
+	if cur == nil {
+		if len(claims) != 0 {
+			return errors.Errorf("volume [%s] can not be added after app started", spec.Name)
+		}
+		return nil
+	}
+	want := resource.MustParse(spec.Size)
+	for i := range claims {
+		c := &claims[i]
+		if !ownClaim(app, *cur, c.Name) {
+			continue
+		}
+		if cur.AccessMode != spec.AccessMode || cur.StorageClass != spec.StorageClass {
+			return errors.Errorf("access mode and storage class of claimed volume [%s] can not be changed", spec.Name)
+		}
+		size := c.Spec.Resources.Requests[corev1.ResourceStorage]
+		switch want.Cmp(size) {
+		case 0:
+			continue
+		case -1:
+			return errors.Errorf("claim [%s] can not be shrunk from [%s] to [%s]", c.Name, size.String(), spec.Size)
+		}
+		if sc.AllowVolumeExpansion == nil || !*sc.AllowVolumeExpansion {
+			return errors.Errorf("storage class [%s] does not allow volume expansion", sc.Name)
+		}
+		log.Infof(ctx, "expand claim [%s] from [%s] to [%s]", c.Name, size.String(), spec.Size)
+		c.Spec.Resources.Requests[corev1.ResourceStorage] = want
+		err := cli.UpdatePersistentVolumeClaim(c)
+		if err != nil {
+			return errors.Wrapf(err, "expand claim [%s]", c.Name)
+		}
+	}
+	return nil
+}
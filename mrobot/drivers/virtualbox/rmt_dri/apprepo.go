@@ -51,6 +51,19 @@ func (w *workRepository) new(ctx context.Context, wsp *App) error {
 	return nil
 }
 
+func (w *workRepository) list() []*App {
+	var apps []*App
+	w.rep.Range(func(key, value interface{}) bool {
+		apps = append(apps, value.(*App))
+		return true
+	})
+	return apps
+}
+
+func (w *workRepository) delete(uid string) {
+	w.rep.Delete(uid)
+}
+
 func guid(ctx context.Context) (uid string, err error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -82,7 +95,32 @@ type App struct {
 	Limit               *Limit
 	Health              *HealthOption
 	Log                 *Log
+	Restart             *Restart
 	Ports               map[int]PortInfo
+	// ReservedPorts port in vm -> host port reserved by ReservePort
+	ReservedPorts map[int]int
+
+	// status runtime status of main process
+	status procStatus
+	// proc main process of app
+	proc process
+	// health result of health monitor
+	health healthStatus
+	// healthMu guards Health, it is replaced by HealthEx while monitor reads it
+	healthMu sync.Mutex
+}
+
+// healthOption probes of app, option is never changed in place, it is replaced as a whole
+func (a *App) healthOption() *HealthOption {
+	a.healthMu.Lock()
+	defer a.healthMu.Unlock()
+	return a.Health
+}
+
+func (a *App) setHealthOption(h *HealthOption) {
+	a.healthMu.Lock()
+	defer a.healthMu.Unlock()
+	a.Health = h
 }
 
 type PortInfo struct {
@@ -92,6 +130,21 @@ type PortInfo struct {
 	HostPortMapping int    `validate:"required"`
 }
 
+// RestartPolicy restart policy of main process, same as name of worker0.App_Restart_Policy
+type RestartPolicy string
+
+const (
+	RestartAlways    RestartPolicy = "Always"
+	RestartOnFailure RestartPolicy = "OnFailure"
+	RestartNever     RestartPolicy = "Never"
+)
+
+type Restart struct {
+	Policy RestartPolicy
+	// Backoff seconds to wait before the first restart
+	Backoff int
+}
+
 type Log struct {
 	RealTimeFile    string
 	CompressLogPath string
@@ -104,10 +157,33 @@ const (
 	HttpPost MethodType = "httpPost"
 )
 
+// ProbeType type of health probe
+type ProbeType string
+
+const (
+	ProbeHTTP ProbeType = "http"
+	ProbeTCP  ProbeType = "tcp"
+	ProbeExec ProbeType = "exec"
+	ProbeGRPC ProbeType = "grpc"
+)
+
 type HealthBasic struct {
+	Type   ProbeType
 	Method MethodType
-	Path   string `validate:"required"`
-	Port   int    `validate:"required"`
+	Path   string
+	Port   int `validate:"required"`
+	// Command command of exec probe
+	Command []string
+	// GRPCService service of grpc probe
+	GRPCService string
+	// timings in seconds, 0 means default
+	InitialDelay int
+	Period       int
+	Timeout      int
+	// FailureThreshold failures in a row before probe failed
+	FailureThreshold int
+	// SuccessThreshold successes in a row before probe passed
+	SuccessThreshold int
 }
 
 type HealthOption struct {
@@ -132,4 +208,11 @@ type FilePremise struct {
 	Name        string
 	AcquireAddr string
 	Shell       string
+	// Sensitive file is only readable by owner
+	Sensitive bool
+	// CheckSum sha256 of file in hex
+	CheckSum string
+	// ExtractTo dir relative to workdir which the tar.gz file is extracted into
+	ExtractTo       string
+	StripComponents int
 }
@@ -0,0 +1,156 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// cgroupRoot mount point of cgroup v2 unified hierarchy
+var cgroupRoot = "/sys/fs/cgroup"
+
+const (
+	// cgroupParent parent cgroup of all apps started by agent
+	cgroupParent = "cmapp"
+	// cpuPeriod period of cpu.max in microseconds
+	cpuPeriod = 100000
+	// minCPUQuota min quota of cpu.max accepted by kernel in microseconds
+	minCPUQuota = 1000
+)
+
+// setupCgroup create cgroup of app with limits, path of cgroup is returned
+func setupCgroup(app *App) (string, error) {
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return "", errors.Errorf("cgroup v2 is not mounted at [%s]", cgroupRoot)
+	}
+	parent := filepath.Join(cgroupRoot, cgroupParent)
+	err := os.MkdirAll(parent, 0755)
+	if err != nil {
+		return "", errors.Wrapf(err, "create cgroup [%s]", parent)
+	}
+	// controllers must be enabled in every ancestor, they may have been enabled already
+	for _, dir := range []string{cgroupRoot, parent} {
+		for _, c := range []string{"+cpu", "+memory"} {
+			_ = ioutil.WriteFile(filepath.Join(dir, "cgroup.subtree_control"), []byte(c), 0644)
+		}
+	}
+	path := filepath.Join(parent, app.UID)
+	err = os.MkdirAll(path, 0755)
+	if err != nil {
+		return "", errors.Wrapf(err, "create cgroup [%s]", path)
+	}
+	err = applyLimit(path, app.Limit)
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// applyLimit write limit to cgroup, cpu in millicores and memory in MB, 0 means no limit
+func applyLimit(path string, l *Limit) error {
+	cpu, mem := fmt.Sprintf("max %d", cpuPeriod), "max"
+	if l != nil && l.CPU > 0 {
+		// limit under 10m is raised to min quota, kernel reject it with EINVAL
+		quota := l.CPU * cpuPeriod / 1000
+		if quota < minCPUQuota {
+			quota = minCPUQuota
+		}
+		cpu = fmt.Sprintf("%d %d", quota, cpuPeriod)
+	}
+	if l != nil && l.Memory > 0 {
+		mem = strconv.Itoa(l.Memory * mb)
+	}
+	err := ioutil.WriteFile(filepath.Join(path, "cpu.max"), []byte(cpu), 0644)
+	if err != nil {
+		return errors.Wrapf(err, "write cpu.max [%s]", cpu)
+	}
+	err = ioutil.WriteFile(filepath.Join(path, "memory.max"), []byte(mem), 0644)
+	if err != nil {
+		return errors.Wrapf(err, "write memory.max [%s]", mem)
+	}
+	// disable swap so memory limit works like docker, swap controller may be absent
+	if l != nil && l.Memory > 0 {
+		_ = ioutil.WriteFile(filepath.Join(path, "memory.swap.max"), []byte("0"), 0644)
+	}
+	return nil
+}
+
+// readCgroup read enforced limit and count of oom kills of cgroup
+func readCgroup(path string) (*Limit, int, error) {
+	l := &Limit{}
+	cpu, err := ioutil.ReadFile(filepath.Join(path, "cpu.max"))
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "read cpu.max")
+	}
+	if fs := strings.Fields(string(cpu)); len(fs) == 2 && fs[0] != "max" {
+		quota, _ := strconv.Atoi(fs[0])
+		period, _ := strconv.Atoi(fs[1])
+		if period > 0 {
+			l.CPU = quota * 1000 / period
+		}
+	}
+	mem, err := ioutil.ReadFile(filepath.Join(path, "memory.max"))
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "read memory.max")
+	}
+	if m := strings.TrimSpace(string(mem)); m != "max" {
+		bs, _ := strconv.Atoi(m)
+		l.Memory = bs / mb
+	}
+	f, err := os.Open(filepath.Join(path, "memory.events"))
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "open memory.events")
+	}
+	defer f.Close()
+	var oom int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if fs := strings.Fields(scanner.Text()); len(fs) == 2 && fs[0] == "oom_kill" {
+			oom, _ = strconv.Atoi(fs[1])
+		}
+	}
+	return l, oom, nil
+}
+
+// oomKills count of oom kills of cgroup, 0 if it is unknown
+func oomKills(path string) int {
+	if len(path) == 0 {
+		return 0
+	}
+	_, oom, err := readCgroup(path)
+	if err != nil {
+		return 0
+	}
+	return oom
+}
+
+// removeCgroup remove cgroup of app, all the processes in it must have exited
+func removeCgroup(uid string) error {
+	path := filepath.Join(cgroupRoot, cgroupParent, uid)
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "remove cgroup [%s]", path)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCgroupLimit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroup")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	old := cgroupRoot
+	cgroupRoot = dir
+	defer func() { cgroupRoot = old }()
+
+	app := &App{UID: "app1", Limit: &Limit{CPU: 500, Memory: 256}}
+	_, err = setupCgroup(app)
+	assert.NotNil(t, err, "cgroup v2 is not mounted")
+
+	err = ioutil.WriteFile(filepath.Join(dir, "cgroup.controllers"), []byte("cpu memory"), 0644)
+	assert.Nil(t, err)
+	path, err := setupCgroup(app)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, cgroupParent, "app1"), path)
+	cpu, err := ioutil.ReadFile(filepath.Join(path, "cpu.max"))
+	assert.Nil(t, err)
+	assert.Equal(t, "50000 100000", string(cpu))
+	mem, err := ioutil.ReadFile(filepath.Join(path, "memory.max"))
+	assert.Nil(t, err)
+	assert.Equal(t, "268435456", string(mem))
+
+	err = ioutil.WriteFile(filepath.Join(path, "memory.events"), []byte("low 0\nhigh 0\nmax 3\noom 2\noom_kill 2\n"), 0644)
+	assert.Nil(t, err)
+	l, oom, err := readCgroup(path)
+	assert.Nil(t, err)
+	assert.Equal(t, &Limit{CPU: 500, Memory: 256}, l)
+	assert.Equal(t, 2, oom)
+
+	err = applyLimit(path, &Limit{})
+	assert.Nil(t, err)
+	l, _, err = readCgroup(path)
+	assert.Nil(t, err)
+	assert.Equal(t, &Limit{}, l)
+	assert.Equal(t, 0, oomKills(""))
+
+	err = applyLimit(path, &Limit{CPU: 5})
+	assert.Nil(t, err)
+	cpu, err = ioutil.ReadFile(filepath.Join(path, "cpu.max"))
+	assert.Nil(t, err)
+	assert.Equal(t, "1000 100000", string(cpu), "quota is raised to min")
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/creack/pty"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// defaultExecTimeout timeout in seconds of command exec in app
+const defaultExecTimeout = 30
+
+// ExecInApp run command in workspace of app with environments of app
+func (v *VirtualboxWorker) ExecInApp(ctx context.Context, req *worker0.ExecReq) (*worker0.ExecRes, error) {
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	if len(req.Command) == 0 {
+		return nil, errors.New("command to exec is nil")
+	}
+	timeout := int(req.Timeout)
+	if timeout <= 0 {
+		timeout = defaultExecTimeout
+	}
+	abs, _ := filepath.Abs(app.Workspace)
+	tctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+	// run argv directly, joining it into shell would split or expand its elements
+	c := exec.CommandContext(tctx, req.Command[0], req.Command[1:]...)
+	c.Dir = abs
+	c.Env = os.Environ()
+	for k, val := range execEnvs(ctx, app, req.Envs) {
+		c.Env = append(c.Env, fmt.Sprintf("%s=%s", k, val))
+	}
+	var stdout, stderr bytes.Buffer
+	c.Stdout, c.Stderr = &stdout, &stderr
+	err = c.Run()
+	if tctx.Err() != nil {
+		return nil, errors.Wrapf(tctx.Err(), "exec command [%v], timeout [%d]s", req.Command, timeout)
+	}
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, errors.Wrapf(err, "exec command [%v]", req.Command)
+	}
+	log.Debugf(ctx, "Currently exec command [%v] in app, exit code [%d]", req.Command, c.ProcessState.ExitCode())
+	return &worker0.ExecRes{Stdout: stdout.String(), Stderr: stderr.String(), ExitCode: int32(c.ProcessState.ExitCode())}, nil
+}
+
+// ExecInAppTTY run command in workspace of app with pty
+func (v *VirtualboxWorker) ExecInAppTTY(stream worker0.Worker0_ExecInAppTTYServer) error {
+	ctx := stream.Context()
+	app, err := repo.load(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fail to load app from repo")
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		return errors.Wrap(err, "receive start input")
+	}
+	if first.Start == nil || len(first.Start.Command) == 0 {
+		return errors.New("command to exec is nil, first input must carry start")
+	}
+	abs, _ := filepath.Abs(app.Workspace)
+	c := exec.CommandContext(ctx, first.Start.Command[0], first.Start.Command[1:]...)
+	c.Dir = abs
+	c.Env = os.Environ()
+	for k, val := range execEnvs(ctx, app, first.Start.Envs) {
+		c.Env = append(c.Env, fmt.Sprintf("%s=%s", k, val))
+	}
+	f, err := pty.Start(c)
+	if err != nil {
+		return errors.Wrap(err, "start command with pty")
+	}
+	defer f.Close()
+
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			if len(in.Stdin) != 0 {
+				if _, err = f.Write(in.Stdin); err != nil {
+					return
+				}
+			}
+			if in.Resize != nil {
+				_ = pty.Setsize(f, &pty.Winsize{Rows: uint16(in.Resize.Height), Cols: uint16(in.Resize.Width)})
+			}
+		}
+	}()
+
+	buf := make([]byte, 4096)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if serr := stream.Send(&worker0.ExecOutput{Stdout: append([]byte{}, buf[:n]...)}); serr != nil {
+				return errors.Wrap(serr, "send output")
+			}
+		}
+		// EIO is returned once process exit on linux
+		if err != nil {
+			if err != io.EOF && !errors.Is(err, os.ErrClosed) {
+				log.Debugf(ctx, "Currently pty read end. Err: [%v]", err)
+			}
+			break
+		}
+	}
+	out := &worker0.ExecOutput{Exited: true}
+	if err = c.Wait(); err != nil {
+		out.Message = err.Error()
+	}
+	out.ExitCode = int32(c.ProcessState.ExitCode())
+	return stream.Send(out)
+}
+
+// execEnvs environments of app process covered by envs of request
+func execEnvs(ctx context.Context, app *App, envs map[string]string) map[string]string {
+	es := appEnvs(ctx, app)
+	for k, val := range envs {
+		es[k] = val
+	}
+	return es
+}
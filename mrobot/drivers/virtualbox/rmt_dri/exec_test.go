@@ -0,0 +1,52 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"google.golang.org/grpc/metadata"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestExecInApp(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ws")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{"MA_UUID": "app-exec"}))
+	assert.Nil(t, repo.new(ctx, &App{Workspace: dir, Environments: map[string]string{"PEER": "peer0"}}))
+	defer repo.delete("app-exec")
+	v := &VirtualboxWorker{}
+
+	res, err := v.ExecInApp(ctx, &worker0.ExecReq{Command: []string{"sh", "-c", "echo $PEER $0; echo oops >&2; exit 3", "a b"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "peer0 a b\n", res.Stdout)
+	assert.Equal(t, "oops\n", res.Stderr)
+	assert.Equal(t, int32(3), res.ExitCode)
+
+	start := time.Now()
+	_, err = v.ExecInApp(ctx, &worker0.ExecReq{Command: []string{"sleep", "10"}, Timeout: 1})
+	assert.NotNil(t, err, "command is killed after timeout")
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+
+	_, err = v.ExecInApp(ctx, &worker0.ExecReq{Command: []string{"no-such-command"}})
+	assert.NotNil(t, err)
+}
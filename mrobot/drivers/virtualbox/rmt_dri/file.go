@@ -0,0 +1,354 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	agfw "github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/worker"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultFileMode mode of written file when mode is not set
+const defaultFileMode = 0644
+
+// ListFiles list files in workspace of app
+func (v *VirtualboxWorker) ListFiles(ctx context.Context, req *worker0.FileReq) (*worker0.ListFilesRes, error) {
+	root, err := workspaceRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	files, err := listFiles(root, req.Path, req.Recursive)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list files of [%s]", req.Path)
+	}
+	return &worker0.ListFilesRes{Files: files}, nil
+}
+
+// ReadFile read file in workspace of app
+func (v *VirtualboxWorker) ReadFile(req *worker0.FileReq, stream worker0.Worker0_ReadFileServer) error {
+	root, err := workspaceRoot(stream.Context())
+	if err != nil {
+		return err
+	}
+	p, err := safePath(root, req.Path, true)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return errors.Wrapf(err, "open file [%s]", req.Path)
+	}
+	defer f.Close()
+	_, err = io.Copy(&agfw.ChunkWriter{Send: stream.Send}, f)
+	if err != nil {
+		return errors.Wrapf(err, "read file [%s]", req.Path)
+	}
+	return nil
+}
+
+// WriteFile write file in workspace of app, content is written to a temp file first and then renamed
+func (v *VirtualboxWorker) WriteFile(stream worker0.Worker0_WriteFileServer) error {
+	ctx := stream.Context()
+	root, err := workspaceRoot(ctx)
+	if err != nil {
+		return err
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		return errors.Wrap(err, "receive first chunk")
+	}
+	if len(first.Path) == 0 {
+		return errors.New("path of file is nil, first chunk must carry path")
+	}
+	info, err := writeFile(root, first, func() ([]byte, error) {
+		c, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return c.Data, nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "write file [%s]", first.Path)
+	}
+	log.Debugf(ctx, "Currently write file [%s], size [%d]", info.Path, info.Size)
+	return stream.SendAndClose(info)
+}
+
+// DeleteFile delete file in workspace of app
+func (v *VirtualboxWorker) DeleteFile(ctx context.Context, req *worker0.FileReq) (*worker0.Empty, error) {
+	root, err := workspaceRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if agfw.WorkspacePath(root, req.Path) == root {
+		return nil, errors.New("can not delete workspace of app")
+	}
+	// the link itself is deleted rather than its target
+	p, err := safePath(root, req.Path, false)
+	if err != nil {
+		return nil, err
+	}
+	if req.Recursive {
+		err = os.RemoveAll(p)
+	} else {
+		err = os.Remove(p)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "delete file [%s]", req.Path)
+	}
+	log.Debugf(ctx, "Currently delete file [%s]", req.Path)
+	return &worker0.Empty{}, nil
+}
+
+// ArchiveDir archive dir in workspace of app as tar.gz
+func (v *VirtualboxWorker) ArchiveDir(req *worker0.FileReq, stream worker0.Worker0_ArchiveDirServer) error {
+	root, err := workspaceRoot(stream.Context())
+	if err != nil {
+		return err
+	}
+	p, err := safePath(root, req.Path, true)
+	if err != nil {
+		return err
+	}
+	err = archiveDir(p, &agfw.ChunkWriter{Send: stream.Send})
+	if err != nil {
+		return errors.Wrapf(err, "archive dir [%s]", req.Path)
+	}
+	return nil
+}
+
+// workspaceRoot absolute workspace of app in metadata
+func workspaceRoot(ctx context.Context) (string, error) {
+	app, err := repo.load(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "fail to load app from repo")
+	}
+	abs, err := filepath.Abs(app.Workspace)
+	if err != nil {
+		return "", errors.Wrapf(err, "get abs path of workspace [%s]", app.Workspace)
+	}
+	return abs, nil
+}
+
+// safePath join p to workspace root and resolve symlinks of it, the path is rejected once it
+// escape the root through a link. Last element of p is not resolved when follow is false.
+func safePath(root, p string, follow bool) (string, error) {
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", errors.Wrapf(err, "resolve workspace [%s]", root)
+	}
+	target := agfw.WorkspacePath(root, p)
+	dir, base := target, ""
+	if !follow && target != root {
+		dir, base = filepath.Dir(target), filepath.Base(target)
+	}
+	real, err := evalExisting(dir)
+	if err != nil {
+		return "", errors.Wrapf(err, "resolve path [%s]", p)
+	}
+	rel, err := filepath.Rel(realRoot, real)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("path [%s] is out of workspace", p)
+	}
+	return filepath.Join(root, rel, base), nil
+}
+
+// evalExisting resolve symlinks of the longest existing prefix of p, the rest is joined as it is
+func evalExisting(p string) (string, error) {
+	var rest string
+	for {
+		real, err := filepath.EvalSymlinks(p)
+		if err == nil {
+			return filepath.Join(real, rest), nil
+		}
+		parent := filepath.Dir(p)
+		if !os.IsNotExist(err) || parent == p {
+			return "", err
+		}
+		rest = filepath.Join(filepath.Base(p), rest)
+		p = parent
+	}
+}
+
+// listFiles list the file itself, or the children of dir
+func listFiles(root, p string, recursive bool) ([]*worker0.FileInfo, error) {
+	target, err := safePath(root, p, true)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(target)
+	if err != nil {
+		return nil, err
+	}
+	if !stat.IsDir() {
+		return []*worker0.FileInfo{fileInfo(root, target, stat)}, nil
+	}
+	var files []*worker0.FileInfo
+	if !recursive {
+		infos, err := ioutil.ReadDir(target)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			files = append(files, fileInfo(root, filepath.Join(target, info.Name()), info))
+		}
+		return files, nil
+	}
+	err = filepath.Walk(target, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != target {
+			files = append(files, fileInfo(root, path, info))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func fileInfo(root, path string, info os.FileInfo) *worker0.FileInfo {
+	rel, _ := filepath.Rel(root, path)
+	return &worker0.FileInfo{
+		Path:    filepath.ToSlash(rel),
+		Size:    info.Size(),
+		Mode:    uint32(info.Mode().Perm()),
+		ModTime: info.ModTime().Unix(),
+		IsDir:   info.IsDir(),
+	}
+}
+
+// writeFile write first chunk and the following chunks returned by next until io.EOF
+func writeFile(root string, first *worker0.WriteFileReq, next func() ([]byte, error)) (*worker0.FileInfo, error) {
+	if agfw.WorkspacePath(root, first.Path) == root {
+		return nil, errors.New("can not write to workspace of app")
+	}
+	// rename replace the link itself, so only parent dir need to be resolved
+	target, err := safePath(root, first.Path, false)
+	if err != nil {
+		return nil, err
+	}
+	err = os.MkdirAll(filepath.Dir(target), os.ModePerm)
+	if err != nil {
+		return nil, errors.Wrap(err, "mkdir parent dir")
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(target), "."+filepath.Base(target)+".")
+	if err != nil {
+		return nil, errors.Wrap(err, "create temp file")
+	}
+	defer os.Remove(tmp.Name())
+	data := first.Data
+	for {
+		if _, err = tmp.Write(data); err != nil {
+			_ = tmp.Close()
+			return nil, errors.Wrap(err, "write temp file")
+		}
+		data, err = next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			_ = tmp.Close()
+			return nil, errors.Wrap(err, "receive chunk")
+		}
+	}
+	if err = tmp.Close(); err != nil {
+		return nil, errors.Wrap(err, "close temp file")
+	}
+	mode := os.FileMode(first.Mode).Perm()
+	if mode == 0 {
+		mode = defaultFileMode
+	}
+	if err = os.Chmod(tmp.Name(), mode); err != nil {
+		return nil, errors.Wrap(err, "chmod temp file")
+	}
+	if err = os.Rename(tmp.Name(), target); err != nil {
+		return nil, errors.Wrap(err, "rename temp file")
+	}
+	stat, err := os.Stat(target)
+	if err != nil {
+		return nil, errors.Wrap(err, "stat file")
+	}
+	return fileInfo(root, target, stat), nil
+}
+
+// archiveDir write tar.gz of dir to w, names in archive are relative to dir
+func archiveDir(dir string, w io.Writer) error {
+	stat, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !stat.IsDir() {
+		return errors.Errorf("[%s] is not dir", dir)
+	}
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err = tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestWriteAndListFiles(t *testing.T) {
+	root, err := ioutil.TempDir("", "ws")
+	assert.Nil(t, err)
+	defer os.RemoveAll(root)
+
+	chunks := [][]byte{[]byte("b"), []byte("c")}
+	info, err := writeFile(root, &worker0.WriteFileReq{Path: "../../conf/a.txt", Data: []byte("a")}, func() ([]byte, error) {
+		if len(chunks) == 0 {
+			return nil, io.EOF
+		}
+		c := chunks[0]
+		chunks = chunks[1:]
+		return c, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "conf/a.txt", info.Path)
+	assert.Equal(t, int64(3), info.Size)
+	assert.Equal(t, uint32(0644), info.Mode)
+	content, err := ioutil.ReadFile(filepath.Join(root, "conf", "a.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", string(content))
+
+	files, err := listFiles(root, "/", false)
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, "conf", files[0].Path)
+	assert.True(t, files[0].IsDir)
+
+	files, err = listFiles(root, "", true)
+	assert.Nil(t, err)
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	assert.Equal(t, []string{"conf", "conf/a.txt"}, paths)
+
+	files, err = listFiles(root, "conf/a.txt", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "conf/a.txt", files[0].Path)
+}
+
+func TestArchiveDir(t *testing.T) {
+	root, err := ioutil.TempDir("", "ws")
+	assert.Nil(t, err)
+	defer os.RemoveAll(root)
+	assert.Nil(t, os.MkdirAll(filepath.Join(root, "data", "sub"), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(root, "data", "sub", "f"), []byte("hello"), 0644))
+
+	var buf bytes.Buffer
+	assert.Nil(t, archiveDir(filepath.Join(root, "data"), &buf))
+	gr, err := gzip.NewReader(&buf)
+	assert.Nil(t, err)
+	tr := tar.NewReader(gr)
+	contents := make(map[string]string)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		names = append(names, hdr.Name)
+		b, _ := ioutil.ReadAll(tr)
+		contents[hdr.Name] = string(b)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"sub/", "sub/f"}, names)
+	assert.Equal(t, "hello", contents["sub/f"])
+
+	assert.NotNil(t, archiveDir(filepath.Join(root, "data", "sub", "f"), &buf))
+}
+
+func TestSafePath(t *testing.T) {
+	root, err := ioutil.TempDir("", "ws")
+	assert.Nil(t, err)
+	defer os.RemoveAll(root)
+	outside, err := ioutil.TempDir("", "outside")
+	assert.Nil(t, err)
+	defer os.RemoveAll(outside)
+	assert.Nil(t, os.Symlink(outside, filepath.Join(root, "out")))
+	assert.Nil(t, os.MkdirAll(filepath.Join(root, "data"), os.ModePerm))
+	assert.Nil(t, os.Symlink("data", filepath.Join(root, "in")))
+
+	_, err = safePath(root, "out/passwd", true)
+	assert.NotNil(t, err)
+	_, err = safePath(root, "out/sub/new.txt", false)
+	assert.NotNil(t, err)
+	_, err = listFiles(root, "out", false)
+	assert.NotNil(t, err)
+	_, err = writeFile(root, &worker0.WriteFileReq{Path: "out/a.txt", Data: []byte("a")}, func() ([]byte, error) {
+		return nil, io.EOF
+	})
+	assert.NotNil(t, err)
+
+	// link itself is in workspace
+	p, err := safePath(root, "out", false)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(root, "out"), p)
+	p, err = safePath(root, "in/new/a.txt", true)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(root, "data", "new", "a.txt"), p)
+}
@@ -0,0 +1,413 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	agfw "github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/worker"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"net"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	// probeInterval interval between two rounds of probes
+	probeInterval = 10 * time.Second
+	// probeTimeout timeout of each probe
+	probeTimeout = 5 * time.Second
+	// probeDelay liveness is not probed until process has been running for this long
+	probeDelay = 10 * time.Second
+	// failureThreshold process is restarted after liveness probe failed this many times in a row
+	failureThreshold = 3
+	// readyFailureThreshold app is unhealthy after readness probe failed this many times in a row
+	readyFailureThreshold = 1
+)
+
+// healthEvents health transitions of apps, pushed to core by agent framework
+var healthEvents = newHealthQueue()
+
+// probeClient timeout of request is decided by probe
+var probeClient = &http.Client{}
+
+// healthStatus health of app judged by health monitor
+type healthStatus struct {
+	mu      sync.Mutex
+	known   bool
+	healthy bool
+	message string
+}
+
+// set health of app, whether the health is changed is returned
+func (h *healthStatus) set(healthy bool, message string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	changed := !h.known || h.healthy != healthy
+	h.known, h.healthy, h.message = true, healthy, message
+	return changed
+}
+
+func (h *healthStatus) get() (bool, string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.healthy, h.message
+}
+
+// healthQueue pending health transitions, only the latest one of each app is kept, so
+// transition is never dropped when core is slow and the stale ones are merged
+type healthQueue struct {
+	mu      sync.Mutex
+	pending map[string]agfw.AppHealth
+	order   []string
+	notify  chan struct{}
+	out     chan agfw.AppHealth
+	once    sync.Once
+}
+
+func newHealthQueue() *healthQueue {
+	return &healthQueue{
+		pending: make(map[string]agfw.AppHealth),
+		notify:  make(chan struct{}, 1),
+		out:     make(chan agfw.AppHealth),
+	}
+}
+
+// push replace pending transition of same app, it never blocks
+func (q *healthQueue) push(h agfw.AppHealth) {
+	q.mu.Lock()
+	if _, ok := q.pending[h.AppUUID]; !ok {
+		q.order = append(q.order, h.AppUUID)
+	}
+	q.pending[h.AppUUID] = h
+	q.mu.Unlock()
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// pop the oldest pending transition
+func (q *healthQueue) pop() (agfw.AppHealth, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.order) == 0 {
+		return agfw.AppHealth{}, false
+	}
+	uid := q.order[0]
+	q.order = q.order[1:]
+	h := q.pending[uid]
+	delete(q.pending, uid)
+	return h, true
+}
+
+// events start forwarding pending transitions once it is consumed
+func (q *healthQueue) events() <-chan agfw.AppHealth {
+	q.once.Do(func() {
+		go func() {
+			for range q.notify {
+				for h, ok := q.pop(); ok; h, ok = q.pop() {
+					q.out <- h
+				}
+			}
+		}()
+	})
+	return q.out
+}
+
+// HealthEvents health transitions of apps, implement agfw.HealthNotifier
+func (v *VirtualboxWorker) HealthEvents() <-chan agfw.AppHealth {
+	return healthEvents.events()
+}
+
+// emptyProbe core always sends both probes, the one without port and command is not set
+func emptyProbe(b *worker0.App_Health_Basic) bool {
+	return b == nil || (b.Port == 0 && len(b.Command) == 0)
+}
+
+// probeOpt convert probe of worker0 to probe of app
+func probeOpt(b *worker0.App_Health_Basic) (*HealthBasic, error) {
+	h := &HealthBasic{
+		Path:             b.Path,
+		Port:             int(b.Port),
+		Command:          b.Command,
+		GRPCService:      b.GRPCService,
+		InitialDelay:     int(b.InitialDelaySeconds),
+		Period:           int(b.PeriodSeconds),
+		Timeout:          int(b.TimeoutSeconds),
+		FailureThreshold: int(b.FailureThreshold),
+		SuccessThreshold: int(b.SuccessThreshold),
+	}
+	if h.InitialDelay < 0 || h.Period < 0 || h.Timeout < 0 || h.FailureThreshold < 0 || h.SuccessThreshold < 0 {
+		return nil, errors.Errorf("timings of probe [%+v] can not be negative", b)
+	}
+	switch b.ProbeType {
+	case worker0.App_Health_Basic_HTTP:
+		h.Type = ProbeHTTP
+		switch b.MethodType {
+		case worker0.App_Health_Basic_GET:
+			h.Method = HttpGet
+		case worker0.App_Health_Basic_POST:
+			h.Method = HttpPost
+		default:
+			return nil, errors.Errorf("fail to parse method [%s]", b.MethodType)
+		}
+	case worker0.App_Health_Basic_TCP:
+		h.Type = ProbeTCP
+	case worker0.App_Health_Basic_EXEC:
+		h.Type = ProbeExec
+		if len(b.Command) == 0 {
+			return nil, errors.New("command of exec probe is empty")
+		}
+	case worker0.App_Health_Basic_GRPC:
+		h.Type = ProbeGRPC
+	default:
+		return nil, errors.Errorf("fail to parse probe type [%s]", b.ProbeType)
+	}
+	if h.Type != ProbeExec && b.Port <= 0 {
+		return nil, errors.Errorf("port [%d] of probe not correct", b.Port)
+	}
+	return h, nil
+}
+
+// seconds convert seconds to duration, def is used if seconds is not set
+func seconds(sec int, def time.Duration) time.Duration {
+	if sec <= 0 {
+		return def
+	}
+	return time.Duration(sec) * time.Second
+}
+
+// threshold def is used if threshold is not set
+func threshold(n, def int) int {
+	if n <= 0 {
+		return def
+	}
+	return n
+}
+
+// probe check app once, dir is the work dir of exec probe. nil is returned if app passed
+func probe(h *HealthBasic, dir string) error {
+	timeout := seconds(h.Timeout, probeTimeout)
+	switch h.Type {
+	case ProbeTCP:
+		conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", h.Port), timeout)
+		if err != nil {
+			return errors.Wrapf(err, "dial port [%d]", h.Port)
+		}
+		_ = conn.Close()
+		return nil
+	case ProbeGRPC:
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		conn, err := grpc.DialContext(ctx, fmt.Sprintf("127.0.0.1:%d", h.Port), grpc.WithInsecure(), grpc.WithBlock())
+		if err != nil {
+			return errors.Wrapf(err, "dial grpc port [%d]", h.Port)
+		}
+		defer conn.Close()
+		res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: h.GRPCService})
+		if err != nil {
+			return errors.Wrapf(err, "check health of service [%s]", h.GRPCService)
+		}
+		if res.Status != healthpb.HealthCheckResponse_SERVING {
+			return errors.Errorf("grpc health status [%s]", res.Status)
+		}
+		return nil
+	case ProbeExec:
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		c := exec.CommandContext(ctx, h.Command[0], h.Command[1:]...)
+		c.Dir = dir
+		out, err := c.CombinedOutput()
+		if err != nil {
+			return errors.Wrapf(err, "exec command [%s], out [%s]", strings.Join(h.Command, " "), out)
+		}
+		return nil
+	default:
+		method := http.MethodGet
+		if h.Method == HttpPost {
+			method = http.MethodPost
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("http://127.0.0.1:%d%s", h.Port, h.Path), nil)
+		if err != nil {
+			return errors.Wrap(err, "new http request")
+		}
+		resp, err := probeClient.Do(req)
+		if err != nil {
+			return errors.Wrap(err, "do http request")
+		}
+		_ = resp.Body.Close()
+		// same as k8s, any code between 200 and 400 indicates success
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+			return errors.Errorf("http response code [%d]", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// probeState results of a probe in a row
+type probeState struct {
+	last      time.Time
+	failures  int
+	successes int
+	passed    bool
+	err       error
+}
+
+// due whether probe should run now, probe runs every period after its initial delay since process
+// started. tick is the interval of monitor, half of it is tolerated
+func (s *probeState) due(h *HealthBasic, started time.Time, delay, tick time.Duration) bool {
+	now := time.Now()
+	if now.Sub(started) < seconds(h.InitialDelay, delay) {
+		return false
+	}
+	if !s.last.IsZero() && now.Sub(s.last) < seconds(h.Period, probeInterval)-tick/2 {
+		return false
+	}
+	s.last = now
+	return true
+}
+
+// record result of probe, probe passes after success threshold and fails after failure threshold
+func (s *probeState) record(h *HealthBasic, err error, failureDef int) {
+	s.err = err
+	if err != nil {
+		s.failures, s.successes = s.failures+1, 0
+		if s.failures >= threshold(h.FailureThreshold, failureDef) {
+			s.passed = false
+		}
+		return
+	}
+	s.failures, s.successes = 0, s.successes+1
+	if s.successes >= threshold(h.SuccessThreshold, 1) {
+		s.passed = true
+	}
+}
+
+// monitorTick interval of monitor, the shortest period of probes
+func monitorTick(hc *HealthOption) time.Duration {
+	tick := probeInterval
+	if hc == nil {
+		return tick
+	}
+	for _, h := range []*HealthBasic{hc.Liveness, hc.Readness} {
+		if h != nil {
+			if p := seconds(h.Period, probeInterval); p < tick {
+				tick = p
+			}
+		}
+	}
+	return tick
+}
+
+// monitor probe app by periods of its probes until supervisor of process exit. Process is
+// killed after liveness failed its failure threshold times, then supervisor restarts it by
+// restart policy. Health transitions are pushed to core.
+func monitor(ctx context.Context, app *App, dir string, done <-chan struct{}) {
+	tick := monitorTick(app.healthOption())
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	var (
+		pid        int
+		started    time.Time
+		ready      bool
+		live, read probeState
+	)
+	for {
+		// probes may be changed by HealthEx while app is running
+		hc := app.healthOption()
+		if t := monitorTick(hc); t != tick {
+			tick = t
+			ticker.Reset(tick)
+		}
+		state, p, _, msg := app.status.get()
+		switch state {
+		case worker0.AppStatus_Running:
+			if p != pid {
+				pid, started, ready = p, time.Now(), false
+				live, read = probeState{}, probeState{}
+			}
+			healthy, reason := true, ""
+			if hc != nil && hc.Liveness != nil {
+				if live.due(hc.Liveness, started, probeDelay, tick) {
+					live.record(hc.Liveness, probe(hc.Liveness, dir), failureThreshold)
+				}
+				if live.failures > 0 {
+					healthy, reason = false, fmt.Sprintf("liveness probe failed [%d] times. Err: [%v]", live.failures, live.err)
+					if live.failures >= threshold(hc.Liveness.FailureThreshold, failureThreshold) {
+						killUnhealthy(ctx, app, pid, reason)
+						live.failures = 0
+					}
+				}
+			}
+			if healthy && hc != nil && hc.Readness != nil {
+				if read.due(hc.Readness, started, 0, tick) {
+					read.record(hc.Readness, probe(hc.Readness, dir), readyFailureThreshold)
+				}
+				if read.passed {
+					ready = true
+				} else {
+					healthy, reason = false, fmt.Sprintf("readness probe not passed. Err: [%v]", read.err)
+				}
+			}
+			// app is still starting, not report it as unhealthy
+			if healthy || ready || time.Since(started) >= readyTimeout {
+				setHealth(ctx, app, healthy, reason)
+			}
+		case worker0.AppStatus_Failed, worker0.AppStatus_Stopped:
+			setHealth(ctx, app, false, fmt.Sprintf("process not running, message [%s]", msg))
+		}
+		select {
+		case <-done:
+			setHealth(ctx, app, false, "process stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// killUnhealthy kill process group of unhealthy process, supervisor decides whether to restart it
+func killUnhealthy(ctx context.Context, app *App, pid int, reason string) {
+	log.Warnf(ctx, "Currently kill process group [%d] of app, reason [%s]", pid, reason)
+	app.proc.markUnhealthy(reason)
+	if err := syscall.Kill(-pid, syscall.SIGKILL); err != nil {
+		log.Errorf(ctx, "Manage err when kill process group [%d]. Err: [%v]", pid, err)
+	}
+}
+
+// setHealth set health of app, transition is pushed to core
+func setHealth(ctx context.Context, app *App, healthy bool, message string) {
+	if !app.health.set(healthy, message) {
+		return
+	}
+	log.Infof(ctx, "Currently health of app changed to [%t], message [%s]", healthy, message)
+	tags := make(map[string]string, len(app.Tags))
+	for k, v := range app.Tags {
+		tags[k] = v
+	}
+	healthEvents.push(agfw.AppHealth{AppUUID: app.UID, Tags: tags, Healthy: healthy, Message: message})
+}
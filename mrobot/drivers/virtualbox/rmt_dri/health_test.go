@@ -0,0 +1,184 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	agfw "github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/worker"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// closedPort a local port which nobody listens on
+func closedPort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	_ = l.Close()
+	return port
+}
+
+func TestProbe(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+	port, _ := strconv.Atoi(srv.URL[strings.LastIndex(srv.URL, ":")+1:])
+
+	assert.Nil(t, probe(&HealthBasic{Type: ProbeHTTP, Method: HttpGet, Path: "/healthz", Port: port}, ""))
+	assert.NotNil(t, probe(&HealthBasic{Type: ProbeHTTP, Method: HttpPost, Path: "/other", Port: port}, ""))
+	assert.Nil(t, probe(&HealthBasic{Type: ProbeTCP, Port: port}, ""))
+	assert.NotNil(t, probe(&HealthBasic{Type: ProbeTCP, Port: closedPort(t)}, ""))
+
+	dir, err := ioutil.TempDir("", "ws")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, ioutil.WriteFile(dir+"/ready", nil, 0644))
+	assert.Nil(t, probe(&HealthBasic{Type: ProbeExec, Command: []string{"test", "-f", "ready"}}, dir))
+	assert.Nil(t, ioutil.WriteFile(dir+"/a b", nil, 0644))
+	assert.Nil(t, probe(&HealthBasic{Type: ProbeExec, Command: []string{"test", "-f", "a b"}}, dir), "argv is not split again")
+
+	_, err = probeOpt(&worker0.App_Health_Basic{ProbeType: worker0.App_Health_Basic_EXEC})
+	assert.NotNil(t, err, "command of exec probe is required")
+	h, err := probeOpt(&worker0.App_Health_Basic{ProbeType: worker0.App_Health_Basic_TCP, Port: 80})
+	assert.Nil(t, err)
+	assert.Equal(t, &HealthBasic{Type: ProbeTCP, Port: 80}, h)
+	assert.True(t, emptyProbe(&worker0.App_Health_Basic{}))
+	_, err = probeOpt(&worker0.App_Health_Basic{ProbeType: worker0.App_Health_Basic_TCP, Port: 80, PeriodSeconds: -1})
+	assert.NotNil(t, err, "negative timing is rejected")
+}
+
+func TestProbeGRPC(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	srv := grpc.NewServer()
+	hs := health.NewServer()
+	hs.SetServingStatus("app", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(srv, hs)
+	go srv.Serve(l)
+	defer srv.Stop()
+	port := l.Addr().(*net.TCPAddr).Port
+
+	assert.Nil(t, probe(&HealthBasic{Type: ProbeGRPC, Port: port}, ""))
+	assert.NotNil(t, probe(&HealthBasic{Type: ProbeGRPC, Port: port, GRPCService: "app"}, ""))
+	assert.NotNil(t, probe(&HealthBasic{Type: ProbeGRPC, Port: closedPort(t), Timeout: 1}, ""))
+}
+
+func TestProbeState(t *testing.T) {
+	h := &HealthBasic{FailureThreshold: 2, SuccessThreshold: 2}
+	var s probeState
+	s.record(h, nil, failureThreshold)
+	assert.False(t, s.passed, "not passed before success threshold")
+	s.record(h, nil, failureThreshold)
+	assert.True(t, s.passed)
+	s.record(h, net.ErrClosed, failureThreshold)
+	assert.True(t, s.passed, "still passed before failure threshold")
+	s.record(h, net.ErrClosed, failureThreshold)
+	assert.False(t, s.passed)
+}
+
+func TestMonitorKillUnhealthy(t *testing.T) {
+	oldInterval, oldDelay := probeInterval, probeDelay
+	probeInterval, probeDelay = 100*time.Millisecond, 0
+	defer func() { probeInterval, probeDelay = oldInterval, oldDelay }()
+	dir, err := ioutil.TempDir("", "ws")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	app := &App{
+		UID:      "app-unhealthy",
+		StartCMD: []string{"sleep", "30"},
+		Restart:  &Restart{Policy: RestartNever},
+		Health:   &HealthOption{Liveness: &HealthBasic{Type: ProbeTCP, Port: closedPort(t)}},
+	}
+	launch(context.Background(), app, dir)
+	_, done := app.proc.get()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("unhealthy process not killed")
+	}
+	state, _, _, msg := app.status.get()
+	assert.Equal(t, worker0.AppStatus_Failed, state)
+	assert.Contains(t, msg, "unhealthy")
+	assert.Eventually(t, func() bool {
+		healthy, _ := app.health.get()
+		return !healthy
+	}, time.Second, 50*time.Millisecond)
+}
+
+func TestMonitorHealthChanged(t *testing.T) {
+	oldInterval, oldDelay := probeInterval, probeDelay
+	probeInterval, probeDelay = 2*time.Second, 0
+	defer func() { probeInterval, probeDelay = oldInterval, oldDelay }()
+	dir, err := ioutil.TempDir("", "ws")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	app := &App{
+		UID:      "app-health-changed",
+		StartCMD: []string{"sleep", "30"},
+		Restart:  &Restart{Policy: RestartNever},
+	}
+	launch(context.Background(), app, dir)
+	// probe set after app started is picked up with its own period
+	app.setHealthOption(&HealthOption{Liveness: &HealthBasic{Type: ProbeTCP, Port: closedPort(t), Period: 1, FailureThreshold: 1}})
+	_, done := app.proc.get()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("unhealthy process not killed")
+	}
+	_, _, _, msg := app.status.get()
+	assert.Contains(t, msg, "unhealthy")
+}
+
+func TestHealthQueue(t *testing.T) {
+	q := newHealthQueue()
+	q.push(agfw.AppHealth{AppUUID: "app1", Healthy: false, Message: "down"})
+	q.push(agfw.AppHealth{AppUUID: "app2", Healthy: false})
+	q.push(agfw.AppHealth{AppUUID: "app1", Healthy: true})
+
+	events := q.events()
+	h := <-events
+	assert.Equal(t, "app1", h.AppUUID)
+	assert.True(t, h.Healthy, "only the latest transition of app is kept")
+	h = <-events
+	assert.Equal(t, "app2", h.AppUUID)
+	select {
+	case h = <-events:
+		t.Fatalf("unexpected transition [%v]", h)
+	case <-time.After(100 * time.Millisecond):
+	}
+	q.push(agfw.AppHealth{AppUUID: "app2", Healthy: true})
+	h = <-events
+	assert.True(t, h.Healthy)
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// procStatus runtime status of main process of app
+type procStatus struct {
+	mu       sync.Mutex
+	state    worker0.AppStatus_State
+	pid      int
+	restarts int
+	message  string
+	// exitCode exit code of the last exited process
+	exitCode int
+}
+
+func (p *procStatus) set(state worker0.AppStatus_State, pid int, message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// process started again after it had been running
+	if state == worker0.AppStatus_Running && p.pid != 0 && p.pid != pid {
+		p.restarts++
+	}
+	p.state = state
+	if pid != 0 {
+		p.pid = pid
+	}
+	p.message = message
+}
+
+// exited record exit code of process
+func (p *procStatus) exited(code int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.exitCode = code
+}
+
+func (p *procStatus) lastExitCode() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.exitCode
+}
+
+func (p *procStatus) get() (worker0.AppStatus_State, int, int, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state, p.pid, p.restarts, p.message
+}
+
+// GetApp get app in metadata with status of its process
+func (v *VirtualboxWorker) GetApp(ctx context.Context, _ *worker0.Empty) (*worker0.AppStatus, error) {
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	return v.appStatus(ctx, app), nil
+}
+
+// ListApps list apps which have all the tags
+func (v *VirtualboxWorker) ListApps(ctx context.Context, req *worker0.ListAppsReq) (*worker0.ListAppsRes, error) {
+	res := &worker0.ListAppsRes{}
+	for _, app := range repo.list() {
+		if hasTags(app.Tags, req.Tags) {
+			res.Apps = append(res.Apps, v.appStatus(ctx, app))
+		}
+	}
+	return res, nil
+}
+
+// RenderApp virtualbox app is run by process on vm, there is no manifest to render
+func (v *VirtualboxWorker) RenderApp(ctx context.Context, _ *worker0.Empty) (*worker0.RenderAppRes, error) {
+	return nil, errors.New("render app not support, virtualbox app has no manifest")
+}
+
+func (v *VirtualboxWorker) appStatus(ctx context.Context, app *App) *worker0.AppStatus {
+	state, pid, restarts, msg := app.status.get()
+	if state == worker0.AppStatus_Unknown {
+		state = worker0.AppStatus_Created
+	}
+	// process may be killed outside
+	if state == worker0.AppStatus_Running && syscall.Kill(pid, 0) != nil {
+		state = worker0.AppStatus_Failed
+		msg = fmt.Sprintf("process [%d] not exist", pid)
+	}
+	st := &worker0.AppStatus{
+		App:          v.workerApp(ctx, app),
+		StateType:    state,
+		Pid:          int32(pid),
+		RestartCount: int32(restarts),
+		Message:      msg,
+		ExitCode:     int32(app.status.lastExitCode()),
+	}
+	if path := app.proc.cgroupPath(); len(path) != 0 {
+		if l, oom, err := readCgroup(path); err == nil {
+			st.EnforcedLimit = &worker0.App_Limit{CPU: int32(l.CPU), Memory: int32(l.Memory)}
+			st.OOMKills = int32(oom)
+		}
+	}
+	if state == worker0.AppStatus_Running {
+		st.Healthy, _ = app.health.get()
+	}
+	return st
+}
+
+func hasTags(tags map[string]string, want []*worker0.App_Tag) bool {
+	for _, t := range want {
+		if v, ok := tags[t.Key]; !ok || v != t.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// workerApp convert app in repo to worker0 app
+func (v *VirtualboxWorker) workerApp(ctx context.Context, app *App) *worker0.App {
+	name, version := app.Name, ""
+	if i := strings.LastIndex(app.Name, ":"); i != -1 {
+		name, version = app.Name[:i], app.Name[i+1:]
+	}
+	wa := &worker0.App{
+		UUID: app.UID,
+		MainP: &worker0.App_MainProcess{
+			CheckSum: app.PackageMd5,
+			Name:     name,
+			Version:  version,
+			Type:     worker0.App_MainProcess_Binary,
+			StartCMD: app.StartCMD,
+		},
+		Workspace: &worker0.App_WorkspaceInfo{Workspace: app.Workspace},
+	}
+	for _, m := range app.FileMounts {
+		wa.FileMounts = append(wa.FileMounts, &worker0.App_FileMount{File: m.File, MountTo: m.MountTo, Volume: m.Volume})
+	}
+	for k, val := range app.Environments {
+		wa.EnvironmentVars = append(wa.EnvironmentVars, &worker0.App_EnvVar{Key: k, Value: val})
+	}
+	localIP, err := getLocalIP()
+	if err != nil {
+		log.Warnf(ctx, "Currently fail to get local ip. Err: [%v]", err)
+	}
+	for _, p := range app.Ports {
+		wa.Networks = append(wa.Networks, &worker0.App_Network{
+			PortInfo: &worker0.App_Network_PortInf{
+				Port:         int32(p.Port),
+				Name:         p.Name,
+				ProtocolType: worker0.App_Network_PortInf_Protocol(worker0.App_Network_PortInf_Protocol_value[p.Protocol]),
+			},
+			RouteInfo: []*worker0.App_Network_RouteInf{
+				{RouteType: worker0.App_Network_RouteInf_IN, Router: fmt.Sprintf("%s:%d", localIP, p.Port)},
+				{RouteType: worker0.App_Network_RouteInf_OUT, Router: fmt.Sprintf("%s:%d", v.HostIP, p.HostPortMapping)},
+			},
+		})
+	}
+	for _, f := range app.FilePremises {
+		wa.FilePremise = append(wa.FilePremise, &worker0.App_File{Name: f.Name, AcquireAddr: f.AcquireAddr, Shell: f.Shell, Sensitive: f.Sensitive, CheckSum: f.CheckSum, ExtractTo: f.ExtractTo, StripComponents: int32(f.StripComponents)})
+	}
+	if app.Limit != nil {
+		wa.LimitInfo = &worker0.App_Limit{CPU: int32(app.Limit.CPU), Memory: int32(app.Limit.Memory)}
+	}
+	if hc := app.healthOption(); hc != nil {
+		wa.HealthInfo = &worker0.App_Health{Liveness: healthBasic(hc.Liveness), Readness: healthBasic(hc.Readness)}
+	}
+	if app.Log != nil {
+		wa.LogInfo = &worker0.App_Log{RealTimeFile: app.Log.RealTimeFile, FilePath: app.Log.CompressLogPath}
+	}
+	if app.Restart != nil {
+		wa.RestartInfo = &worker0.App_Restart{
+			PolicyType: worker0.App_Restart_Policy(worker0.App_Restart_Policy_value[string(app.Restart.Policy)]),
+			Backoff:    int32(app.Restart.Backoff),
+		}
+	}
+	for k, val := range app.Tags {
+		wa.Tags = append(wa.Tags, &worker0.App_Tag{Key: k, Value: val})
+	}
+	return wa
+}
+
+func healthBasic(h *HealthBasic) *worker0.App_Health_Basic {
+	if h == nil {
+		return nil
+	}
+	b := &worker0.App_Health_Basic{
+		Path:                h.Path,
+		Port:                int32(h.Port),
+		Command:             h.Command,
+		GRPCService:         h.GRPCService,
+		InitialDelaySeconds: int32(h.InitialDelay),
+		PeriodSeconds:       int32(h.Period),
+		TimeoutSeconds:      int32(h.Timeout),
+		FailureThreshold:    int32(h.FailureThreshold),
+		SuccessThreshold:    int32(h.SuccessThreshold),
+	}
+	if h.Method == HttpPost {
+		b.MethodType = worker0.App_Health_Basic_POST
+	}
+	switch h.Type {
+	case ProbeTCP:
+		b.ProbeType = worker0.App_Health_Basic_TCP
+	case ProbeExec:
+		b.ProbeType = worker0.App_Health_Basic_EXEC
+	case ProbeGRPC:
+		b.ProbeType = worker0.App_Health_Basic_GRPC
+	}
+	return b
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"testing"
+)
+
+func TestProcStatus(t *testing.T) {
+	var p procStatus
+	p.set(worker0.AppStatus_Running, 10, "")
+	p.set(worker0.AppStatus_Failed, 0, "exit 1")
+	state, pid, restarts, msg := p.get()
+	assert.Equal(t, worker0.AppStatus_Failed, state)
+	assert.Equal(t, 10, pid)
+	assert.Equal(t, 0, restarts)
+	assert.Equal(t, "exit 1", msg)
+
+	p.set(worker0.AppStatus_Running, 11, "")
+	_, pid, restarts, _ = p.get()
+	assert.Equal(t, 11, pid)
+	assert.Equal(t, 1, restarts)
+}
+
+func TestHasTags(t *testing.T) {
+	tags := map[string]string{"uuid": "app-1", "machine_id": "1"}
+	assert.True(t, hasTags(tags, nil))
+	assert.True(t, hasTags(tags, []*worker0.App_Tag{{Key: "machine_id", Value: "1"}}))
+	assert.False(t, hasTags(tags, []*worker0.App_Tag{{Key: "machine_id", Value: "2"}}))
+	assert.False(t, hasTags(tags, []*worker0.App_Tag{{Key: "chain", Value: "1"}}))
+}
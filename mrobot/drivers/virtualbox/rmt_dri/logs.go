@@ -0,0 +1,162 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"bufio"
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// followInterval interval to check new lines of real time file
+var followInterval = 500 * time.Millisecond
+
+// line timestamp layouts besides RFC3339, lines without timestamp are never filtered by since time
+var lineTimeLayouts = []string{"2006-01-02 15:04:05.000", "2006-01-02 15:04:05"}
+
+// StreamLogs tail real time log file of app
+func (v *VirtualboxWorker) StreamLogs(req *worker0.LogReq, stream worker0.Worker0_StreamLogsServer) error {
+	ctx := stream.Context()
+	app, err := repo.load(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fail to load app from repo")
+	}
+	if app.Log == nil || len(app.Log.RealTimeFile) == 0 {
+		return errors.Errorf("real time log file of app [%s] is not set", app.UID)
+	}
+	file := app.Log.RealTimeFile
+	if !filepath.IsAbs(file) {
+		ws, _ := filepath.Abs(app.Workspace)
+		file = filepath.Join(ws, file)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return errors.Wrapf(err, "open log file [%s]", file)
+	}
+	defer f.Close()
+
+	var since time.Time
+	if req.SinceTime != 0 {
+		since = time.Unix(req.SinceTime, 0)
+	}
+	lines, err := tailLines(f, int(req.Tail), since)
+	if err != nil {
+		return errors.Wrapf(err, "read log file [%s]", file)
+	}
+	source := filepath.Base(file)
+	for _, l := range lines {
+		if err = stream.Send(&worker0.LogLine{Line: l, Source: source}); err != nil {
+			return errors.Wrap(err, "send log line")
+		}
+	}
+	if !req.Follow {
+		return nil
+	}
+	log.Debugf(ctx, "Currently start to follow log file [%s]", file)
+	return follow(ctx, f, func(l string) error {
+		return stream.Send(&worker0.LogLine{Line: l, Source: source})
+	})
+}
+
+// tailLines read lines after since, only last tail lines are kept if tail > 0
+func tailLines(r io.Reader, tail int, since time.Time) ([]string, error) {
+	var lines []string
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+	for s.Scan() {
+		l := s.Text()
+		if !since.IsZero() {
+			if t, ok := lineTime(l); ok && t.Before(since) {
+				continue
+			}
+		}
+		lines = append(lines, l)
+		if tail > 0 && len(lines) > tail {
+			lines = lines[1:]
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// lineTime parse timestamp at the beginning of line
+func lineTime(l string) (time.Time, bool) {
+	if fs := strings.Fields(l); len(fs) != 0 {
+		if t, err := time.Parse(time.RFC3339Nano, fs[0]); err == nil {
+			return t, true
+		}
+	}
+	for _, layout := range lineTimeLayouts {
+		if len(l) < len(layout) {
+			continue
+		}
+		if t, err := time.ParseInLocation(layout, l[:len(layout)], time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// follow send new lines appended to f until ctx done, file truncated will be read from beginning
+func follow(ctx context.Context, f *os.File, send func(string) error) error {
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return errors.Wrap(err, "get offset of log file")
+	}
+	var partial string
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		st, err := f.Stat()
+		if err != nil {
+			return errors.Wrap(err, "stat log file")
+		}
+		if st.Size() < offset {
+			offset, partial = 0, ""
+		}
+		if st.Size() == offset {
+			continue
+		}
+		buf := make([]byte, st.Size()-offset)
+		n, err := f.ReadAt(buf, offset)
+		if err != nil && err != io.EOF {
+			return errors.Wrap(err, "read log file")
+		}
+		offset += int64(n)
+		parts := strings.Split(partial+string(buf[:n]), "\n")
+		partial = parts[len(parts)-1]
+		for _, l := range parts[:len(parts)-1] {
+			if err = send(l); err != nil {
+				return errors.Wrap(err, "send log line")
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTailLines(t *testing.T) {
+	content := "2021-06-01T10:00:00Z a\n2021-06-01T11:00:00Z b\nno time c\n2021-06-01 12:00:00 d\n"
+	lines, err := tailLines(strings.NewReader(content), 0, time.Time{})
+	assert.Nil(t, err)
+	assert.Len(t, lines, 4)
+
+	lines, err = tailLines(strings.NewReader(content), 2, time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"no time c", "2021-06-01 12:00:00 d"}, lines)
+
+	since, _ := time.Parse(time.RFC3339, "2021-06-01T10:30:00Z")
+	lines, err = tailLines(strings.NewReader(content), 0, since)
+	assert.Nil(t, err)
+	assert.Equal(t, "2021-06-01T11:00:00Z b", lines[0])
+	assert.Equal(t, "no time c", lines[1])
+}
+
+func TestFollow(t *testing.T) {
+	f, err := ioutil.TempFile("", "follow")
+	assert.Nil(t, err)
+	defer os.Remove(f.Name())
+	_, _ = f.WriteString("old\n")
+	_, _ = f.Seek(0, 2)
+
+	followInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var got []string
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		_, _ = f.WriteString("new1\nne")
+		time.Sleep(30 * time.Millisecond)
+		_, _ = f.WriteString("w2\n")
+	}()
+	err = follow(ctx, f, func(l string) error {
+		got = append(got, l)
+		if len(got) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"new1", "new2"}, got)
+}
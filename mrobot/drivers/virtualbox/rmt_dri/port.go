@@ -0,0 +1,129 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	virtualbox "github.com/zibuyu28/cmapp/mrobot/drivers/virtualbox/vboxm"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"net"
+	"sync"
+)
+
+// portMu serialize port check and reservation
+var portMu sync.Mutex
+
+// usedPorts ports in vm and host ports used or reserved by all apps
+func usedPorts() (map[int]string, map[int]string) {
+	vmPorts := make(map[int]string)
+	hostPorts := make(map[int]string)
+	for _, a := range repo.list() {
+		for p, pi := range a.Ports {
+			vmPorts[p] = a.UID
+			hostPorts[pi.HostPortMapping] = a.UID
+		}
+		for p, hp := range a.ReservedPorts {
+			vmPorts[p] = a.UID
+			hostPorts[hp] = a.UID
+		}
+	}
+	return vmPorts, hostPorts
+}
+
+func (v *VirtualboxWorker) checkPort(uid string, req *worker0.PortReq) *worker0.PortRes {
+	res := &worker0.PortRes{Port: req.Port, HostPort: req.HostPort}
+	vmPorts, hostPorts := usedPorts()
+	if req.Port != 0 {
+		if owner, ok := vmPorts[int(req.Port)]; ok && owner != uid {
+			res.Message = fmt.Sprintf("port [%d] is used by app [%s]", req.Port, owner)
+			return res
+		}
+		if _, ok := vmPorts[int(req.Port)]; !ok {
+			l, err := net.Listen("tcp", fmt.Sprintf(":%d", req.Port))
+			if err != nil {
+				res.Message = fmt.Sprintf("port [%d] is in use. Err: [%v]", req.Port, err)
+				return res
+			}
+			_ = l.Close()
+		}
+	}
+	if req.HostPort != 0 {
+		if owner, ok := hostPorts[int(req.HostPort)]; ok && owner != uid {
+			res.Message = fmt.Sprintf("host port [%d] is used by app [%s]", req.HostPort, owner)
+			return res
+		}
+		for _, e := range req.ExcludeHostPorts {
+			if e == req.HostPort {
+				res.Message = fmt.Sprintf("host port [%d] is reserved in core", req.HostPort)
+				return res
+			}
+		}
+		if _, ok := hostPorts[int(req.HostPort)]; !ok && !virtualbox.RmtTCPPortAvailable(v.HostIP, int(req.HostPort)) {
+			res.Message = fmt.Sprintf("host port [%d] is in use on host [%s]", req.HostPort, v.HostIP)
+			return res
+		}
+	}
+	res.Available = true
+	return res
+}
+
+// CheckPort check whether port in vm and host port are available
+func (v *VirtualboxWorker) CheckPort(ctx context.Context, req *worker0.PortReq) (*worker0.PortRes, error) {
+	uid, _ := guid(ctx)
+	portMu.Lock()
+	defer portMu.Unlock()
+	res := v.checkPort(uid, req)
+	log.Debugf(ctx, "Currently check port [%d], host port [%d], available [%t]", req.Port, req.HostPort, res.Available)
+	return res, nil
+}
+
+// ReservePort reserve port in vm and host port for app, host port is picked if not set
+func (v *VirtualboxWorker) ReservePort(ctx context.Context, req *worker0.PortReq) (*worker0.PortRes, error) {
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	if req.Port == 0 {
+		return nil, errors.New("port to reserve is nil")
+	}
+	portMu.Lock()
+	defer portMu.Unlock()
+	res := v.checkPort(app.UID, req)
+	if !res.Available {
+		return res, nil
+	}
+	if req.HostPort == 0 {
+		_, hostPorts := usedPorts()
+		exclude := make(map[int]struct{})
+		for p := range hostPorts {
+			exclude[p] = struct{}{}
+		}
+		for _, e := range req.ExcludeHostPorts {
+			exclude[int(e)] = struct{}{}
+		}
+		hp, err := virtualbox.RmtAvailableTCPPort(ctx, v.HostIP, exclude)
+		if err != nil {
+			return nil, errors.Wrap(err, "pick host port")
+		}
+		res.HostPort = int32(hp)
+	}
+	app.ReservedPorts[int(res.Port)] = int(res.HostPort)
+	log.Infof(ctx, "Currently reserve port [%d] host port [%d] for app", res.Port, res.HostPort)
+	return res, nil
+}
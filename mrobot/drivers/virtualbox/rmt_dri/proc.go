@@ -0,0 +1,315 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/cmd"
+	"github.com/zibuyu28/cmapp/common/httputil"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	// readyTimeout max time to wait app ready after process started
+	readyTimeout = time.Minute
+	// stopGrace time to wait process group exit after SIGTERM, then it is killed
+	stopGrace = 10 * time.Second
+	// stopTimeout max time to wait process exit after it is killed
+	stopTimeout = 30 * time.Second
+	// maxBackoff max time to wait before restart
+	maxBackoff = 5 * time.Minute
+	// backoffReset backoff is reset if process has been running for this long
+	backoffReset = 10 * time.Minute
+)
+
+// process main process of app, maintained by supervisor
+type process struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	// done closed once supervisor exit
+	done chan struct{}
+	// pid pid of running process, also the pgid since process is started with its own group
+	pid int
+	// stopping process is being stopped, never restart
+	stopping bool
+	// cgroup path of cgroup which limits the process, empty if limit is not enforced
+	cgroup string
+	// unhealthy reason why running process is killed by health monitor
+	unhealthy string
+}
+
+func (p *process) set(cancel context.CancelFunc, done chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cancel, p.done, p.stopping, p.pid, p.unhealthy = cancel, done, false, 0, ""
+}
+
+func (p *process) get() (context.CancelFunc, chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cancel, p.done
+}
+
+func (p *process) setCgroup(path string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cgroup = path
+}
+
+func (p *process) cgroupPath() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cgroup
+}
+
+// markUnhealthy record reason why the running process is killed by health monitor
+func (p *process) markUnhealthy(reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.unhealthy = reason
+}
+
+// takeUnhealthy get and clear the reason why the process is killed by health monitor
+func (p *process) takeUnhealthy() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	reason := p.unhealthy
+	p.unhealthy = ""
+	return reason
+}
+
+func (p *process) setPid(pid int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pid = pid
+}
+
+// stop mark process stopping, pid of running process is returned
+func (p *process) stop() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopping = true
+	return p.pid
+}
+
+func (p *process) isStopping() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stopping
+}
+
+// packageFile file of installation package in dir
+func packageFile(app *App, dir string) string {
+	split := strings.Split(app.InstallationPackage, "/")
+	return filepath.Join(dir, split[len(split)-1])
+}
+
+// installPackage download package to workspace and run package handle shells,
+// the package is moved from staged file instead of downloading if staged is set
+func installPackage(ctx context.Context, app *App, abs, staged string) error {
+	file := packageFile(app, abs)
+	if len(staged) != 0 {
+		err := os.Rename(staged, file)
+		if err != nil {
+			return errors.Wrapf(err, "move staged package [%s]", staged)
+		}
+	} else {
+		log.Debugf(ctx, "Currently start get main package add [%s], save to dir [%s/]", app.InstallationPackage, abs)
+		err := httputil.HTTPDoDownloadFile(file, app.InstallationPackage)
+		if err != nil {
+			return errors.Wrap(err, "download package")
+		}
+	}
+
+	// do package handle shell
+	for _, shell := range app.PackageHandleShells {
+		out, err := cmd.NewDefaultCMD(shell, []string{}, cmd.WithWorkDir(abs)).Run()
+		if err != nil {
+			return errors.Wrapf(err, "exec package handle shell [%s], Err: [%v]", shell, err)
+		}
+		log.Debugf(ctx, "Currently execute shell [%s] success, out [%s]", shell, out)
+	}
+	return nil
+}
+
+// launch start main process of app in background and supervise it, process is restarted by
+// restart policy of app after it exit. Process is not bound to ctx.
+func launch(ctx context.Context, app *App, abs string) {
+	ctx = log.WithAppUUID(ctx, app.UID)
+	setupCommand := strings.Join(app.StartCMD, " ")
+	processEnvs := appEnvs(ctx, app)
+	policy, backoff := restartPolicy(app.Restart)
+	runCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	app.proc.set(cancel, done)
+	var cgPath string
+	if app.Limit != nil {
+		path, err := setupCgroup(app)
+		if err != nil {
+			log.Warnf(ctx, "Currently limit of app is not enforced. Err: [%v]", err)
+		} else {
+			// shell joins the cgroup before running command, so all the descendants are limited
+			cgPath = path
+			setupCommand = fmt.Sprintf("echo $$ > %s && %s", filepath.Join(path, "cgroup.procs"), setupCommand)
+		}
+	}
+	app.proc.setCgroup(cgPath)
+	app.status.set(worker0.AppStatus_Starting, 0, "")
+	go func() {
+		defer close(done)
+		wait := backoff
+		for {
+			started := time.Now()
+			oomBefore := oomKills(cgPath)
+			defaultCMD := cmd.NewDefaultCMD(setupCommand, []string{}, cmd.WithWorkDir(abs),
+				cmd.WithEnvs(processEnvs), cmd.WithTimeout(-1), cmd.WithContext(runCtx), cmd.WithOnStart(func(pid int) {
+					app.proc.setPid(pid)
+					app.status.set(worker0.AppStatus_Running, pid, "")
+				}))
+			_, err := defaultCMD.Run()
+			app.proc.setPid(0)
+			code := defaultCMD.ExitCode()
+			app.status.exited(code)
+			if app.proc.isStopping() || runCtx.Err() != nil {
+				app.status.set(worker0.AppStatus_Stopped, 0, "process stopped")
+				log.Debugf(ctx, "Currently app process stopped")
+				return
+			}
+			if reason := app.proc.takeUnhealthy(); len(reason) != 0 {
+				app.status.set(worker0.AppStatus_Failed, 0, fmt.Sprintf("process killed as app is unhealthy, %s", reason))
+				log.Errorf(ctx, "Manage err when exec setup command [%s], process killed as app is unhealthy. Err: [%v]", setupCommand, err)
+			} else if oom := oomKills(cgPath); oom > oomBefore {
+				app.status.set(worker0.AppStatus_Failed, 0, fmt.Sprintf("process killed by oom, exit code [%d]", code))
+				log.Errorf(ctx, "Manage err when exec setup command [%s], process killed by oom. Err: [%v]", setupCommand, err)
+			} else if err != nil || code != 0 {
+				app.status.set(worker0.AppStatus_Failed, 0, fmt.Sprintf("process exit with code [%d]. Err: [%v]", code, err))
+				log.Errorf(ctx, "Manage err when exec setup command [%s], exit code [%d]. Err: [%v]", setupCommand, code, err)
+			} else {
+				app.status.set(worker0.AppStatus_Stopped, 0, "process exited")
+				log.Debugf(ctx, "Currently app process exited")
+			}
+			if !shouldRestart(policy, code, err) {
+				return
+			}
+			if time.Since(started) > backoffReset {
+				wait = backoff
+			}
+			log.Warnf(ctx, "Currently restart app process after [%s]", wait)
+			select {
+			case <-runCtx.Done():
+				app.status.set(worker0.AppStatus_Stopped, 0, "process stopped")
+				return
+			case <-time.After(wait):
+			}
+			if wait *= 2; wait > maxBackoff {
+				wait = maxBackoff
+			}
+		}
+	}()
+	go monitor(ctx, app, abs, done)
+}
+
+// restartPolicy policy and first backoff of restart, default to restart always like k8s
+func restartPolicy(r *Restart) (RestartPolicy, time.Duration) {
+	if r == nil {
+		return RestartAlways, time.Second
+	}
+	backoff := time.Duration(r.Backoff) * time.Second
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	return r.Policy, backoff
+}
+
+func shouldRestart(policy RestartPolicy, code int, err error) bool {
+	switch policy {
+	case RestartNever:
+		return false
+	case RestartOnFailure:
+		return err != nil || code != 0
+	default:
+		return true
+	}
+}
+
+// stopProcess stop main process of app, SIGTERM is sent to the process group first,
+// and the group is killed if it not exit in grace time
+func stopProcess(ctx context.Context, app *App) error {
+	cancel, done := app.proc.get()
+	if cancel == nil {
+		return nil
+	}
+	pid := app.proc.stop()
+	if pid != 0 {
+		log.Debugf(ctx, "Currently send SIGTERM to process group [%d]", pid)
+		if err := syscall.Kill(-pid, syscall.SIGTERM); err != nil {
+			log.Warnf(ctx, "Currently fail to send SIGTERM to process group [%d]. Err: [%v]", pid, err)
+		}
+		select {
+		case <-done:
+			return nil
+		case <-time.After(stopGrace):
+			log.Warnf(ctx, "Currently process group [%d] not exit in [%s], now to kill it", pid, stopGrace)
+		}
+	}
+	cancel()
+	select {
+	case <-done:
+		log.Debug(ctx, "Currently main process of app exited")
+		return nil
+	case <-time.After(stopTimeout):
+		return errors.Errorf("wait main process of app [%s] exit timeout", app.UID)
+	}
+}
+
+// waitReady probe readness of app every second until ready, process exited or timeout
+func waitReady(ctx context.Context, app *App) error {
+	hc := app.healthOption()
+	if hc == nil || hc.Readness == nil {
+		return nil
+	}
+	abs, _ := filepath.Abs(app.Workspace)
+	toutctx, cancelFunc := context.WithTimeout(ctx, readyTimeout)
+	defer cancelFunc()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			err := probe(hc.Readness, abs)
+			if err == nil {
+				return nil
+			}
+			log.Debugf(ctx, "Currently app not ready. Err: [%v]", err)
+			state, _, _, msg := app.status.get()
+			if state == worker0.AppStatus_Failed || state == worker0.AppStatus_Stopped {
+				return errors.Errorf("app process exited before ready, message [%s]", msg)
+			}
+		case <-toutctx.Done():
+			return errors.New("app readness check timeout")
+		}
+	}
+}
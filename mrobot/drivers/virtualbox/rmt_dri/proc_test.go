@@ -0,0 +1,81 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLaunchAndStop(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ws")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	app := &App{UID: "app-proc", StartCMD: []string{"sleep", "30"}}
+	ctx := context.Background()
+	launch(ctx, app, dir)
+	assert.Eventually(t, func() bool {
+		state, _, _, _ := app.status.get()
+		return state == worker0.AppStatus_Running
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Nil(t, waitReady(ctx, app))
+
+	assert.Nil(t, stopProcess(ctx, app))
+	state, _, _, msg := app.status.get()
+	assert.Equal(t, worker0.AppStatus_Stopped, state)
+	assert.Equal(t, "process stopped", msg)
+}
+
+func TestLaunchExitCode(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ws")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	app := &App{UID: "app-exit", StartCMD: []string{"exit", "3"}, Restart: &Restart{Policy: RestartNever}}
+	launch(context.Background(), app, dir)
+	_, done := app.proc.get()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("process not exit")
+	}
+	state, _, restarts, _ := app.status.get()
+	assert.Equal(t, worker0.AppStatus_Failed, state)
+	assert.Equal(t, 0, restarts)
+	assert.Equal(t, 3, app.status.lastExitCode())
+}
+
+func TestShouldRestart(t *testing.T) {
+	assert.True(t, shouldRestart(RestartAlways, 0, nil))
+	assert.False(t, shouldRestart(RestartNever, 1, nil))
+	assert.False(t, shouldRestart(RestartOnFailure, 0, nil))
+	assert.True(t, shouldRestart(RestartOnFailure, 1, nil))
+	assert.True(t, shouldRestart(RestartOnFailure, -1, errors.New("killed")))
+
+	policy, backoff := restartPolicy(nil)
+	assert.Equal(t, RestartAlways, policy)
+	assert.Equal(t, time.Second, backoff)
+	_, backoff = restartPolicy(&Restart{Policy: RestartOnFailure, Backoff: 5})
+	assert.Equal(t, 5*time.Second, backoff)
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"bufio"
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/mrobot/drivers/virtualbox/ssh_cmd"
+	virtualbox "github.com/zibuyu28/cmapp/mrobot/drivers/virtualbox/vboxm"
+	agfw "github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/worker"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// cpuSampleInterval interval between two samples of /proc/stat
+var cpuSampleInterval = time.Second
+
+// Resource allocatable from vm config, used from /proc stats of vm which agent running in
+func (v *VirtualboxWorker) Resource(ctx context.Context) (*agfw.Resource, error) {
+	res := &agfw.Resource{}
+	// vm config is read when agent starts, host is not logged in for every report
+	vm := v.vm
+	if vm == nil {
+		vm = &virtualbox.VM{CPUs: runtime.NumCPU()}
+	}
+	res.CPUAllocatable = vm.CPUs * 1000
+	res.MemoryAllocatable = vm.Memory
+
+	mf, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return nil, errors.Wrap(err, "open meminfo")
+	}
+	defer mf.Close()
+	total, avail, err := parseMeminfo(mf)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse meminfo")
+	}
+	if res.MemoryAllocatable == 0 {
+		res.MemoryAllocatable = total
+	}
+	res.MemoryUsed = total - avail
+
+	busy, err := cpuBusy()
+	if err != nil {
+		return nil, errors.Wrap(err, "get cpu usage")
+	}
+	res.CPUUsed = int(busy * float64(res.CPUAllocatable))
+
+	var st syscall.Statfs_t
+	err = syscall.Statfs("/", &st)
+	if err != nil {
+		return nil, errors.Wrap(err, "statfs of root")
+	}
+	res.DiskAllocatable = int(st.Blocks * uint64(st.Bsize) / mb)
+	res.DiskUsed = int((st.Blocks - st.Bfree) * uint64(st.Bsize) / mb)
+	return res, nil
+}
+
+// vmInfo read config of vm from host by ssh
+func (v *VirtualboxWorker) vmInfo(ctx context.Context) (*virtualbox.VM, error) {
+	cli, err := ssh_cmd.NewSSHCli(v.HostIP, v.HostPort, v.HostUsername, v.HostPassword)
+	if err != nil {
+		return nil, errors.Wrap(err, "new ssh cli")
+	}
+	defer cli.Close()
+	return virtualbox.NewRMTDriver(ctx, v.VBUUID, v.StorePath, v.HostIP, cli).VMInfo()
+}
+
+const mb = 1024 * 1024
+
+// parseMeminfo return MemTotal and MemAvailable in MB
+func parseMeminfo(r io.Reader) (int, int, error) {
+	var total, avail int
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fs := strings.Fields(s.Text())
+		if len(fs) < 2 {
+			continue
+		}
+		if fs[0] != "MemTotal:" && fs[0] != "MemAvailable:" {
+			continue
+		}
+		kb, err := strconv.Atoi(fs[1])
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "parse [%s]", s.Text())
+		}
+		if fs[0] == "MemTotal:" {
+			total = kb / 1024
+		} else {
+			avail = kb / 1024
+		}
+	}
+	if err := s.Err(); err != nil {
+		return 0, 0, errors.Wrap(err, "scan meminfo")
+	}
+	if total == 0 {
+		return 0, 0, errors.New("MemTotal not found")
+	}
+	return total, avail, nil
+}
+
+// parseCPUStat return busy and total jiffies of the 'cpu' line
+func parseCPUStat(r io.Reader) (uint64, uint64, error) {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fs := strings.Fields(s.Text())
+		if len(fs) < 5 || fs[0] != "cpu" {
+			continue
+		}
+		var busy, total uint64
+		for i, f := range fs[1:] {
+			n, err := strconv.ParseUint(f, 10, 64)
+			if err != nil {
+				return 0, 0, errors.Wrapf(err, "parse [%s]", s.Text())
+			}
+			total += n
+			// idle and iowait
+			if i != 3 && i != 4 {
+				busy += n
+			}
+		}
+		return busy, total, nil
+	}
+	return 0, 0, errors.New("cpu line not found")
+}
+
+func readCPUStat() (uint64, uint64, error) {
+	f, err := os.Open("/proc/stat")
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "open stat")
+	}
+	defer f.Close()
+	return parseCPUStat(f)
+}
+
+// cpuBusy busy ratio of cpu between two samples
+func cpuBusy() (float64, error) {
+	b1, t1, err := readCPUStat()
+	if err != nil {
+		return 0, err
+	}
+	time.Sleep(cpuSampleInterval)
+	b2, t2, err := readCPUStat()
+	if err != nil {
+		return 0, err
+	}
+	if t2 <= t1 {
+		return 0, nil
+	}
+	return float64(b2-b1) / float64(t2-t1), nil
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	virtualbox "github.com/zibuyu28/cmapp/mrobot/drivers/virtualbox/vboxm"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMeminfo(t *testing.T) {
+	total, avail, err := parseMeminfo(strings.NewReader("MemTotal:        2048000 kB\nMemFree:          512000 kB\nMemAvailable:    1024000 kB\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2000, total)
+	assert.Equal(t, 1000, avail)
+
+	_, _, err = parseMeminfo(strings.NewReader("MemFree: 1 kB\n"))
+	assert.NotNil(t, err)
+}
+
+func TestParseCPUStat(t *testing.T) {
+	busy, total, err := parseCPUStat(strings.NewReader("cpu  10 0 20 60 10 0 0 0 0 0\ncpu0 10 0 20 60 10 0 0 0 0 0\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(30), busy)
+	assert.Equal(t, uint64(100), total)
+}
+
+func TestResource(t *testing.T) {
+	old := cpuSampleInterval
+	cpuSampleInterval = 10 * time.Millisecond
+	defer func() { cpuSampleInterval = old }()
+	// host is not reachable, cached vm config is used without ssh
+	v := &VirtualboxWorker{HostIP: "127.0.0.1", HostPort: 1, vm: &virtualbox.VM{CPUs: 2, Memory: 2048}}
+	res, err := v.Resource(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 2000, res.CPUAllocatable)
+	assert.Equal(t, 2048, res.MemoryAllocatable)
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/httputil"
+	"github.com/zibuyu28/cmapp/common/log"
+	"github.com/zibuyu28/cmapp/common/md5"
+	"github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/core"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"os"
+	"path/filepath"
+)
+
+// stageDir dir in workspace to keep new package before swap
+const stageDir = ".update"
+
+// appSpec part of app which can be changed by update
+type appSpec struct {
+	Name                string
+	InstallationPackage string
+	PackageMd5          string
+	PackageHandleShells []string
+	StartCMD            []string
+	Environments        map[string]string
+	FileMounts          map[string]FileMount
+}
+
+func (a *App) spec() appSpec {
+	return appSpec{
+		Name:                a.Name,
+		InstallationPackage: a.InstallationPackage,
+		PackageMd5:          a.PackageMd5,
+		PackageHandleShells: a.PackageHandleShells,
+		StartCMD:            a.StartCMD,
+		Environments:        a.Environments,
+		FileMounts:          a.FileMounts,
+	}.clone()
+}
+
+// clone copy spec with maps, so changes of the copy never affect the origin
+func (s appSpec) clone() appSpec {
+	envs := make(map[string]string)
+	for key, val := range s.Environments {
+		envs[key] = val
+	}
+	mounts := make(map[string]FileMount)
+	for key, val := range s.FileMounts {
+		mounts[key] = val
+	}
+	s.Environments, s.FileMounts = envs, mounts
+	return s
+}
+
+func (a *App) setSpec(s appSpec) {
+	a.Name = s.Name
+	a.InstallationPackage = s.InstallationPackage
+	a.PackageMd5 = s.PackageMd5
+	a.PackageHandleShells = s.PackageHandleShells
+	a.StartCMD = s.StartCMD
+	a.Environments = s.Environments
+	a.FileMounts = s.FileMounts
+}
+
+// UpdateApp apply changed spec to app. Started app is updated by stopping the old process,
+// swapping the package and starting again, and it is rolled back if the new one is not ready.
+func (v *VirtualboxWorker) UpdateApp(ctx context.Context, req *worker0.UpdateAppReq) (*worker0.App, error) {
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	old := app.spec()
+	next, err := updateSpec(ctx, old, req)
+	if err != nil {
+		return nil, err
+	}
+	cancel, _ := app.proc.get()
+	if cancel == nil {
+		log.Debug(ctx, "Currently app not started, only update spec")
+		app.setSpec(next)
+		return v.workerApp(ctx, app), nil
+	}
+	abs, _ := filepath.Abs(app.Workspace)
+
+	// download new package before stopping the old process to shorten the downtime
+	var staged string
+	if next.InstallationPackage != old.InstallationPackage {
+		dir := filepath.Join(abs, stageDir)
+		err = os.MkdirAll(dir, os.ModePerm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "mkdir stage dir [%s]", dir)
+		}
+		defer os.RemoveAll(dir)
+		staged = packageFile(&App{InstallationPackage: next.InstallationPackage}, dir)
+		log.Debugf(ctx, "Currently download new package [%s] to [%s]", next.InstallationPackage, staged)
+		err = httputil.HTTPDoDownloadFile(staged, next.InstallationPackage)
+		if err != nil {
+			return nil, errors.Wrap(err, "download new package")
+		}
+	}
+
+	log.Infof(ctx, "update app from [%s] to [%s]", old.Name, next.Name)
+	err = stopProcess(ctx, app)
+	if err != nil {
+		return nil, errors.Wrap(err, "stop old process")
+	}
+	app.setSpec(next)
+	err = redeploy(ctx, app, abs, staged, staged != "")
+	if err == nil {
+		log.Infof(ctx, "update app to [%s] success", next.Name)
+		return v.workerApp(ctx, app), nil
+	}
+
+	log.Errorf(ctx, "Manage err when update app to [%s], now to roll back. Err: [%v]", next.Name, err)
+	if serr := stopProcess(ctx, app); serr != nil {
+		return nil, errors.Wrapf(err, "update app, roll back failed with stop process err [%v]", serr)
+	}
+	app.setSpec(old)
+	rerr := redeploy(ctx, app, abs, "", next.InstallationPackage != old.InstallationPackage)
+	if rerr != nil {
+		return nil, errors.Wrapf(err, "update app, roll back failed with err [%v]", rerr)
+	}
+	return nil, errors.Wrapf(err, "update app, rolled back to [%s]", old.Name)
+}
+
+// redeploy start app again, package is installed again if install is set
+func redeploy(ctx context.Context, app *App, abs, staged string, install bool) error {
+	if install {
+		err := installPackage(ctx, app, abs, staged)
+		if err != nil {
+			return err
+		}
+	}
+	launch(ctx, app, abs)
+	return waitReady(ctx, app)
+}
+
+// updateSpec apply update request to spec
+func updateSpec(ctx context.Context, s appSpec, req *worker0.UpdateAppReq) (appSpec, error) {
+	s = s.clone()
+	if len(req.Name) != 0 || len(req.Version) != 0 {
+		if len(req.Name) == 0 || len(req.Version) == 0 {
+			return s, errors.Errorf("fail to get name [%s] or version [%s] info", req.Name, req.Version)
+		}
+		pkg, err := core.PackageInfo(ctx, req.Name, req.Version)
+		if err != nil {
+			return s, errors.Wrapf(err, "get package info")
+		}
+		s.Name = fmt.Sprintf("%s:%s", req.Name, req.Version)
+		s.InstallationPackage = pkg.Binary.Download
+		s.PackageMd5 = pkg.Binary.CheckSum
+		s.PackageHandleShells = pkg.Binary.PackageHandleShells
+		s.StartCMD = pkg.Binary.StartCommands
+	}
+	for _, e := range req.Envs {
+		if len(e.Key) == 0 {
+			return s, errors.New("env got empty key")
+		}
+		if len(e.Value) == 0 {
+			delete(s.Environments, e.Key)
+			continue
+		}
+		s.Environments[e.Key] = e.Value
+	}
+	if len(req.FileMounts) != 0 {
+		s.FileMounts = make(map[string]FileMount)
+		for _, m := range req.FileMounts {
+			if len(m.File) == 0 {
+				return s, errors.New("file of mount is empty")
+			}
+			key := md5.MD5(fmt.Sprintf("%s:%s:%s", m.File, m.MountTo, m.Volume))
+			s.FileMounts[key] = FileMount{File: m.File, MountTo: m.MountTo, Volume: m.Volume}
+		}
+	}
+	return s, nil
+}
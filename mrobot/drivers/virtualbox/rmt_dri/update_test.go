@@ -0,0 +1,47 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rmt_dri
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"testing"
+)
+
+func TestUpdateSpec(t *testing.T) {
+	app := &App{
+		Name:         "peer:1.0",
+		StartCMD:     []string{"./peer"},
+		Environments: map[string]string{"A": "1", "B": "2"},
+		FileMounts:   map[string]FileMount{"k": {File: "a", MountTo: "b"}},
+	}
+	old := app.spec()
+	next, err := updateSpec(context.Background(), old, &worker0.UpdateAppReq{
+		Envs:       []*worker0.App_EnvVar{{Key: "A", Value: ""}, {Key: "C", Value: "3"}},
+		FileMounts: []*worker0.App_FileMount{{File: "c", MountTo: "d"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"B": "2", "C": "3"}, next.Environments)
+	assert.Len(t, next.FileMounts, 1)
+	assert.Equal(t, "peer:1.0", next.Name)
+	// snapshot is not changed by update
+	assert.Equal(t, map[string]string{"A": "1", "B": "2"}, old.Environments)
+
+	_, err = updateSpec(context.Background(), old, &worker0.UpdateAppReq{Name: "peer"})
+	assert.NotNil(t, err)
+}
@@ -18,6 +18,8 @@ package rmt_dri
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	v "github.com/go-playground/validator/v10"
@@ -31,12 +33,13 @@ import (
 	"github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/core"
 	agfw "github.com/zibuyu28/cmapp/mrobot/pkg/agentfw/worker"
 	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"io"
+	"io/ioutil"
 	"net"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 )
 
 type VirtualboxWorker struct {
@@ -47,6 +50,9 @@ type VirtualboxWorker struct {
 	HostPassword string
 	StorePath    string
 	VBUUID       string
+
+	// vm config of vm which agent running in, read once when agent starts
+	vm *virtualbox.VM
 }
 
 func NewVirtualboxWorker() *VirtualboxWorker {
@@ -78,6 +84,11 @@ func NewVirtualboxWorker() *VirtualboxWorker {
 	if err != nil {
 		panic(err)
 	}
+	ctx := context.Background()
+	w.vm, err = w.vmInfo(ctx)
+	if err != nil {
+		log.Warnf(ctx, "Currently fail to get vm config, use stats of /proc instead. Err: [%v]", err)
+	}
 	return w
 }
 func (v *VirtualboxWorker) NewApp(ctx context.Context, req *worker0.NewAppReq) (*worker0.App, error) {
@@ -104,10 +115,11 @@ func (v *VirtualboxWorker) NewApp(ctx context.Context, req *worker0.NewAppReq) (
 		StartCMD:            pkg.Binary.StartCommands,
 		Tags:                map[string]string{"uuid": uid, "machine_id": fmt.Sprintf("%d", v.MachineID)},
 
-		Environments: make(map[string]string),
-		FilePremises: make(map[string]FilePremise),
-		FileMounts:   make(map[string]FileMount),
-		Ports:        make(map[int]PortInfo),
+		Environments:  make(map[string]string),
+		FilePremises:  make(map[string]FilePremise),
+		FileMounts:    make(map[string]FileMount),
+		Ports:         make(map[int]PortInfo),
+		ReservedPorts: make(map[int]int),
 	}
 	err = repo.new(ctx, app)
 	if err != nil {
@@ -134,12 +146,19 @@ func (v *VirtualboxWorker) GetHostVolume(ctx context.Context, _ *worker0.App) er
 	panic("implement me")
 }
 
-func (v *VirtualboxWorker) StartApp(ctx context.Context, _ *worker0.App) (*worker0.Empty, error) {
+func (v *VirtualboxWorker) StartApp(ctx context.Context, wa *worker0.App) (*worker0.Empty, error) {
 	log.Debug(ctx, "Currently to start app")
 	app, err := repo.load(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "fail to load app from repo")
 	}
+	if wa != nil && wa.DryRun {
+		err = validateApp(app)
+		if err != nil {
+			return nil, errors.Wrap(err, "dry run app")
+		}
+		return &worker0.Empty{}, nil
+	}
 	marshal, _ := json.Marshal(app)
 	log.Infof(ctx, "app info [%s]", string(marshal))
 
@@ -157,21 +176,9 @@ func (v *VirtualboxWorker) StartApp(ctx context.Context, _ *worker0.App) (*worke
 		}
 	}
 
-	split := strings.Split(app.InstallationPackage, "/")
-	fileName := split[len(split)-1]
-	packageFile := filepath.Join(abs, fileName)
-	err = httputil.HTTPDoDownloadFile(packageFile, app.InstallationPackage)
+	err = installPackage(ctx, app, abs, "")
 	if err != nil {
-		return nil, errors.Wrap(err, "download package")
-	}
-
-	// do package handle shell
-	for _, shell := range app.PackageHandleShells {
-		out, err := cmd.NewDefaultCMD(shell, []string{}, cmd.WithWorkDir(abs)).Run()
-		if err != nil {
-			return nil, errors.Wrapf(err, "exec package handle shell [%s], Err: [%v]", shell, err)
-		}
-		log.Debugf(ctx, "Currently execute shell [%s] success, out [%s]", shell, out)
+		return nil, err
 	}
 
 	log.Debug(ctx, "Currently start exec file premise")
@@ -182,6 +189,22 @@ func (v *VirtualboxWorker) StartApp(ctx context.Context, _ *worker0.App) (*worke
 		if err != nil {
 			return nil, errors.Wrapf(err, "download premise [%s]", premise.Name)
 		}
+		err = verifyFile(premiseFile, premise.CheckSum)
+		if err != nil {
+			return nil, errors.Wrapf(err, "verify premise [%s]", premise.Name)
+		}
+		if premise.Sensitive {
+			err = os.Chmod(premiseFile, 0600)
+			if err != nil {
+				return nil, errors.Wrapf(err, "chmod sensitive premise [%s]", premise.Name)
+			}
+		}
+		if len(premise.ExtractTo) != 0 {
+			err = extractFile(abs, premise)
+			if err != nil {
+				return nil, err
+			}
+		}
 		out, err := cmd.NewDefaultCMD(premise.Shell, []string{}, cmd.WithWorkDir(abs)).Run()
 		if err != nil {
 			return nil, errors.Wrapf(err, "exec premise shell [%s], Err: [%v]", premise.Shell, err)
@@ -201,92 +224,79 @@ func (v *VirtualboxWorker) StartApp(ctx context.Context, _ *worker0.App) (*worke
 	//	mount.Volume
 	//}
 
-	// tag handle: set tag to process environment -> check same name
-	var processEnvs = make(map[string]string)
-	for t, v1 := range app.Tags {
-		processEnvs[t] = v1
-	}
+	// limit: process's cpu and memory are limited by cgroup v2 when app.Limit set, see launch
 
-	// env handle
-	for k, v2 := range app.Environments {
-		if old, ok := processEnvs[k]; ok {
-			log.Warnf(ctx, "Currently set env got same key [%s]. Now to cover old [%s], new [%s]. Please know this", k, old, v2)
-		}
-		processEnvs[k] = v2
+	// start app
+	log.Debug(ctx, "Currently start to setup app")
+	launch(ctx, app, abs)
+
+	// health: check this app is setup success or not, after that
+	//       : health monitor started by launch keeps checking it
+	err = waitReady(ctx, app)
+	if err != nil {
+		return nil, err
 	}
+	return &worker0.Empty{}, nil
+}
 
-	// TODO: limit process's cpu and memory in the same way like docker
-	// now ignore limit
+// StopApp stop main process of app by signalling its process group
+func (v *VirtualboxWorker) StopApp(ctx context.Context, _ *worker0.App) (*worker0.Empty, error) {
+	log.Debug(ctx, "Currently to stop app")
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	err = stopProcess(ctx, app)
+	if err != nil {
+		return nil, errors.Wrap(err, "stop process")
+	}
+	log.Info(ctx, "stop app success")
+	return &worker0.Empty{}, nil
+}
 
-	// start app
-	log.Debug(ctx, "Currently start to setup app")
-	setupCommand := strings.Join(app.StartCMD, " ")
-	go func() {
-		// TODO: 这里可以将这个 cmdins 进行存储 和 维护
-		defaultCMD := cmd.NewDefaultCMD(setupCommand, []string{}, cmd.WithWorkDir(abs),
-		cmd.WithEnvs(processEnvs), cmd.WithTimeout(-1))
-		_, err = defaultCMD.Run()
+// DestroyApp stop app, remove its port forwarding and workspace unless data is kept, then forget it
+func (v *VirtualboxWorker) DestroyApp(ctx context.Context, wa *worker0.App) (*worker0.Empty, error) {
+	log.Debug(ctx, "Currently to destroy app")
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	err = stopProcess(ctx, app)
+	if err != nil {
+		return nil, errors.Wrap(err, "stop process")
+	}
+	err = removeCgroup(app.UID)
+	if err != nil {
+		return nil, err
+	}
+	if len(app.Ports) != 0 {
+		cli, err := ssh_cmd.NewSSHCli(v.HostIP, v.HostPort, v.HostUsername, v.HostPassword)
 		if err != nil {
-			log.Errorf(ctx, "Currently exec setup command failed [%s], Err: [%v]", setupCommand, err)
-			return
+			return nil, errors.Wrap(err, "new ssh cli")
 		}
-		log.Debugf(ctx, "Currently setup app success")
-	}()
-
-	// health: check this app is setup success or not
-	//       : by the way, the worker need to provided
-	//       : app live status checking-engine (timed
-	//       : check app running status, and recovery
-	//       : app if not health)
-
-	// now just to check 10 times
-
-	if app.Health.Readness != nil {
-		readnessUrl := fmt.Sprintf("http://127.0.0.1:%d%s", app.Health.Readness.Port, app.Health.Readness.Path)
-		log.Debugf(ctx, "Currently read url [%s]", readnessUrl)
-
-		// 检查1分钟
-		toutctx, cancelFunc := context.WithTimeout(ctx, time.Minute)
-		defer cancelFunc()
-		// 间隔1秒检查一次
-		ticker := time.NewTicker(time.Second)
-		for {
-			select {
-			case <-ticker.C:
-				switch app.Health.Readness.Method {
-				case HttpGet:
-					_, err = httputil.HTTPDoGet(readnessUrl)
-					if err != nil {
-						log.Errorf(ctx, "Manage err when check app readness. Now to continue. Err: [%v]", err)
-						continue
-					}
-					return &worker0.Empty{}, nil
-				case HttpPost:
-					_, err = httputil.HTTPDoPost("", readnessUrl)
-					if err != nil {
-						log.Errorf(ctx, "Manage err when check app readness. Now to continue. Err: [%v]", err)
-						continue
-					}
-					return &worker0.Empty{}, nil
-				}
-			case <-toutctx.Done():
-				return nil, errors.New("app readness check timeout")
+		defer cli.Close()
+		vbm := virtualbox.NewRMTDriver(ctx, v.VBUUID, v.StorePath, v.HostIP, cli)
+		for _, p := range app.Ports {
+			err = vbm.UnexportPort(p.Name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unexport port [%d]", p.Port)
 			}
 		}
 	}
-
-	// TODO: get app pid == info
+	abs, _ := filepath.Abs(app.Workspace)
+	if wa != nil && wa.KeepData {
+		log.Infof(ctx, "keep workspace [%s] of app", abs)
+	} else {
+		err = os.RemoveAll(abs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "remove workspace [%s]", abs)
+		}
+	}
+	repo.delete(app.UID)
+	log.Info(ctx, "destroy app success")
 	return &worker0.Empty{}, nil
 }
 
-func (v *VirtualboxWorker) StopApp(ctx context.Context, app *worker0.App) (*worker0.Empty, error) {
-	panic("implement me")
-}
-
-func (v *VirtualboxWorker) DestroyApp(ctx context.Context, app *worker0.App) (*worker0.Empty, error) {
-	panic("implement me")
-}
-
 func (v *VirtualboxWorker) TagEx(ctx context.Context, tag *worker0.App_Tag) (*worker0.App_Tag, error) {
 	log.Debug(ctx, "Currently start to execute set app tag")
 	app, err := repo.load(ctx)
@@ -355,6 +365,14 @@ func (v *VirtualboxWorker) NetworkEx(ctx context.Context, network *worker0.App_N
 	if network.PortInfo.Port == 0 {
 		return nil, errors.New("env got empty port")
 	}
+	if network.DryRun {
+		return nil, errors.New("dry run not support, virtualbox app has no manifest")
+	}
+
+	// port is always exported by nat of host, like node port of k8s
+	if network.ExposeType != worker0.App_Network_NodePort {
+		return nil, errors.Errorf("expose type [%s] not support, virtualbox only support [NodePort]", network.ExposeType)
+	}
 
 	network.PortInfo.ProtocolType = worker0.App_Network_PortInf_TCP
 
@@ -366,7 +384,13 @@ func (v *VirtualboxWorker) NetworkEx(ctx context.Context, network *worker0.App_N
 	vbm := virtualbox.NewRMTDriver(ctx, v.VBUUID, v.StorePath, v.HostIP, cli)
 
 	log.Debugf(ctx, "Currently get enum name [%s]", network.PortInfo.ProtocolType.String())
-	actualPort, err := vbm.ExportPort(network.PortInfo.Name, network.PortInfo.ProtocolType.String(), int(network.PortInfo.Port))
+	var actualPort int
+	if hp, ok := app.ReservedPorts[int(network.PortInfo.Port)]; ok {
+		actualPort = hp
+		err = vbm.ExportPortTo(network.PortInfo.Name, network.PortInfo.ProtocolType.String(), hp, int(network.PortInfo.Port))
+	} else {
+		actualPort, err = vbm.ExportPort(network.PortInfo.Name, network.PortInfo.ProtocolType.String(), int(network.PortInfo.Port))
+	}
 	if err != nil {
 		return nil, errors.Wrapf(err, "export port [%d]", network.PortInfo.Port)
 	}
@@ -398,6 +422,21 @@ func (v *VirtualboxWorker) NetworkEx(ctx context.Context, network *worker0.App_N
 	return network, nil
 }
 
+// appEnvs environments of app process, tags are set to environments too and covered by envs with same name
+func appEnvs(ctx context.Context, app *App) map[string]string {
+	var processEnvs = make(map[string]string)
+	for t, v1 := range app.Tags {
+		processEnvs[t] = v1
+	}
+	for k, v2 := range app.Environments {
+		if old, ok := processEnvs[k]; ok {
+			log.Warnf(ctx, "Currently set env got same key [%s]. Now to cover old [%s], new [%s]. Please know this", k, old, v2)
+		}
+		processEnvs[k] = v2
+	}
+	return processEnvs
+}
+
 // getLocalIP get local ip
 func getLocalIP() (string, error) {
 	addrs, err := net.InterfaceAddrs()
@@ -415,6 +454,90 @@ func getLocalIP() (string, error) {
 	return "", errors.New("Can not find the client ip address!")
 }
 
+// verifyFile compare sha256 of file with the check sum, empty check sum is not verified
+func verifyFile(file, sum string) error {
+	if len(sum) == 0 {
+		return nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return errors.Wrapf(err, "open file [%s]", file)
+	}
+	defer f.Close()
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return errors.Wrapf(err, "read file [%s]", file)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, sum) {
+		return errors.Errorf("check sum [%s] not match [%s]", got, sum)
+	}
+	return nil
+}
+
+// validateApp check app is able to start, nothing is done in vm
+func validateApp(app *App) error {
+	validate := v.New()
+	err := validate.Struct(app)
+	if err != nil {
+		return errors.Wrap(err, "validate app")
+	}
+	if len(app.InstallationPackage) == 0 {
+		return errors.New("installation package of app is empty")
+	}
+	if len(app.StartCMD) == 0 {
+		return errors.New("start command of app is empty")
+	}
+	for _, p := range app.Ports {
+		err = validate.Struct(p)
+		if err != nil {
+			return errors.Wrapf(err, "validate port [%d]", p.Port)
+		}
+	}
+	for _, m := range app.FileMounts {
+		err = validate.Struct(m)
+		if err != nil {
+			return errors.Wrapf(err, "validate mount of file [%s]", m.File)
+		}
+	}
+	return nil
+}
+
+// extractFile extract the tar.gz premise into its dir under workspace, extracted files are
+// only readable by owner if the premise is sensitive
+func extractFile(abs string, premise FilePremise) error {
+	f, err := os.Open(filepath.Join(abs, premise.Name))
+	if err != nil {
+		return errors.Wrapf(err, "open premise [%s]", premise.Name)
+	}
+	defer f.Close()
+	files, err := agfw.ExtractTarGz(f, premise.StripComponents, 0)
+	if err != nil {
+		return errors.Wrapf(err, "extract premise [%s]", premise.Name)
+	}
+	dir := agfw.WorkspacePath(abs, premise.ExtractTo)
+	for _, file := range files {
+		p := filepath.Join(dir, filepath.FromSlash(file.Path))
+		err = os.MkdirAll(filepath.Dir(p), os.ModePerm)
+		if err != nil {
+			return errors.Wrapf(err, "mkdir for file [%s]", p)
+		}
+		mode := file.Mode
+		if premise.Sensitive {
+			mode = 0600
+		}
+		err = ioutil.WriteFile(p, file.Content, mode)
+		if err != nil {
+			return errors.Wrapf(err, "write file [%s]", p)
+		}
+		err = os.Chmod(p, mode)
+		if err != nil {
+			return errors.Wrapf(err, "chmod file [%s]", p)
+		}
+	}
+	return nil
+}
+
 func (v *VirtualboxWorker) FilePremiseEx(ctx context.Context, file *worker0.App_File) (*worker0.App_File, error) {
 
 	log.Debug(ctx, "Currently start to execute set file premise")
@@ -432,9 +555,13 @@ func (v *VirtualboxWorker) FilePremiseEx(ctx context.Context, file *worker0.App_
 		return nil, errors.Errorf("file premise exist [%#+v]", e)
 	}
 	premise := FilePremise{
-		Name:        file.Name,
-		AcquireAddr: file.AcquireAddr,
-		Shell:       file.Shell,
+		Name:            file.Name,
+		AcquireAddr:     file.AcquireAddr,
+		Shell:           file.Shell,
+		Sensitive:       file.Sensitive,
+		CheckSum:        file.CheckSum,
+		ExtractTo:       file.ExtractTo,
+		StripComponents: int(file.StripComponents),
 	}
 	app.FilePremises[key] = premise
 	return file, nil
@@ -456,6 +583,14 @@ func (v *VirtualboxWorker) LimitEx(ctx context.Context, limit *worker0.App_Limit
 		lm.Memory = int(limit.Memory)
 	}
 	app.Limit = lm
+	// limit running process at once
+	if path := app.proc.cgroupPath(); len(path) != 0 {
+		err = applyLimit(path, lm)
+		if err != nil {
+			return nil, errors.Wrap(err, "apply limit to cgroup")
+		}
+		log.Debugf(ctx, "Currently apply limit cpu [%d]m memory [%d]MB to cgroup [%s]", lm.CPU, lm.Memory, path)
+	}
 	return limit, nil
 }
 
@@ -466,43 +601,28 @@ func (v *VirtualboxWorker) HealthEx(ctx context.Context, health *worker0.App_Hea
 		return nil, errors.Wrap(err, "fail to load app from repo")
 	}
 	var healthOpt HealthOption
-	if health.Readness != nil {
+	if !emptyProbe(health.Readness) {
 		log.Debugf(ctx, "Currently get read ness health info [%#+v]", health.Readness)
-		read := &HealthBasic{
-			Path: health.Readness.Path,
-			Port: int(health.Readness.Port),
-		}
-		switch health.Readness.MethodType {
-		case worker0.App_Health_Basic_GET:
-			read.Method = HttpGet
-		case worker0.App_Health_Basic_POST:
-			read.Method = HttpPost
-		default:
-			return nil, errors.Wrapf(err, "fail to parse method [%s]", health.Readness.MethodType)
+		healthOpt.Readness, err = probeOpt(health.Readness)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse readness")
 		}
-		healthOpt.Readness = read
 	}
-	if health.Liveness != nil {
+	if !emptyProbe(health.Liveness) {
 		log.Debugf(ctx, "Currently get live ness health info [%#+v]", health.Liveness)
-		live := HealthBasic{
-			Path: health.Liveness.Path,
-			Port: int(health.Liveness.Port),
+		healthOpt.Liveness, err = probeOpt(health.Liveness)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse liveness")
 		}
-		switch health.Liveness.MethodType {
-		case worker0.App_Health_Basic_GET:
-			live.Method = HttpGet
-		case worker0.App_Health_Basic_POST:
-			live.Method = HttpPost
-		default:
-			return nil, errors.Wrapf(err, "fail to parse method [%s]", health.Liveness.MethodType)
+		if healthOpt.Liveness.SuccessThreshold > 1 {
+			return nil, errors.Errorf("success threshold [%d] of liveness must be 1", healthOpt.Liveness.SuccessThreshold)
 		}
-		healthOpt.Liveness = &live
 	}
 	if healthOpt.Readness == nil && healthOpt.Liveness == nil {
 		log.Infof(ctx, "not set health info")
 		return nil, nil
 	}
-	app.Health = &healthOpt
+	app.setHealthOption(&healthOpt)
 	return health, nil
 }
 
@@ -520,3 +640,30 @@ func (v *VirtualboxWorker) LogEx(ctx context.Context, appLog *worker0.App_Log) (
 
 	return appLog, nil
 }
+
+// RestartEx set restart policy of main process, it takes effect on next start
+func (v *VirtualboxWorker) RestartEx(ctx context.Context, restart *worker0.App_Restart) (*worker0.App_Restart, error) {
+	log.Debug(ctx, "Currently start to execute set restart policy")
+	app, err := repo.load(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fail to load app from repo")
+	}
+	if restart.Backoff < 0 {
+		return nil, errors.Errorf("backoff [%d] of restart not correct", restart.Backoff)
+	}
+	app.Restart = &Restart{Policy: RestartPolicy(restart.PolicyType.String()), Backoff: int(restart.Backoff)}
+	return restart, nil
+}
+
+// VolumeEx data of app is kept in its workspace on the vm, claiming volume is not supported
+func (v *VirtualboxWorker) VolumeEx(ctx context.Context, vol *worker0.App_Volume) (*worker0.App_Volume, error) {
+	log.Debug(ctx, "Currently start to execute set volume")
+	return nil, errors.Errorf("volume [%s] not support, virtualbox app uses its workspace", vol.Name)
+}
+
+// ScaleApp virtualbox app is run by single process on its vm, which owns the ports and
+// workspace of app, so scaling is not supported
+func (v *VirtualboxWorker) ScaleApp(ctx context.Context, req *worker0.ScaleReq) (*worker0.App, error) {
+	log.Debug(ctx, "Currently start to execute scale app")
+	return nil, errors.Errorf("scale app to [%d] replicas not support, virtualbox app runs a single process", req.Replicas)
+}
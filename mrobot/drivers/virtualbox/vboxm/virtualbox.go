@@ -1041,15 +1041,51 @@ func (d *Driver) ExportPort(name, protocol string, targetPort int) (int, error)
 		log.Debugf(d.ctx, "NAT forwarding host port for guest port %d (%s) changed from %d to %d",
 			targetPort, name, 30001, actualHostPort)
 	}
-	cmd := fmt.Sprintf("natpf%d", 1)
-	d.vbm("controlvm", d.MachineName, cmd, "delete", name)
-	if err := d.vbm("controlvm", d.MachineName,
-		cmd, fmt.Sprintf("%s,%s,0.0.0.0,%d,,%d", name, protocol, actualHostPort, targetPort)); err != nil {
+	err = d.ExportPortTo(name, protocol, actualHostPort, targetPort)
+	if err != nil {
 		return -1, err
 	}
 	return actualHostPort, nil
 }
 
+// ExportPortTo export target port to the given host port
+func (d *Driver) ExportPortTo(name, protocol string, hostPort, targetPort int) error {
+	cmd := fmt.Sprintf("natpf%d", 1)
+	d.vbm("controlvm", d.MachineName, cmd, "delete", name)
+	return d.vbm("controlvm", d.MachineName,
+		cmd, fmt.Sprintf("%s,%s,0.0.0.0,%d,,%d", name, protocol, hostPort, targetPort))
+}
+
+// UnexportPort remove port forwarding by name
+func (d *Driver) UnexportPort(name string) error {
+	return d.vbm("controlvm", d.MachineName, fmt.Sprintf("natpf%d", 1), "delete", name)
+}
+
+// RmtTCPPortAvailable check whether port of remote host is not in use
+func RmtTCPPortAvailable(rmtHost string, port int) bool {
+	cn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", rmtHost, port), 3*time.Second)
+	if cn != nil {
+		_ = cn.Close()
+	}
+	return err != nil && strings.Contains(err.Error(), "connection refused")
+}
+
+// RmtAvailableTCPPort pick a port of remote host which is not in use and not excluded
+func RmtAvailableTCPPort(ctx context.Context, rmtHost string, exclude map[int]struct{}) (int, error) {
+	rand.Seed(time.Now().UnixNano())
+	for i := 0; i < 20; i++ {
+		p := randInt(30001, 65534)
+		if _, ok := exclude[p]; ok {
+			continue
+		}
+		if RmtTCPPortAvailable(rmtHost, p) {
+			log.Infof(ctx, "remote host [%s] port [%d] not in use", rmtHost, p)
+			return p, nil
+		}
+	}
+	return -1, fmt.Errorf("unable to allocate tcp port")
+}
+
 // rmtSetPortForwarding Setup a NAT port forwarding entry.
 func rmtSetPortForwarding(d *Driver, interfaceNum int, mapName, protocol string, guestPort int) (int, error) {
 	actualHostPort, err := getRmtAvailableTCPPort(d.ctx, d.rmtHost)
@@ -39,3 +39,8 @@ func getVMInfo(name string, vbox VBoxManager) (*VM, error) {
 
 	return vm, nil
 }
+
+// VMInfo get cpus and memory(MB) config of vm
+func (d *Driver) VMInfo() (*VM, error) {
+	return getVMInfo(d.MachineName, d.VBoxManager)
+}
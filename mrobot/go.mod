@@ -5,6 +5,7 @@ go 1.15
 require (
 	github.com/agiledragon/gomonkey v2.0.1+incompatible
 	github.com/bramvdbogaerde/go-scp v1.1.0
+	github.com/creack/pty v1.1.17
 	github.com/go-playground/validator/v10 v10.4.1
 	github.com/google/uuid v1.2.0
 	github.com/intel-go/cpuid v0.0.0-20210602155658-5747e5cec0d9
@@ -21,6 +22,7 @@ require (
 	k8s.io/api v0.21.2
 	k8s.io/apimachinery v0.21.2
 	k8s.io/client-go v0.21.2
+	k8s.io/utils v0.0.0-20201110183641-67b214c5f920
 	sigs.k8s.io/yaml v1.2.0
 )
 
@@ -37,6 +37,7 @@ const (
 	MachineEngineCoreGRPCPORT  = "MACHINE_ENGINE_CORE_GRPC_PORT"
 	MachineEngineCoreGRPCAddr  = "MACHINE_ENGINE_CORE_GRPC_ADDR"
 	MachineEngineCoreHttpAddr = "MACHINE_ENGINE_CORE_HTTP_ADDR"
+	MachineEngineCoreAgentToken = "MACHINE_ENGINE_CORE_AGENT_TOKEN"
 	MachineEngineDriverName    = "MACHINE_ENGINE_DRIVER_NAME"
 	MachineEngineDriverID      = "MACHINE_ENGINE_DRIVER_ID"
 	MachineEngineDriverVersion = "MACHINE_ENGINE_DRIVER_VERSION"
@@ -46,6 +47,7 @@ const (
 	BaseCoreHTTPAddr = "CoreHTTPAddr"
 	BaseCoreGRPCAddr = "CoreGRPCAddr"
 	BaseCoreAddr = "CoreAddr"
+	BaseCoreAgentToken = "CoreAgentToken"
 	BaseRepository = "Repository"
 	BaseStorePath = "StorePath"
 )
@@ -54,7 +56,7 @@ const (
 func CreateMachine(ctx context.Context, uuid, param string) error {
 	ctx, cancelFunc := context.WithCancel(ctx)
 	defer cancelFunc()
-	log.Debugf(ctx, "Currently create machine logic, uuid [%v]", uuid)
+	log.Debug(ctx, "Currently create machine logic")
 
 	driverName := os.Getenv(MachineEngineDriverName)
 	if len(driverName) == 0 {
@@ -88,8 +90,11 @@ func CreateMachine(ctx context.Context, uuid, param string) error {
 	}
 	log.Debugf(ctx, "get core http addr [%s]", httpAddr)
 
+	// core writes machine uuid and job id of the reports to its log
 	ctx = metadata.NewOutgoingContext(ctx, metadata.New(map[string]string{
-		"UUID": uuid,
+		"UUID":               uuid,
+		log.FieldMachineUUID: uuid,
+		log.FieldJobID:       log.FieldValue(ctx, log.FieldJobID),
 	}))
 
 	var meIns driver.MachineDriverClient
@@ -111,6 +116,8 @@ func CreateMachine(ctx context.Context, uuid, param string) error {
 	p[BaseCoreHTTPAddr] = httpAddr
 	p[BaseCoreGRPCAddr] = grpcAddr
 	p[BaseCoreAddr] = httpAddr
+	// agent token is optional, api only for agents is disabled in core without it
+	p[BaseCoreAgentToken] = os.Getenv(MachineEngineCoreAgentToken)
 	p[BaseRepository] = "" // TODO: check need
 	p[BaseStorePath] = "" // TODO: check need
 
@@ -24,18 +24,21 @@ import (
 	"github.com/zibuyu28/cmapp/common/httputil"
 	"github.com/zibuyu28/cmapp/common/log"
 	"k8s.io/apimachinery/pkg/util/json"
+	"net/url"
 	"os"
 	"strings"
 )
 
 const (
-	driAgentCoreHTTPAddr string = "CORE_HTTP_ADDR"
-	driverPrefix         string = "DRIAGENT_"
+	driAgentCoreHTTPAddr   string = "CORE_HTTP_ADDR"
+	driAgentCoreAgentToken string = "CORE_AGENT_TOKEN"
+	driverPrefix           string = "DRIAGENT_"
 )
 
 var cli *client
 
 func init() {
+	var c client
 	environ := os.Environ()
 	for _, s := range environ {
 		if strings.HasPrefix(s, driverPrefix) {
@@ -43,16 +46,22 @@ func init() {
 			if len(kvs) != 2 {
 				continue
 			}
-			if kvs[0] == driAgentCoreHTTPAddr {
-				cli = &client{coreAddr: kvs[1]}
-				break
+			switch kvs[0] {
+			case driAgentCoreHTTPAddr:
+				c.coreAddr = kvs[1]
+			case driAgentCoreAgentToken:
+				c.agentToken = kvs[1]
 			}
 		}
 	}
+	if len(c.coreAddr) != 0 {
+		cli = &c
+	}
 }
 
 type client struct {
-	coreAddr string
+	coreAddr   string
+	agentToken string
 }
 
 // Package package info
@@ -64,6 +73,7 @@ type Package struct {
 		Tag           string   `json:"tag"`
 		WorkDir       string   `json:"work_dir"`
 		StartCommands []string `json:"start_command"`
+		PullPolicy    string   `json:"pull_policy"`
 	}
 	Binary struct {
 		Download            string   `json:"download"`
@@ -107,3 +117,51 @@ func PackageInfo(ctx context.Context, name, version string) (*Package, error) {
 	}
 	return &p, nil
 }
+
+// Credential credential of image registry
+type Credential struct {
+	Server   string `json:"server"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email"`
+}
+
+// RegistryCredential get credential of registry which image belongs to from core, nil is
+// returned if the registry is not registered
+func RegistryCredential(ctx context.Context, image string) (*Credential, error) {
+	if cli == nil {
+		return nil, errors.New("core client is nil")
+	}
+	return RegistryCredentialByAddr(ctx, cli.coreAddr, cli.agentToken, image)
+}
+
+// RegistryCredentialByAddr get credential of registry from core of the address, it is used
+// by driver which runs out of agent. Core only returns credential to agent with agent token,
+// request without token still gets nil for image of unregistered registry, which is pulled
+// anonymously
+func RegistryCredentialByAddr(ctx context.Context, coreAddr, agentToken, image string) (*Credential, error) {
+	credentialUrl := fmt.Sprintf("%s/api/v1/registry/credential?image=%s", coreAddr, url.QueryEscape(image))
+	log.Debugf(ctx, "registry credential get url [%s]", credentialUrl)
+
+	header := map[string]string{}
+	if len(agentToken) != 0 {
+		header["Authorization"] = "Bearer " + agentToken
+	}
+	resp, err := httputil.HTTPDoGetWithHeader(credentialUrl, header)
+	if err != nil {
+		return nil, errors.Wrap(err, "http do get")
+	}
+	var res = struct {
+		Code    int         `json:"code"`
+		Message string      `json:"message"`
+		Data    *Credential `json:"data"`
+	}{}
+	err = json.Unmarshal(resp, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal resp to credential")
+	}
+	if res.Code != 200 {
+		return nil, errors.New(res.Message)
+	}
+	return res.Data, nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+)
+
+// FileChunkSize max size of data in one file chunk
+const FileChunkSize = 64 << 10
+
+// WorkspacePath join the path to workspace root, path like '../' can not escape the root
+func WorkspacePath(root, p string) string {
+	return path.Join(root, path.Clean("/"+p))
+}
+
+// ChunkWriter split written data into file chunks and send them
+type ChunkWriter struct {
+	Send func(*worker0.FileChunk) error
+}
+
+func (w *ChunkWriter) Write(p []byte) (int, error) {
+	for i := 0; i < len(p); i += FileChunkSize {
+		end := i + FileChunkSize
+		if end > len(p) {
+			end = len(p)
+		}
+		err := w.Send(&worker0.FileChunk{Data: append([]byte{}, p[i:end]...)})
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(p), nil
+}
+
+// ArchiveFile regular file extracted from archive
+type ArchiveFile struct {
+	// Path relative path of file after leading components are stripped
+	Path    string
+	Mode    os.FileMode
+	Content []byte
+}
+
+// ExtractTarGz read regular files from tar.gz stream, leading components of their path are
+// stripped like tar does and entries left with empty path are skipped. entry with absolute
+// path or '..' is rejected, limit is max total size of files, not limited if it is not positive
+func ExtractTarGz(r io.Reader, strip int, limit int64) ([]ArchiveFile, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "open gzip")
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+	var files []ArchiveFile
+	var total int64
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "read tar")
+		}
+		if path.IsAbs(hdr.Name) {
+			return nil, errors.Errorf("entry [%s] has absolute path", hdr.Name)
+		}
+		var parts []string
+		for _, p := range strings.Split(hdr.Name, "/") {
+			if p == ".." {
+				return nil, errors.Errorf("entry [%s] escapes the dir", hdr.Name)
+			}
+			if len(p) != 0 && p != "." {
+				parts = append(parts, p)
+			}
+		}
+		if !hdr.FileInfo().Mode().IsRegular() || len(parts) <= strip {
+			continue
+		}
+		total += hdr.Size
+		if limit > 0 && total > limit {
+			return nil, errors.Errorf("files in archive are larger than [%d] bytes", limit)
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read entry [%s]", hdr.Name)
+		}
+		files = append(files, ArchiveFile{Path: path.Join(parts[strip:]...), Mode: hdr.FileInfo().Mode().Perm(), Content: content})
+	}
+}
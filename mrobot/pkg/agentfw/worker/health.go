@@ -0,0 +1,88 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"github.com/zibuyu28/cmapp/common/log"
+	coreproto "github.com/zibuyu28/cmapp/core/proto/ma_manager"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strconv"
+	"time"
+)
+
+// AppHealth health transition of app
+type AppHealth struct {
+	AppUUID string
+	// Tags tags of app, core find node of app by them
+	Tags    map[string]string
+	Healthy bool
+	Message string
+}
+
+// HealthNotifier worker which implement it will push health transitions of apps to core
+type HealthNotifier interface {
+	HealthEvents() <-chan AppHealth
+}
+
+// reportHealth push health transitions to core until ctx done,
+// skipped if worker not implement HealthNotifier or core grpc address is not set
+func reportHealth(ctx context.Context) {
+	hn, ok := workerServer.(HealthNotifier)
+	if !ok {
+		log.Debug(ctx, "Currently worker not implement health notifier, skip health report")
+		return
+	}
+	addr := Flags["CORE_GRPC_ADDR"].Value
+	mid, err := strconv.Atoi(Flags["MACHINE_ID"].Value)
+	if len(addr) == 0 || err != nil {
+		log.Warnf(ctx, "Currently core grpc addr [%s] or machine id [%s] not set, skip health report", addr, Flags["MACHINE_ID"].Value)
+		return
+	}
+	ctx = log.WithMachineID(ctx, Flags["MACHINE_ID"].Value)
+	// core writes machine id of the reports to its log
+	ctx = metadata.AppendToOutgoingContext(ctx, log.FieldMachineID, Flags["MACHINE_ID"].Value)
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure())
+	if err != nil {
+		log.Errorf(ctx, "Manage err when dial core grpc [%s]. Err: [%v]", addr, err)
+		return
+	}
+	defer conn.Close()
+	cli := coreproto.NewMachineManageClient(conn)
+
+	events := hn.HealthEvents()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case h := <-events:
+			tctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			_, err = cli.ReportAppHealth(tctx, &coreproto.AppHealth{
+				MachineID: int32(mid),
+				AppUUID:   h.AppUUID,
+				Tags:      h.Tags,
+				Healthy:   h.Healthy,
+				Message:   h.Message,
+			})
+			cancel()
+			if err != nil {
+				log.Errorf(log.WithAppUUID(ctx, h.AppUUID), "Manage err when report health of app. Err: [%v]", err)
+			}
+		}
+	}
+}
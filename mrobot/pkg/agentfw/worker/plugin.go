@@ -20,6 +20,7 @@ import (
 	"github.com/zibuyu28/cmapp/common/log"
 	"github.com/zibuyu28/cmapp/plugin/proto/worker0"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"net"
 	"os"
 )
@@ -46,7 +47,7 @@ func pluginIns(ctx context.Context) (*plugin, error) {
 		log.Fatalf(ctx, "Error loading plugin RPC server. Err: [%v], stdErr: [%s]", err, os.Stderr)
 	}
 
-	grpcserver := grpc.NewServer()
+	grpcserver := grpc.NewServer(grpc.UnaryInterceptor(logInterceptor), grpc.StreamInterceptor(logStreamInterceptor))
 
 	worker0.RegisterWorker0Server(grpcserver, workerServer)
 
@@ -65,3 +66,47 @@ func pluginIns(ctx context.Context) (*plugin, error) {
 	return &plugin{}, nil
 
 }
+
+// logContext set request id and app uuid in metadata, and machine id of agent to the log context
+func logContext(ctx context.Context) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(log.FieldRequestID); len(v) != 0 {
+			ctx = log.WithRequestID(ctx, v[0])
+		}
+		if v := md.Get("MA_UUID"); len(v) != 0 {
+			ctx = log.WithAppUUID(ctx, v[0])
+		}
+	}
+	if mid := Flags["MACHINE_ID"].Value; len(mid) != 0 {
+		ctx = log.WithMachineID(ctx, mid)
+	}
+	return ctx
+}
+
+// logInterceptor log context for unary request
+func logInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx = logContext(ctx)
+	resp, err := handler(ctx, req)
+	if err != nil {
+		log.Errorf(ctx, "Manage err when handle grpc request [%s]. Err: [%v]", info.FullMethod, err)
+	}
+	return resp, err
+}
+
+type logStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *logStream) Context() context.Context {
+	return s.ctx
+}
+
+// logStreamInterceptor log context for stream request
+func logStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, &logStream{ServerStream: ss, ctx: logContext(ss.Context())})
+	if err != nil {
+		log.Errorf(ss.Context(), "Manage err when handle grpc stream [%s]. Err: [%v]", info.FullMethod, err)
+	}
+	return err
+}
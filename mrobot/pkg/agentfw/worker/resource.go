@@ -0,0 +1,107 @@
+/*
+ * Copyright © 2021 zibuyu28
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/zibuyu28/cmapp/common/log"
+	coreproto "github.com/zibuyu28/cmapp/core/proto/ma_manager"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strconv"
+	"time"
+)
+
+var ResourceReportInterval = 30 * time.Second
+
+// Resource resource of machine, cpu unit is 'm', memory and disk unit is 'MB'
+type Resource struct {
+	CPUAllocatable    int
+	MemoryAllocatable int
+	DiskAllocatable   int
+	CPUUsed           int
+	MemoryUsed        int
+	DiskUsed          int
+}
+
+// ResourceReporter worker which implement it will report resource of machine to core periodically
+type ResourceReporter interface {
+	Resource(ctx context.Context) (*Resource, error)
+}
+
+// reportResource report resource to core every ResourceReportInterval until ctx done,
+// skipped if worker not implement ResourceReporter or core grpc address is not set
+func reportResource(ctx context.Context) {
+	rr, ok := workerServer.(ResourceReporter)
+	if !ok {
+		log.Debug(ctx, "Currently worker not implement resource reporter, skip resource report")
+		return
+	}
+	addr := Flags["CORE_GRPC_ADDR"].Value
+	mid, err := strconv.Atoi(Flags["MACHINE_ID"].Value)
+	if len(addr) == 0 || err != nil {
+		log.Warnf(ctx, "Currently core grpc addr [%s] or machine id [%s] not set, skip resource report", addr, Flags["MACHINE_ID"].Value)
+		return
+	}
+	ctx = log.WithMachineID(ctx, Flags["MACHINE_ID"].Value)
+	// core writes machine id of the reports to its log
+	ctx = metadata.AppendToOutgoingContext(ctx, log.FieldMachineID, Flags["MACHINE_ID"].Value)
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure())
+	if err != nil {
+		log.Errorf(ctx, "Manage err when dial core grpc [%s]. Err: [%v]", addr, err)
+		return
+	}
+	defer conn.Close()
+	cli := coreproto.NewMachineManageClient(conn)
+
+	ticker := time.NewTicker(ResourceReportInterval)
+	defer ticker.Stop()
+	for {
+		err = report(ctx, cli, rr, mid)
+		if err != nil {
+			log.Errorf(ctx, "Manage err when report resource of machine. Err: [%v]", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func report(ctx context.Context, cli coreproto.MachineManageClient, rr ResourceReporter, mid int) error {
+	res, err := rr.Resource(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get resource")
+	}
+	tctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err = cli.ReportResource(tctx, &coreproto.MachineResource{
+		MachineID:         int32(mid),
+		CPUAllocatable:    int32(res.CPUAllocatable),
+		MemoryAllocatable: int32(res.MemoryAllocatable),
+		DiskAllocatable:   int32(res.DiskAllocatable),
+		CPUUsed:           int32(res.CPUUsed),
+		MemoryUsed:        int32(res.MemoryUsed),
+		DiskUsed:          int32(res.DiskUsed),
+	})
+	if err != nil {
+		return errors.Wrap(err, "rpc report resource")
+	}
+	return nil
+}
@@ -107,6 +107,8 @@ func Start(ctx context.Context, muxs ...*http.ServeMux) {
 	if err != nil {
 		log.Fatalf(ctx, "Currently fail to new plugin. Err: [%v]", err)
 	}
+	go reportResource(ctx)
+	go reportHealth(ctx)
 
 	// block
 	// signal handler
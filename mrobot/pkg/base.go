@@ -31,6 +31,7 @@ type BaseDriver struct {
 	CoreHTTPAddr    string `validate:"required"`
 	CoreGRPCAddr    string `validate:"required"`
 	CoreAddr        string `validate:"required"`
+	CoreAgentToken  string
 	ImageRepository ImageRepository
 }
 
@@ -59,6 +60,12 @@ func (b *BaseDriver) GetFlags() []*driver.Flag {
 			EnvVar: "BASE_CORE_ADDR",
 			Value:  nil,
 		},
+		{
+			Name:   "CoreAgentToken",
+			Usage:  "token of core api only for agents",
+			EnvVar: "BASE_CORE_AGENT_TOKEN",
+			Value:  nil,
+		},
 		{
 			Name:   "Repository",
 			Usage:  "image repository",
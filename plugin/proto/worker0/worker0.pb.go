@@ -24,6 +24,99 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type UpdateAppReq_Strategy int32
+
+const (
+	UpdateAppReq_Recreate      UpdateAppReq_Strategy = 0
+	UpdateAppReq_RollingUpdate UpdateAppReq_Strategy = 1
+)
+
+// Enum value maps for UpdateAppReq_Strategy.
+var (
+	UpdateAppReq_Strategy_name = map[int32]string{
+		0: "Recreate",
+		1: "RollingUpdate",
+	}
+	UpdateAppReq_Strategy_value = map[string]int32{
+		"Recreate":      0,
+		"RollingUpdate": 1,
+	}
+)
+
+func (x UpdateAppReq_Strategy) Enum() *UpdateAppReq_Strategy {
+	p := new(UpdateAppReq_Strategy)
+	*p = x
+	return p
+}
+
+func (x UpdateAppReq_Strategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UpdateAppReq_Strategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_worker0_proto_enumTypes[0].Descriptor()
+}
+
+func (UpdateAppReq_Strategy) Type() protoreflect.EnumType {
+	return &file_worker0_proto_enumTypes[0]
+}
+
+func (x UpdateAppReq_Strategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UpdateAppReq_Strategy.Descriptor instead.
+func (UpdateAppReq_Strategy) EnumDescriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{1, 0}
+}
+
+// workload kind, k8s only. StatefulSet gives app stable network identity and storage
+type App_Workload int32
+
+const (
+	App_Deployment  App_Workload = 0
+	App_StatefulSet App_Workload = 1
+)
+
+// Enum value maps for App_Workload.
+var (
+	App_Workload_name = map[int32]string{
+		0: "Deployment",
+		1: "StatefulSet",
+	}
+	App_Workload_value = map[string]int32{
+		"Deployment":  0,
+		"StatefulSet": 1,
+	}
+)
+
+func (x App_Workload) Enum() *App_Workload {
+	p := new(App_Workload)
+	*p = x
+	return p
+}
+
+func (x App_Workload) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (App_Workload) Descriptor() protoreflect.EnumDescriptor {
+	return file_worker0_proto_enumTypes[1].Descriptor()
+}
+
+func (App_Workload) Type() protoreflect.EnumType {
+	return &file_worker0_proto_enumTypes[1]
+}
+
+func (x App_Workload) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use App_Workload.Descriptor instead.
+func (App_Workload) EnumDescriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 0}
+}
+
 type App_MainProcess_PType int32
 
 const (
@@ -54,11 +147,11 @@ func (x App_MainProcess_PType) String() string {
 }
 
 func (App_MainProcess_PType) Descriptor() protoreflect.EnumDescriptor {
-	return file_worker0_proto_enumTypes[0].Descriptor()
+	return file_worker0_proto_enumTypes[2].Descriptor()
 }
 
 func (App_MainProcess_PType) Type() protoreflect.EnumType {
-	return &file_worker0_proto_enumTypes[0]
+	return &file_worker0_proto_enumTypes[2]
 }
 
 func (x App_MainProcess_PType) Number() protoreflect.EnumNumber {
@@ -67,7 +160,65 @@ func (x App_MainProcess_PType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use App_MainProcess_PType.Descriptor instead.
 func (App_MainProcess_PType) EnumDescriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 0, 0}
+	return file_worker0_proto_rawDescGZIP(), []int{2, 0, 0}
+}
+
+// how port is exposed out of cluster, k8s only. route OUT carries the external address, absent for ClusterIP
+type App_Network_Expose int32
+
+const (
+	App_Network_NodePort     App_Network_Expose = 0
+	App_Network_ClusterIP    App_Network_Expose = 1
+	App_Network_LoadBalancer App_Network_Expose = 2
+	// http by host '<m-machine>-<app>-<port>.<domain>' on port 80
+	App_Network_Ingress App_Network_Expose = 3
+	// tls passthrough by host like Ingress on port 443, for grpc with tls
+	App_Network_TLSPassthrough App_Network_Expose = 4
+)
+
+// Enum value maps for App_Network_Expose.
+var (
+	App_Network_Expose_name = map[int32]string{
+		0: "NodePort",
+		1: "ClusterIP",
+		2: "LoadBalancer",
+		3: "Ingress",
+		4: "TLSPassthrough",
+	}
+	App_Network_Expose_value = map[string]int32{
+		"NodePort":       0,
+		"ClusterIP":      1,
+		"LoadBalancer":   2,
+		"Ingress":        3,
+		"TLSPassthrough": 4,
+	}
+)
+
+func (x App_Network_Expose) Enum() *App_Network_Expose {
+	p := new(App_Network_Expose)
+	*p = x
+	return p
+}
+
+func (x App_Network_Expose) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (App_Network_Expose) Descriptor() protoreflect.EnumDescriptor {
+	return file_worker0_proto_enumTypes[3].Descriptor()
+}
+
+func (App_Network_Expose) Type() protoreflect.EnumType {
+	return &file_worker0_proto_enumTypes[3]
+}
+
+func (x App_Network_Expose) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use App_Network_Expose.Descriptor instead.
+func (App_Network_Expose) EnumDescriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 3, 0}
 }
 
 type App_Network_PortInf_Protocol int32
@@ -100,11 +251,11 @@ func (x App_Network_PortInf_Protocol) String() string {
 }
 
 func (App_Network_PortInf_Protocol) Descriptor() protoreflect.EnumDescriptor {
-	return file_worker0_proto_enumTypes[1].Descriptor()
+	return file_worker0_proto_enumTypes[4].Descriptor()
 }
 
 func (App_Network_PortInf_Protocol) Type() protoreflect.EnumType {
-	return &file_worker0_proto_enumTypes[1]
+	return &file_worker0_proto_enumTypes[4]
 }
 
 func (x App_Network_PortInf_Protocol) Number() protoreflect.EnumNumber {
@@ -113,7 +264,7 @@ func (x App_Network_PortInf_Protocol) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use App_Network_PortInf_Protocol.Descriptor instead.
 func (App_Network_PortInf_Protocol) EnumDescriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 3, 0, 0}
+	return file_worker0_proto_rawDescGZIP(), []int{2, 3, 0, 0}
 }
 
 type App_Network_RouteInf_Route int32
@@ -146,11 +297,11 @@ func (x App_Network_RouteInf_Route) String() string {
 }
 
 func (App_Network_RouteInf_Route) Descriptor() protoreflect.EnumDescriptor {
-	return file_worker0_proto_enumTypes[2].Descriptor()
+	return file_worker0_proto_enumTypes[5].Descriptor()
 }
 
 func (App_Network_RouteInf_Route) Type() protoreflect.EnumType {
-	return &file_worker0_proto_enumTypes[2]
+	return &file_worker0_proto_enumTypes[5]
 }
 
 func (x App_Network_RouteInf_Route) Number() protoreflect.EnumNumber {
@@ -159,7 +310,7 @@ func (x App_Network_RouteInf_Route) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use App_Network_RouteInf_Route.Descriptor instead.
 func (App_Network_RouteInf_Route) EnumDescriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 3, 1, 0}
+	return file_worker0_proto_rawDescGZIP(), []int{2, 3, 1, 0}
 }
 
 // supported http method
@@ -193,11 +344,11 @@ func (x App_Health_Basic_Method) String() string {
 }
 
 func (App_Health_Basic_Method) Descriptor() protoreflect.EnumDescriptor {
-	return file_worker0_proto_enumTypes[3].Descriptor()
+	return file_worker0_proto_enumTypes[6].Descriptor()
 }
 
 func (App_Health_Basic_Method) Type() protoreflect.EnumType {
-	return &file_worker0_proto_enumTypes[3]
+	return &file_worker0_proto_enumTypes[6]
 }
 
 func (x App_Health_Basic_Method) Number() protoreflect.EnumNumber {
@@ -206,7 +357,218 @@ func (x App_Health_Basic_Method) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use App_Health_Basic_Method.Descriptor instead.
 func (App_Health_Basic_Method) EnumDescriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 7, 0, 0}
+	return file_worker0_proto_rawDescGZIP(), []int{2, 7, 0, 0}
+}
+
+// probe type, GRPC probes by grpc health checking protocol without tls,
+// k8s runs it by 'grpc_health_probe' which must be in the image
+type App_Health_Basic_Type int32
+
+const (
+	App_Health_Basic_HTTP App_Health_Basic_Type = 0
+	App_Health_Basic_TCP  App_Health_Basic_Type = 1
+	App_Health_Basic_EXEC App_Health_Basic_Type = 2
+	App_Health_Basic_GRPC App_Health_Basic_Type = 3
+)
+
+// Enum value maps for App_Health_Basic_Type.
+var (
+	App_Health_Basic_Type_name = map[int32]string{
+		0: "HTTP",
+		1: "TCP",
+		2: "EXEC",
+		3: "GRPC",
+	}
+	App_Health_Basic_Type_value = map[string]int32{
+		"HTTP": 0,
+		"TCP":  1,
+		"EXEC": 2,
+		"GRPC": 3,
+	}
+)
+
+func (x App_Health_Basic_Type) Enum() *App_Health_Basic_Type {
+	p := new(App_Health_Basic_Type)
+	*p = x
+	return p
+}
+
+func (x App_Health_Basic_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (App_Health_Basic_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_worker0_proto_enumTypes[7].Descriptor()
+}
+
+func (App_Health_Basic_Type) Type() protoreflect.EnumType {
+	return &file_worker0_proto_enumTypes[7]
+}
+
+func (x App_Health_Basic_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use App_Health_Basic_Type.Descriptor instead.
+func (App_Health_Basic_Type) EnumDescriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 7, 0, 1}
+}
+
+type App_Restart_Policy int32
+
+const (
+	App_Restart_Always    App_Restart_Policy = 0
+	App_Restart_OnFailure App_Restart_Policy = 1
+	App_Restart_Never     App_Restart_Policy = 2
+)
+
+// Enum value maps for App_Restart_Policy.
+var (
+	App_Restart_Policy_name = map[int32]string{
+		0: "Always",
+		1: "OnFailure",
+		2: "Never",
+	}
+	App_Restart_Policy_value = map[string]int32{
+		"Always":    0,
+		"OnFailure": 1,
+		"Never":     2,
+	}
+)
+
+func (x App_Restart_Policy) Enum() *App_Restart_Policy {
+	p := new(App_Restart_Policy)
+	*p = x
+	return p
+}
+
+func (x App_Restart_Policy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (App_Restart_Policy) Descriptor() protoreflect.EnumDescriptor {
+	return file_worker0_proto_enumTypes[8].Descriptor()
+}
+
+func (App_Restart_Policy) Type() protoreflect.EnumType {
+	return &file_worker0_proto_enumTypes[8]
+}
+
+func (x App_Restart_Policy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use App_Restart_Policy.Descriptor instead.
+func (App_Restart_Policy) EnumDescriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 10, 0}
+}
+
+type App_Volume_AccessMode int32
+
+const (
+	App_Volume_ReadWriteOnce App_Volume_AccessMode = 0
+	App_Volume_ReadWriteMany App_Volume_AccessMode = 1
+	App_Volume_ReadOnlyMany  App_Volume_AccessMode = 2
+)
+
+// Enum value maps for App_Volume_AccessMode.
+var (
+	App_Volume_AccessMode_name = map[int32]string{
+		0: "ReadWriteOnce",
+		1: "ReadWriteMany",
+		2: "ReadOnlyMany",
+	}
+	App_Volume_AccessMode_value = map[string]int32{
+		"ReadWriteOnce": 0,
+		"ReadWriteMany": 1,
+		"ReadOnlyMany":  2,
+	}
+)
+
+func (x App_Volume_AccessMode) Enum() *App_Volume_AccessMode {
+	p := new(App_Volume_AccessMode)
+	*p = x
+	return p
+}
+
+func (x App_Volume_AccessMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (App_Volume_AccessMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_worker0_proto_enumTypes[9].Descriptor()
+}
+
+func (App_Volume_AccessMode) Type() protoreflect.EnumType {
+	return &file_worker0_proto_enumTypes[9]
+}
+
+func (x App_Volume_AccessMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use App_Volume_AccessMode.Descriptor instead.
+func (App_Volume_AccessMode) EnumDescriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 11, 0}
+}
+
+type AppStatus_State int32
+
+const (
+	AppStatus_Unknown AppStatus_State = 0
+	// app created but not started
+	AppStatus_Created  AppStatus_State = 1
+	AppStatus_Starting AppStatus_State = 2
+	AppStatus_Running  AppStatus_State = 3
+	AppStatus_Stopped  AppStatus_State = 4
+	AppStatus_Failed   AppStatus_State = 5
+)
+
+// Enum value maps for AppStatus_State.
+var (
+	AppStatus_State_name = map[int32]string{
+		0: "Unknown",
+		1: "Created",
+		2: "Starting",
+		3: "Running",
+		4: "Stopped",
+		5: "Failed",
+	}
+	AppStatus_State_value = map[string]int32{
+		"Unknown":  0,
+		"Created":  1,
+		"Starting": 2,
+		"Running":  3,
+		"Stopped":  4,
+		"Failed":   5,
+	}
+)
+
+func (x AppStatus_State) Enum() *AppStatus_State {
+	p := new(AppStatus_State)
+	*p = x
+	return p
+}
+
+func (x AppStatus_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AppStatus_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_worker0_proto_enumTypes[10].Descriptor()
+}
+
+func (AppStatus_State) Type() protoreflect.EnumType {
+	return &file_worker0_proto_enumTypes[10]
+}
+
+func (x AppStatus_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AppStatus_State.Descriptor instead.
+func (AppStatus_State) EnumDescriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{6, 0}
 }
 
 type NewAppReq struct {
@@ -216,6 +578,8 @@ type NewAppReq struct {
 
 	Name    string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
 	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// workload kind of app, can not be changed after app created
+	WorkloadType App_Workload `protobuf:"varint,3,opt,name=WorkloadType,proto3,enum=worker0.App_Workload" json:"WorkloadType,omitempty"`
 }
 
 func (x *NewAppReq) Reset() {
@@ -264,26 +628,31 @@ func (x *NewAppReq) GetVersion() string {
 	return ""
 }
 
-type App struct {
+func (x *NewAppReq) GetWorkloadType() App_Workload {
+	if x != nil {
+		return x.WorkloadType
+	}
+	return App_Deployment
+}
+
+type UpdateAppReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UUID            string             `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
-	MainP           *App_MainProcess   `protobuf:"bytes,2,opt,name=MainP,proto3" json:"MainP,omitempty"`
-	FileMounts      []*App_FileMount   `protobuf:"bytes,3,rep,name=FileMounts,proto3" json:"FileMounts,omitempty"`
-	EnvironmentVars []*App_EnvVar      `protobuf:"bytes,4,rep,name=EnvironmentVars,proto3" json:"EnvironmentVars,omitempty"`
-	Networks        []*App_Network     `protobuf:"bytes,5,rep,name=Networks,proto3" json:"Networks,omitempty"`
-	Workspace       *App_WorkspaceInfo `protobuf:"bytes,6,opt,name=Workspace,proto3" json:"Workspace,omitempty"`
-	FilePremise     []*App_File        `protobuf:"bytes,7,rep,name=FilePremise,proto3" json:"FilePremise,omitempty"`
-	LimitInfo       *App_Limit         `protobuf:"bytes,8,opt,name=LimitInfo,proto3" json:"LimitInfo,omitempty"`
-	HealthInfo      *App_Health        `protobuf:"bytes,9,opt,name=HealthInfo,proto3" json:"HealthInfo,omitempty"`
-	LogInfo         *App_Log           `protobuf:"bytes,10,opt,name=LogInfo,proto3" json:"LogInfo,omitempty"`
-	Tags            []*App_Tag         `protobuf:"bytes,11,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	// new package from registry, empty keeps the current package
+	Name    string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=Version,proto3" json:"Version,omitempty"`
+	// envs to set, empty value removes the env
+	Envs []*App_EnvVar `protobuf:"bytes,3,rep,name=Envs,proto3" json:"Envs,omitempty"`
+	// mounts of app after update, empty keeps the current mounts
+	FileMounts []*App_FileMount `protobuf:"bytes,4,rep,name=FileMounts,proto3" json:"FileMounts,omitempty"`
+	// k8s only, virtualbox always stops the old process before starting the new one
+	UpdateStrategy UpdateAppReq_Strategy `protobuf:"varint,5,opt,name=UpdateStrategy,proto3,enum=worker0.UpdateAppReq_Strategy" json:"UpdateStrategy,omitempty"`
 }
 
-func (x *App) Reset() {
-	*x = App{}
+func (x *UpdateAppReq) Reset() {
+	*x = UpdateAppReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -291,13 +660,13 @@ func (x *App) Reset() {
 	}
 }
 
-func (x *App) String() string {
+func (x *UpdateAppReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App) ProtoMessage() {}
+func (*UpdateAppReq) ProtoMessage() {}
 
-func (x *App) ProtoReflect() protoreflect.Message {
+func (x *UpdateAppReq) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -309,61 +678,155 @@ func (x *App) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App.ProtoReflect.Descriptor instead.
-func (*App) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateAppReq.ProtoReflect.Descriptor instead.
+func (*UpdateAppReq) Descriptor() ([]byte, []int) {
 	return file_worker0_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *App) GetUUID() string {
+func (x *UpdateAppReq) GetName() string {
 	if x != nil {
-		return x.UUID
+		return x.Name
 	}
 	return ""
 }
 
-func (x *App) GetMainP() *App_MainProcess {
+func (x *UpdateAppReq) GetVersion() string {
 	if x != nil {
-		return x.MainP
+		return x.Version
 	}
-	return nil
+	return ""
 }
 
-func (x *App) GetFileMounts() []*App_FileMount {
+func (x *UpdateAppReq) GetEnvs() []*App_EnvVar {
 	if x != nil {
-		return x.FileMounts
+		return x.Envs
 	}
 	return nil
 }
 
-func (x *App) GetEnvironmentVars() []*App_EnvVar {
+func (x *UpdateAppReq) GetFileMounts() []*App_FileMount {
 	if x != nil {
-		return x.EnvironmentVars
+		return x.FileMounts
 	}
 	return nil
 }
 
-func (x *App) GetNetworks() []*App_Network {
+func (x *UpdateAppReq) GetUpdateStrategy() UpdateAppReq_Strategy {
 	if x != nil {
-		return x.Networks
+		return x.UpdateStrategy
 	}
-	return nil
+	return UpdateAppReq_Recreate
 }
 
-func (x *App) GetWorkspace() *App_WorkspaceInfo {
-	if x != nil {
-		return x.Workspace
-	}
-	return nil
-}
+type App struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *App) GetFilePremise() []*App_File {
-	if x != nil {
-		return x.FilePremise
-	}
-	return nil
+	UUID            string             `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	MainP           *App_MainProcess   `protobuf:"bytes,2,opt,name=MainP,proto3" json:"MainP,omitempty"`
+	FileMounts      []*App_FileMount   `protobuf:"bytes,3,rep,name=FileMounts,proto3" json:"FileMounts,omitempty"`
+	EnvironmentVars []*App_EnvVar      `protobuf:"bytes,4,rep,name=EnvironmentVars,proto3" json:"EnvironmentVars,omitempty"`
+	Networks        []*App_Network     `protobuf:"bytes,5,rep,name=Networks,proto3" json:"Networks,omitempty"`
+	Workspace       *App_WorkspaceInfo `protobuf:"bytes,6,opt,name=Workspace,proto3" json:"Workspace,omitempty"`
+	FilePremise     []*App_File        `protobuf:"bytes,7,rep,name=FilePremise,proto3" json:"FilePremise,omitempty"`
+	LimitInfo       *App_Limit         `protobuf:"bytes,8,opt,name=LimitInfo,proto3" json:"LimitInfo,omitempty"`
+	HealthInfo      *App_Health        `protobuf:"bytes,9,opt,name=HealthInfo,proto3" json:"HealthInfo,omitempty"`
+	LogInfo         *App_Log           `protobuf:"bytes,10,opt,name=LogInfo,proto3" json:"LogInfo,omitempty"`
+	Tags            []*App_Tag         `protobuf:"bytes,11,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	RestartInfo     *App_Restart       `protobuf:"bytes,12,opt,name=RestartInfo,proto3" json:"RestartInfo,omitempty"`
+	// keep data of app when it is destroyed, workspace for virtualbox and pvc for k8s
+	KeepData     bool          `protobuf:"varint,13,opt,name=KeepData,proto3" json:"KeepData,omitempty"`
+	WorkloadType App_Workload  `protobuf:"varint,14,opt,name=WorkloadType,proto3,enum=worker0.App_Workload" json:"WorkloadType,omitempty"`
+	Volumes      []*App_Volume `protobuf:"bytes,15,rep,name=Volumes,proto3" json:"Volumes,omitempty"`
+	// StartApp only validates app if it is set, k8s objects are validated by the cluster without being applied
+	DryRun bool `protobuf:"varint,16,opt,name=DryRun,proto3" json:"DryRun,omitempty"`
+	// desired replicas of workload, k8s only. 0 means 1
+	Replicas int32 `protobuf:"varint,17,opt,name=Replicas,proto3" json:"Replicas,omitempty"`
 }
 
-func (x *App) GetLimitInfo() *App_Limit {
+func (x *App) Reset() {
+	*x = App{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App) ProtoMessage() {}
+
+func (x *App) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App.ProtoReflect.Descriptor instead.
+func (*App) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *App) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+func (x *App) GetMainP() *App_MainProcess {
+	if x != nil {
+		return x.MainP
+	}
+	return nil
+}
+
+func (x *App) GetFileMounts() []*App_FileMount {
+	if x != nil {
+		return x.FileMounts
+	}
+	return nil
+}
+
+func (x *App) GetEnvironmentVars() []*App_EnvVar {
+	if x != nil {
+		return x.EnvironmentVars
+	}
+	return nil
+}
+
+func (x *App) GetNetworks() []*App_Network {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+func (x *App) GetWorkspace() *App_WorkspaceInfo {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+func (x *App) GetFilePremise() []*App_File {
+	if x != nil {
+		return x.FilePremise
+	}
+	return nil
+}
+
+func (x *App) GetLimitInfo() *App_Limit {
 	if x != nil {
 		return x.LimitInfo
 	}
@@ -391,59 +854,59 @@ func (x *App) GetTags() []*App_Tag {
 	return nil
 }
 
-type Empty struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *App) GetRestartInfo() *App_Restart {
+	if x != nil {
+		return x.RestartInfo
+	}
+	return nil
 }
 
-func (x *Empty) Reset() {
-	*x = Empty{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_worker0_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *App) GetKeepData() bool {
+	if x != nil {
+		return x.KeepData
 	}
+	return false
 }
 
-func (x *Empty) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *App) GetWorkloadType() App_Workload {
+	if x != nil {
+		return x.WorkloadType
+	}
+	return App_Deployment
 }
 
-func (*Empty) ProtoMessage() {}
+func (x *App) GetVolumes() []*App_Volume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
 
-func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_worker0_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *App) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
-func (*Empty) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{2}
+func (x *App) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
 }
 
-type App_MainProcess struct {
+type ScaleReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CheckSum string                `protobuf:"bytes,1,opt,name=CheckSum,proto3" json:"CheckSum,omitempty"`
-	Name     string                `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
-	Version  string                `protobuf:"bytes,3,opt,name=Version,proto3" json:"Version,omitempty"`
-	Type     App_MainProcess_PType `protobuf:"varint,4,opt,name=Type,proto3,enum=worker0.App_MainProcess_PType" json:"Type,omitempty"`
-	Workdir  string                `protobuf:"bytes,5,opt,name=Workdir,proto3" json:"Workdir,omitempty"`
-	StartCMD []string              `protobuf:"bytes,6,rep,name=StartCMD,proto3" json:"StartCMD,omitempty"`
+	// desired replicas, at least 1. stop app to scale it to zero
+	Replicas int32 `protobuf:"varint,1,opt,name=Replicas,proto3" json:"Replicas,omitempty"`
 }
 
-func (x *App_MainProcess) Reset() {
-	*x = App_MainProcess{}
+func (x *ScaleReq) Reset() {
+	*x = ScaleReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -451,13 +914,13 @@ func (x *App_MainProcess) Reset() {
 	}
 }
 
-func (x *App_MainProcess) String() string {
+func (x *ScaleReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App_MainProcess) ProtoMessage() {}
+func (*ScaleReq) ProtoMessage() {}
 
-func (x *App_MainProcess) ProtoReflect() protoreflect.Message {
+func (x *ScaleReq) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -469,69 +932,36 @@ func (x *App_MainProcess) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App_MainProcess.ProtoReflect.Descriptor instead.
-func (*App_MainProcess) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 0}
-}
-
-func (x *App_MainProcess) GetCheckSum() string {
-	if x != nil {
-		return x.CheckSum
-	}
-	return ""
-}
-
-func (x *App_MainProcess) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *App_MainProcess) GetVersion() string {
-	if x != nil {
-		return x.Version
-	}
-	return ""
-}
-
-func (x *App_MainProcess) GetType() App_MainProcess_PType {
-	if x != nil {
-		return x.Type
-	}
-	return App_MainProcess_Binary
-}
-
-func (x *App_MainProcess) GetWorkdir() string {
-	if x != nil {
-		return x.Workdir
-	}
-	return ""
+// Deprecated: Use ScaleReq.ProtoReflect.Descriptor instead.
+func (*ScaleReq) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *App_MainProcess) GetStartCMD() []string {
+func (x *ScaleReq) GetReplicas() int32 {
 	if x != nil {
-		return x.StartCMD
+		return x.Replicas
 	}
-	return nil
+	return 0
 }
 
-// 将文件挂载到对应的目录
-// File 表示要被挂载的文件
-// MountTo 表示挂载到的地方
-// Volume 一般不填这个字段，除非有对应的卷存在
-type App_FileMount struct {
+type PortReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	File    string `protobuf:"bytes,1,opt,name=File,proto3" json:"File,omitempty"`
-	MountTo string `protobuf:"bytes,2,opt,name=MountTo,proto3" json:"MountTo,omitempty"`
-	Volume  string `protobuf:"bytes,3,opt,name=Volume,proto3" json:"Volume,omitempty"`
+	// port in container or vm
+	Port int32 `protobuf:"varint,1,opt,name=Port,proto3" json:"Port,omitempty"`
+	// port exported on host, nat port for virtualbox, node port for k8s. 0 means pick one
+	HostPort     int32                        `protobuf:"varint,2,opt,name=HostPort,proto3" json:"HostPort,omitempty"`
+	ProtocolType App_Network_PortInf_Protocol `protobuf:"varint,3,opt,name=ProtocolType,proto3,enum=worker0.App_Network_PortInf_Protocol" json:"ProtocolType,omitempty"`
+	// host ports already reserved in core, never be picked
+	ExcludeHostPorts []int32 `protobuf:"varint,4,rep,packed,name=ExcludeHostPorts,proto3" json:"ExcludeHostPorts,omitempty"`
+	// how port is exposed, k8s only picks node port for NodePort
+	ExposeType App_Network_Expose `protobuf:"varint,5,opt,name=ExposeType,proto3,enum=worker0.App_Network_Expose" json:"ExposeType,omitempty"`
 }
 
-func (x *App_FileMount) Reset() {
-	*x = App_FileMount{}
+func (x *PortReq) Reset() {
+	*x = PortReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -539,13 +969,13 @@ func (x *App_FileMount) Reset() {
 	}
 }
 
-func (x *App_FileMount) String() string {
+func (x *PortReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App_FileMount) ProtoMessage() {}
+func (*PortReq) ProtoMessage() {}
 
-func (x *App_FileMount) ProtoReflect() protoreflect.Message {
+func (x *PortReq) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -557,43 +987,59 @@ func (x *App_FileMount) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App_FileMount.ProtoReflect.Descriptor instead.
-func (*App_FileMount) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 1}
+// Deprecated: Use PortReq.ProtoReflect.Descriptor instead.
+func (*PortReq) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *App_FileMount) GetFile() string {
+func (x *PortReq) GetPort() int32 {
 	if x != nil {
-		return x.File
+		return x.Port
 	}
-	return ""
+	return 0
 }
 
-func (x *App_FileMount) GetMountTo() string {
+func (x *PortReq) GetHostPort() int32 {
 	if x != nil {
-		return x.MountTo
+		return x.HostPort
 	}
-	return ""
+	return 0
 }
 
-func (x *App_FileMount) GetVolume() string {
+func (x *PortReq) GetProtocolType() App_Network_PortInf_Protocol {
 	if x != nil {
-		return x.Volume
+		return x.ProtocolType
 	}
-	return ""
+	return App_Network_PortInf_TCP
 }
 
-type App_EnvVar struct {
+func (x *PortReq) GetExcludeHostPorts() []int32 {
+	if x != nil {
+		return x.ExcludeHostPorts
+	}
+	return nil
+}
+
+func (x *PortReq) GetExposeType() App_Network_Expose {
+	if x != nil {
+		return x.ExposeType
+	}
+	return App_Network_NodePort
+}
+
+type PortRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key   string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
-	Value string `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	Available bool   `protobuf:"varint,1,opt,name=Available,proto3" json:"Available,omitempty"`
+	Port      int32  `protobuf:"varint,2,opt,name=Port,proto3" json:"Port,omitempty"`
+	HostPort  int32  `protobuf:"varint,3,opt,name=HostPort,proto3" json:"HostPort,omitempty"`
+	Message   string `protobuf:"bytes,4,opt,name=Message,proto3" json:"Message,omitempty"`
 }
 
-func (x *App_EnvVar) Reset() {
-	*x = App_EnvVar{}
+func (x *PortRes) Reset() {
+	*x = PortRes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -601,13 +1047,13 @@ func (x *App_EnvVar) Reset() {
 	}
 }
 
-func (x *App_EnvVar) String() string {
+func (x *PortRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App_EnvVar) ProtoMessage() {}
+func (*PortRes) ProtoMessage() {}
 
-func (x *App_EnvVar) ProtoReflect() protoreflect.Message {
+func (x *PortRes) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -619,36 +1065,64 @@ func (x *App_EnvVar) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App_EnvVar.ProtoReflect.Descriptor instead.
-func (*App_EnvVar) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 2}
+// Deprecated: Use PortRes.ProtoReflect.Descriptor instead.
+func (*PortRes) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *App_EnvVar) GetKey() string {
+func (x *PortRes) GetAvailable() bool {
 	if x != nil {
-		return x.Key
+		return x.Available
 	}
-	return ""
+	return false
 }
 
-func (x *App_EnvVar) GetValue() string {
+func (x *PortRes) GetPort() int32 {
 	if x != nil {
-		return x.Value
+		return x.Port
+	}
+	return 0
+}
+
+func (x *PortRes) GetHostPort() int32 {
+	if x != nil {
+		return x.HostPort
+	}
+	return 0
+}
+
+func (x *PortRes) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-type App_Network struct {
+type AppStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PortInfo  *App_Network_PortInf    `protobuf:"bytes,1,opt,name=PortInfo,proto3" json:"PortInfo,omitempty"`
-	RouteInfo []*App_Network_RouteInf `protobuf:"bytes,2,rep,name=RouteInfo,proto3" json:"RouteInfo,omitempty"`
-}
-
-func (x *App_Network) Reset() {
-	*x = App_Network{}
+	App       *App            `protobuf:"bytes,1,opt,name=App,proto3" json:"App,omitempty"`
+	StateType AppStatus_State `protobuf:"varint,2,opt,name=StateType,proto3,enum=worker0.AppStatus_State" json:"StateType,omitempty"`
+	// pid of main process, virtualbox only
+	Pid int32 `protobuf:"varint,3,opt,name=Pid,proto3" json:"Pid,omitempty"`
+	// pods of app, k8s only
+	Pods         []string `protobuf:"bytes,4,rep,name=Pods,proto3" json:"Pods,omitempty"`
+	RestartCount int32    `protobuf:"varint,5,opt,name=RestartCount,proto3" json:"RestartCount,omitempty"`
+	// result of readness check, false if no readness configured
+	Healthy bool   `protobuf:"varint,6,opt,name=Healthy,proto3" json:"Healthy,omitempty"`
+	Message string `protobuf:"bytes,7,opt,name=Message,proto3" json:"Message,omitempty"`
+	// exit code of the last exited main process, -1 if killed by signal, virtualbox only
+	ExitCode int32 `protobuf:"varint,8,opt,name=ExitCode,proto3" json:"ExitCode,omitempty"`
+	// limit enforced on the main process, nil if not enforced, virtualbox only
+	EnforcedLimit *App_Limit `protobuf:"bytes,9,opt,name=EnforcedLimit,proto3" json:"EnforcedLimit,omitempty"`
+	// count of processes killed by oom in the app, virtualbox only
+	OOMKills int32 `protobuf:"varint,10,opt,name=OOMKills,proto3" json:"OOMKills,omitempty"`
+}
+
+func (x *AppStatus) Reset() {
+	*x = AppStatus{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -656,13 +1130,13 @@ func (x *App_Network) Reset() {
 	}
 }
 
-func (x *App_Network) String() string {
+func (x *AppStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App_Network) ProtoMessage() {}
+func (*AppStatus) ProtoMessage() {}
 
-func (x *App_Network) ProtoReflect() protoreflect.Message {
+func (x *AppStatus) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -674,35 +1148,92 @@ func (x *App_Network) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App_Network.ProtoReflect.Descriptor instead.
-func (*App_Network) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 3}
+// Deprecated: Use AppStatus.ProtoReflect.Descriptor instead.
+func (*AppStatus) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *App_Network) GetPortInfo() *App_Network_PortInf {
+func (x *AppStatus) GetApp() *App {
 	if x != nil {
-		return x.PortInfo
+		return x.App
 	}
 	return nil
 }
 
-func (x *App_Network) GetRouteInfo() []*App_Network_RouteInf {
+func (x *AppStatus) GetStateType() AppStatus_State {
 	if x != nil {
-		return x.RouteInfo
+		return x.StateType
 	}
-	return nil
+	return AppStatus_Unknown
 }
 
-type App_WorkspaceInfo struct {
+func (x *AppStatus) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *AppStatus) GetPods() []string {
+	if x != nil {
+		return x.Pods
+	}
+	return nil
+}
+
+func (x *AppStatus) GetRestartCount() int32 {
+	if x != nil {
+		return x.RestartCount
+	}
+	return 0
+}
+
+func (x *AppStatus) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *AppStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AppStatus) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *AppStatus) GetEnforcedLimit() *App_Limit {
+	if x != nil {
+		return x.EnforcedLimit
+	}
+	return nil
+}
+
+func (x *AppStatus) GetOOMKills() int32 {
+	if x != nil {
+		return x.OOMKills
+	}
+	return 0
+}
+
+type RenderAppRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Workspace string `protobuf:"bytes,1,opt,name=Workspace,proto3" json:"Workspace,omitempty"`
+	// manifests in the order they are applied
+	Manifests []*RenderAppRes_Manifest `protobuf:"bytes,1,rep,name=Manifests,proto3" json:"Manifests,omitempty"`
 }
 
-func (x *App_WorkspaceInfo) Reset() {
-	*x = App_WorkspaceInfo{}
+func (x *RenderAppRes) Reset() {
+	*x = RenderAppRes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -710,13 +1241,13 @@ func (x *App_WorkspaceInfo) Reset() {
 	}
 }
 
-func (x *App_WorkspaceInfo) String() string {
+func (x *RenderAppRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App_WorkspaceInfo) ProtoMessage() {}
+func (*RenderAppRes) ProtoMessage() {}
 
-func (x *App_WorkspaceInfo) ProtoReflect() protoreflect.Message {
+func (x *RenderAppRes) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -728,30 +1259,28 @@ func (x *App_WorkspaceInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App_WorkspaceInfo.ProtoReflect.Descriptor instead.
-func (*App_WorkspaceInfo) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 4}
+// Deprecated: Use RenderAppRes.ProtoReflect.Descriptor instead.
+func (*RenderAppRes) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *App_WorkspaceInfo) GetWorkspace() string {
+func (x *RenderAppRes) GetManifests() []*RenderAppRes_Manifest {
 	if x != nil {
-		return x.Workspace
+		return x.Manifests
 	}
-	return ""
+	return nil
 }
 
-type App_File struct {
+type ListAppsReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	AcquireAddr string `protobuf:"bytes,2,opt,name=AcquireAddr,proto3" json:"AcquireAddr,omitempty"`
-	Shell       string `protobuf:"bytes,3,opt,name=Shell,proto3" json:"Shell,omitempty"`
+	Tags []*App_Tag `protobuf:"bytes,1,rep,name=Tags,proto3" json:"Tags,omitempty"`
 }
 
-func (x *App_File) Reset() {
-	*x = App_File{}
+func (x *ListAppsReq) Reset() {
+	*x = ListAppsReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -759,13 +1288,13 @@ func (x *App_File) Reset() {
 	}
 }
 
-func (x *App_File) String() string {
+func (x *ListAppsReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App_File) ProtoMessage() {}
+func (*ListAppsReq) ProtoMessage() {}
 
-func (x *App_File) ProtoReflect() protoreflect.Message {
+func (x *ListAppsReq) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -777,45 +1306,28 @@ func (x *App_File) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App_File.ProtoReflect.Descriptor instead.
-func (*App_File) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 5}
-}
-
-func (x *App_File) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *App_File) GetAcquireAddr() string {
-	if x != nil {
-		return x.AcquireAddr
-	}
-	return ""
+// Deprecated: Use ListAppsReq.ProtoReflect.Descriptor instead.
+func (*ListAppsReq) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *App_File) GetShell() string {
+func (x *ListAppsReq) GetTags() []*App_Tag {
 	if x != nil {
-		return x.Shell
+		return x.Tags
 	}
-	return ""
+	return nil
 }
 
-type App_Limit struct {
+type ListAppsRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// unit 'm'
-	CPU int32 `protobuf:"varint,1,opt,name=CPU,proto3" json:"CPU,omitempty"`
-	// unit 'MB'
-	Memory int32 `protobuf:"varint,2,opt,name=Memory,proto3" json:"Memory,omitempty"`
+	Apps []*AppStatus `protobuf:"bytes,1,rep,name=Apps,proto3" json:"Apps,omitempty"`
 }
 
-func (x *App_Limit) Reset() {
-	*x = App_Limit{}
+func (x *ListAppsRes) Reset() {
+	*x = ListAppsRes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -823,13 +1335,13 @@ func (x *App_Limit) Reset() {
 	}
 }
 
-func (x *App_Limit) String() string {
+func (x *ListAppsRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App_Limit) ProtoMessage() {}
+func (*ListAppsRes) ProtoMessage() {}
 
-func (x *App_Limit) ProtoReflect() protoreflect.Message {
+func (x *ListAppsRes) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -841,38 +1353,33 @@ func (x *App_Limit) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App_Limit.ProtoReflect.Descriptor instead.
-func (*App_Limit) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 6}
-}
-
-func (x *App_Limit) GetCPU() int32 {
-	if x != nil {
-		return x.CPU
-	}
-	return 0
+// Deprecated: Use ListAppsRes.ProtoReflect.Descriptor instead.
+func (*ListAppsRes) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *App_Limit) GetMemory() int32 {
+func (x *ListAppsRes) GetApps() []*AppStatus {
 	if x != nil {
-		return x.Memory
+		return x.Apps
 	}
-	return 0
+	return nil
 }
 
-type App_Health struct {
+type LogReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// url to judge app living
-	Liveness *App_Health_Basic `protobuf:"bytes,1,opt,name=Liveness,proto3" json:"Liveness,omitempty"`
-	// url to judge app working
-	Readness *App_Health_Basic `protobuf:"bytes,2,opt,name=Readness,proto3" json:"Readness,omitempty"`
+	// keep streaming new lines until client cancel
+	Follow bool `protobuf:"varint,1,opt,name=Follow,proto3" json:"Follow,omitempty"`
+	// only last N lines of history, 0 means all
+	Tail int32 `protobuf:"varint,2,opt,name=Tail,proto3" json:"Tail,omitempty"`
+	// only lines after the unix timestamp(second), 0 means no limit
+	SinceTime int64 `protobuf:"varint,3,opt,name=SinceTime,proto3" json:"SinceTime,omitempty"`
 }
 
-func (x *App_Health) Reset() {
-	*x = App_Health{}
+func (x *LogReq) Reset() {
+	*x = LogReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -880,13 +1387,13 @@ func (x *App_Health) Reset() {
 	}
 }
 
-func (x *App_Health) String() string {
+func (x *LogReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App_Health) ProtoMessage() {}
+func (*LogReq) ProtoMessage() {}
 
-func (x *App_Health) ProtoReflect() protoreflect.Message {
+func (x *LogReq) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -898,38 +1405,44 @@ func (x *App_Health) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App_Health.ProtoReflect.Descriptor instead.
-func (*App_Health) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 7}
+// Deprecated: Use LogReq.ProtoReflect.Descriptor instead.
+func (*LogReq) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *App_Health) GetLiveness() *App_Health_Basic {
+func (x *LogReq) GetFollow() bool {
 	if x != nil {
-		return x.Liveness
+		return x.Follow
 	}
-	return nil
+	return false
 }
 
-func (x *App_Health) GetReadness() *App_Health_Basic {
+func (x *LogReq) GetTail() int32 {
 	if x != nil {
-		return x.Readness
+		return x.Tail
 	}
-	return nil
+	return 0
 }
 
-type App_Log struct {
+func (x *LogReq) GetSinceTime() int64 {
+	if x != nil {
+		return x.SinceTime
+	}
+	return 0
+}
+
+type LogLine struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Real-time log file name. eg: xxx/log.log
-	RealTimeFile string `protobuf:"bytes,1,opt,name=RealTimeFile,proto3" json:"RealTimeFile,omitempty"`
-	// path of compress log file
-	FilePath string `protobuf:"bytes,2,opt,name=FilePath,proto3" json:"FilePath,omitempty"`
+	Line string `protobuf:"bytes,1,opt,name=Line,proto3" json:"Line,omitempty"`
+	// source of the line, pod name for k8s, file name for virtualbox
+	Source string `protobuf:"bytes,2,opt,name=Source,proto3" json:"Source,omitempty"`
 }
 
-func (x *App_Log) Reset() {
-	*x = App_Log{}
+func (x *LogLine) Reset() {
+	*x = LogLine{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -937,13 +1450,13 @@ func (x *App_Log) Reset() {
 	}
 }
 
-func (x *App_Log) String() string {
+func (x *LogLine) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App_Log) ProtoMessage() {}
+func (*LogLine) ProtoMessage() {}
 
-func (x *App_Log) ProtoReflect() protoreflect.Message {
+func (x *LogLine) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -955,36 +1468,38 @@ func (x *App_Log) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App_Log.ProtoReflect.Descriptor instead.
-func (*App_Log) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 8}
+// Deprecated: Use LogLine.ProtoReflect.Descriptor instead.
+func (*LogLine) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *App_Log) GetRealTimeFile() string {
+func (x *LogLine) GetLine() string {
 	if x != nil {
-		return x.RealTimeFile
+		return x.Line
 	}
 	return ""
 }
 
-func (x *App_Log) GetFilePath() string {
+func (x *LogLine) GetSource() string {
 	if x != nil {
-		return x.FilePath
+		return x.Source
 	}
 	return ""
 }
 
-type App_Tag struct {
+type ExecReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key   string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
-	Value string `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	Command []string          `protobuf:"bytes,1,rep,name=Command,proto3" json:"Command,omitempty"`
+	Envs    map[string]string `protobuf:"bytes,2,rep,name=Envs,proto3" json:"Envs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// timeout in seconds, 0 means 30 seconds, ignored in tty mode
+	Timeout int32 `protobuf:"varint,3,opt,name=Timeout,proto3" json:"Timeout,omitempty"`
 }
 
-func (x *App_Tag) Reset() {
-	*x = App_Tag{}
+func (x *ExecReq) Reset() {
+	*x = ExecReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -992,13 +1507,13 @@ func (x *App_Tag) Reset() {
 	}
 }
 
-func (x *App_Tag) String() string {
+func (x *ExecReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App_Tag) ProtoMessage() {}
+func (*ExecReq) ProtoMessage() {}
 
-func (x *App_Tag) ProtoReflect() protoreflect.Message {
+func (x *ExecReq) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1010,37 +1525,44 @@ func (x *App_Tag) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App_Tag.ProtoReflect.Descriptor instead.
-func (*App_Tag) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 9}
+// Deprecated: Use ExecReq.ProtoReflect.Descriptor instead.
+func (*ExecReq) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *App_Tag) GetKey() string {
+func (x *ExecReq) GetCommand() []string {
 	if x != nil {
-		return x.Key
+		return x.Command
 	}
-	return ""
+	return nil
 }
 
-func (x *App_Tag) GetValue() string {
+func (x *ExecReq) GetEnvs() map[string]string {
 	if x != nil {
-		return x.Value
+		return x.Envs
 	}
-	return ""
+	return nil
 }
 
-type App_Network_PortInf struct {
+func (x *ExecReq) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+type ExecRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Port         int32                        `protobuf:"varint,1,opt,name=Port,proto3" json:"Port,omitempty"`
-	Name         string                       `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
-	ProtocolType App_Network_PortInf_Protocol `protobuf:"varint,3,opt,name=ProtocolType,proto3,enum=worker0.App_Network_PortInf_Protocol" json:"ProtocolType,omitempty"`
+	Stdout   string `protobuf:"bytes,1,opt,name=Stdout,proto3" json:"Stdout,omitempty"`
+	Stderr   string `protobuf:"bytes,2,opt,name=Stderr,proto3" json:"Stderr,omitempty"`
+	ExitCode int32  `protobuf:"varint,3,opt,name=ExitCode,proto3" json:"ExitCode,omitempty"`
 }
 
-func (x *App_Network_PortInf) Reset() {
-	*x = App_Network_PortInf{}
+func (x *ExecRes) Reset() {
+	*x = ExecRes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1048,13 +1570,13 @@ func (x *App_Network_PortInf) Reset() {
 	}
 }
 
-func (x *App_Network_PortInf) String() string {
+func (x *ExecRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App_Network_PortInf) ProtoMessage() {}
+func (*ExecRes) ProtoMessage() {}
 
-func (x *App_Network_PortInf) ProtoReflect() protoreflect.Message {
+func (x *ExecRes) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1066,43 +1588,44 @@ func (x *App_Network_PortInf) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App_Network_PortInf.ProtoReflect.Descriptor instead.
-func (*App_Network_PortInf) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 3, 0}
+// Deprecated: Use ExecRes.ProtoReflect.Descriptor instead.
+func (*ExecRes) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *App_Network_PortInf) GetPort() int32 {
+func (x *ExecRes) GetStdout() string {
 	if x != nil {
-		return x.Port
+		return x.Stdout
 	}
-	return 0
+	return ""
 }
 
-func (x *App_Network_PortInf) GetName() string {
+func (x *ExecRes) GetStderr() string {
 	if x != nil {
-		return x.Name
+		return x.Stderr
 	}
 	return ""
 }
 
-func (x *App_Network_PortInf) GetProtocolType() App_Network_PortInf_Protocol {
+func (x *ExecRes) GetExitCode() int32 {
 	if x != nil {
-		return x.ProtocolType
+		return x.ExitCode
 	}
-	return App_Network_PortInf_TCP
+	return 0
 }
 
-type App_Network_RouteInf struct {
+type ExecInput struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RouteType App_Network_RouteInf_Route `protobuf:"varint,1,opt,name=RouteType,proto3,enum=worker0.App_Network_RouteInf_Route" json:"RouteType,omitempty"`
-	Router    string                     `protobuf:"bytes,2,opt,name=Router,proto3" json:"Router,omitempty"`
+	Start  *ExecReq        `protobuf:"bytes,1,opt,name=Start,proto3" json:"Start,omitempty"`
+	Stdin  []byte          `protobuf:"bytes,2,opt,name=Stdin,proto3" json:"Stdin,omitempty"`
+	Resize *ExecInput_Size `protobuf:"bytes,3,opt,name=Resize,proto3" json:"Resize,omitempty"`
 }
 
-func (x *App_Network_RouteInf) Reset() {
-	*x = App_Network_RouteInf{}
+func (x *ExecInput) Reset() {
+	*x = ExecInput{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1110,13 +1633,13 @@ func (x *App_Network_RouteInf) Reset() {
 	}
 }
 
-func (x *App_Network_RouteInf) String() string {
+func (x *ExecInput) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App_Network_RouteInf) ProtoMessage() {}
+func (*ExecInput) ProtoMessage() {}
 
-func (x *App_Network_RouteInf) ProtoReflect() protoreflect.Message {
+func (x *ExecInput) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1128,39 +1651,47 @@ func (x *App_Network_RouteInf) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App_Network_RouteInf.ProtoReflect.Descriptor instead.
-func (*App_Network_RouteInf) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 3, 1}
+// Deprecated: Use ExecInput.ProtoReflect.Descriptor instead.
+func (*ExecInput) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *App_Network_RouteInf) GetRouteType() App_Network_RouteInf_Route {
+func (x *ExecInput) GetStart() *ExecReq {
 	if x != nil {
-		return x.RouteType
+		return x.Start
 	}
-	return App_Network_RouteInf_IN
+	return nil
 }
 
-func (x *App_Network_RouteInf) GetRouter() string {
+func (x *ExecInput) GetStdin() []byte {
 	if x != nil {
-		return x.Router
+		return x.Stdin
 	}
-	return ""
+	return nil
 }
 
-type App_Health_Basic struct {
+func (x *ExecInput) GetResize() *ExecInput_Size {
+	if x != nil {
+		return x.Resize
+	}
+	return nil
+}
+
+type ExecOutput struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MethodType App_Health_Basic_Method `protobuf:"varint,1,opt,name=MethodType,proto3,enum=worker0.App_Health_Basic_Method" json:"MethodType,omitempty"`
-	// path to request
-	Path string `protobuf:"bytes,2,opt,name=Path,proto3" json:"Path,omitempty"`
-	// same as above
-	Port int32 `protobuf:"varint,3,opt,name=Port,proto3" json:"Port,omitempty"`
+	// stdout and stderr are merged in tty
+	Stdout []byte `protobuf:"bytes,1,opt,name=Stdout,proto3" json:"Stdout,omitempty"`
+	// last output, command exited
+	Exited   bool   `protobuf:"varint,2,opt,name=Exited,proto3" json:"Exited,omitempty"`
+	ExitCode int32  `protobuf:"varint,3,opt,name=ExitCode,proto3" json:"ExitCode,omitempty"`
+	Message  string `protobuf:"bytes,4,opt,name=Message,proto3" json:"Message,omitempty"`
 }
 
-func (x *App_Health_Basic) Reset() {
-	*x = App_Health_Basic{}
+func (x *ExecOutput) Reset() {
+	*x = ExecOutput{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_worker0_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1168,13 +1699,13 @@ func (x *App_Health_Basic) Reset() {
 	}
 }
 
-func (x *App_Health_Basic) String() string {
+func (x *ExecOutput) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*App_Health_Basic) ProtoMessage() {}
+func (*ExecOutput) ProtoMessage() {}
 
-func (x *App_Health_Basic) ProtoReflect() protoreflect.Message {
+func (x *ExecOutput) ProtoReflect() protoreflect.Message {
 	mi := &file_worker0_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1186,55 +1717,1590 @@ func (x *App_Health_Basic) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use App_Health_Basic.ProtoReflect.Descriptor instead.
-func (*App_Health_Basic) Descriptor() ([]byte, []int) {
-	return file_worker0_proto_rawDescGZIP(), []int{1, 7, 0}
+// Deprecated: Use ExecOutput.ProtoReflect.Descriptor instead.
+func (*ExecOutput) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *App_Health_Basic) GetMethodType() App_Health_Basic_Method {
+func (x *ExecOutput) GetStdout() []byte {
 	if x != nil {
-		return x.MethodType
+		return x.Stdout
 	}
-	return App_Health_Basic_GET
+	return nil
 }
 
-func (x *App_Health_Basic) GetPath() string {
+func (x *ExecOutput) GetExited() bool {
 	if x != nil {
-		return x.Path
+		return x.Exited
 	}
-	return ""
+	return false
 }
 
-func (x *App_Health_Basic) GetPort() int32 {
+func (x *ExecOutput) GetExitCode() int32 {
 	if x != nil {
-		return x.Port
+		return x.ExitCode
 	}
 	return 0
 }
 
-var File_worker0_proto protoreflect.FileDescriptor
+func (x *ExecOutput) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
 
-var file_worker0_proto_rawDesc = []byte{
-	0x0a, 0x0d, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x22, 0x39, 0x0a, 0x09, 0x4e, 0x65, 0x77, 0x41,
-	0x70, 0x70, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x22, 0xda, 0x0e, 0x0a, 0x03, 0x41, 0x70, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x55,
-	0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x55, 0x55, 0x49, 0x44, 0x12,
-	0x2e, 0x0a, 0x05, 0x4d, 0x61, 0x69, 0x6e, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
-	0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4d, 0x61, 0x69,
-	0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x05, 0x4d, 0x61, 0x69, 0x6e, 0x50, 0x12,
-	0x36, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70,
-	0x70, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0a, 0x46, 0x69, 0x6c,
-	0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x3d, 0x0a, 0x0f, 0x45, 0x6e, 0x76, 0x69, 0x72,
-	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x56, 0x61, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x13, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x45,
-	0x6e, 0x76, 0x56, 0x61, 0x72, 0x52, 0x0f, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65,
-	0x6e, 0x74, 0x56, 0x61, 0x72, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
-	0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x08,
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{16}
+}
+
+type FileReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path      string `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	Recursive bool   `protobuf:"varint,2,opt,name=Recursive,proto3" json:"Recursive,omitempty"`
+}
+
+func (x *FileReq) Reset() {
+	*x = FileReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileReq) ProtoMessage() {}
+
+func (x *FileReq) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileReq.ProtoReflect.Descriptor instead.
+func (*FileReq) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FileReq) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileReq) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+type FileInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// path relative to workspace
+	Path string `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	Size int64  `protobuf:"varint,2,opt,name=Size,proto3" json:"Size,omitempty"`
+	// permission bits of file
+	Mode uint32 `protobuf:"varint,3,opt,name=Mode,proto3" json:"Mode,omitempty"`
+	// unix timestamp(second) of last modification
+	ModTime int64 `protobuf:"varint,4,opt,name=ModTime,proto3" json:"ModTime,omitempty"`
+	IsDir   bool  `protobuf:"varint,5,opt,name=IsDir,proto3" json:"IsDir,omitempty"`
+}
+
+func (x *FileInfo) Reset() {
+	*x = FileInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfo) ProtoMessage() {}
+
+func (x *FileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FileInfo) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileInfo) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *FileInfo) GetModTime() int64 {
+	if x != nil {
+		return x.ModTime
+	}
+	return 0
+}
+
+func (x *FileInfo) GetIsDir() bool {
+	if x != nil {
+		return x.IsDir
+	}
+	return false
+}
+
+type ListFilesRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Files []*FileInfo `protobuf:"bytes,1,rep,name=Files,proto3" json:"Files,omitempty"`
+}
+
+func (x *ListFilesRes) Reset() {
+	*x = ListFilesRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFilesRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesRes) ProtoMessage() {}
+
+func (x *ListFilesRes) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesRes.ProtoReflect.Descriptor instead.
+func (*ListFilesRes) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListFilesRes) GetFiles() []*FileInfo {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type FileChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
+}
+
+func (x *FileChunk) Reset() {
+	*x = FileChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChunk) ProtoMessage() {}
+
+func (x *FileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChunk.ProtoReflect.Descriptor instead.
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FileChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type WriteFileReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// path and mode are only read from the first chunk, mode 0 means 0644
+	Path string `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	Mode uint32 `protobuf:"varint,2,opt,name=Mode,proto3" json:"Mode,omitempty"`
+	Data []byte `protobuf:"bytes,3,opt,name=Data,proto3" json:"Data,omitempty"`
+}
+
+func (x *WriteFileReq) Reset() {
+	*x = WriteFileReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteFileReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteFileReq) ProtoMessage() {}
+
+func (x *WriteFileReq) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteFileReq.ProtoReflect.Descriptor instead.
+func (*WriteFileReq) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WriteFileReq) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *WriteFileReq) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *WriteFileReq) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type App_MainProcess struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CheckSum string                `protobuf:"bytes,1,opt,name=CheckSum,proto3" json:"CheckSum,omitempty"`
+	Name     string                `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Version  string                `protobuf:"bytes,3,opt,name=Version,proto3" json:"Version,omitempty"`
+	Type     App_MainProcess_PType `protobuf:"varint,4,opt,name=Type,proto3,enum=worker0.App_MainProcess_PType" json:"Type,omitempty"`
+	Workdir  string                `protobuf:"bytes,5,opt,name=Workdir,proto3" json:"Workdir,omitempty"`
+	StartCMD []string              `protobuf:"bytes,6,rep,name=StartCMD,proto3" json:"StartCMD,omitempty"`
+}
+
+func (x *App_MainProcess) Reset() {
+	*x = App_MainProcess{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_MainProcess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_MainProcess) ProtoMessage() {}
+
+func (x *App_MainProcess) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_MainProcess.ProtoReflect.Descriptor instead.
+func (*App_MainProcess) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *App_MainProcess) GetCheckSum() string {
+	if x != nil {
+		return x.CheckSum
+	}
+	return ""
+}
+
+func (x *App_MainProcess) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *App_MainProcess) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *App_MainProcess) GetType() App_MainProcess_PType {
+	if x != nil {
+		return x.Type
+	}
+	return App_MainProcess_Binary
+}
+
+func (x *App_MainProcess) GetWorkdir() string {
+	if x != nil {
+		return x.Workdir
+	}
+	return ""
+}
+
+func (x *App_MainProcess) GetStartCMD() []string {
+	if x != nil {
+		return x.StartCMD
+	}
+	return nil
+}
+
+// 将文件挂载到对应的目录
+// File 表示要被挂载的文件
+// MountTo 表示挂载到的地方
+// Volume 一般不填这个字段，除非有对应的卷存在
+type App_FileMount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	File    string `protobuf:"bytes,1,opt,name=File,proto3" json:"File,omitempty"`
+	MountTo string `protobuf:"bytes,2,opt,name=MountTo,proto3" json:"MountTo,omitempty"`
+	Volume  string `protobuf:"bytes,3,opt,name=Volume,proto3" json:"Volume,omitempty"`
+}
+
+func (x *App_FileMount) Reset() {
+	*x = App_FileMount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_FileMount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_FileMount) ProtoMessage() {}
+
+func (x *App_FileMount) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_FileMount.ProtoReflect.Descriptor instead.
+func (*App_FileMount) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *App_FileMount) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *App_FileMount) GetMountTo() string {
+	if x != nil {
+		return x.MountTo
+	}
+	return ""
+}
+
+func (x *App_FileMount) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+type App_EnvVar struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+}
+
+func (x *App_EnvVar) Reset() {
+	*x = App_EnvVar{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_EnvVar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_EnvVar) ProtoMessage() {}
+
+func (x *App_EnvVar) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_EnvVar.ProtoReflect.Descriptor instead.
+func (*App_EnvVar) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 2}
+}
+
+func (x *App_EnvVar) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *App_EnvVar) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type App_Network struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PortInfo   *App_Network_PortInf    `protobuf:"bytes,1,opt,name=PortInfo,proto3" json:"PortInfo,omitempty"`
+	RouteInfo  []*App_Network_RouteInf `protobuf:"bytes,2,rep,name=RouteInfo,proto3" json:"RouteInfo,omitempty"`
+	ExposeType App_Network_Expose      `protobuf:"varint,3,opt,name=ExposeType,proto3,enum=worker0.App_Network_Expose" json:"ExposeType,omitempty"`
+	// only return routes of port, neither service is created nor port is recorded, k8s only
+	DryRun bool `protobuf:"varint,4,opt,name=DryRun,proto3" json:"DryRun,omitempty"`
+}
+
+func (x *App_Network) Reset() {
+	*x = App_Network{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_Network) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_Network) ProtoMessage() {}
+
+func (x *App_Network) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_Network.ProtoReflect.Descriptor instead.
+func (*App_Network) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *App_Network) GetPortInfo() *App_Network_PortInf {
+	if x != nil {
+		return x.PortInfo
+	}
+	return nil
+}
+
+func (x *App_Network) GetRouteInfo() []*App_Network_RouteInf {
+	if x != nil {
+		return x.RouteInfo
+	}
+	return nil
+}
+
+func (x *App_Network) GetExposeType() App_Network_Expose {
+	if x != nil {
+		return x.ExposeType
+	}
+	return App_Network_NodePort
+}
+
+func (x *App_Network) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type App_WorkspaceInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Workspace string `protobuf:"bytes,1,opt,name=Workspace,proto3" json:"Workspace,omitempty"`
+}
+
+func (x *App_WorkspaceInfo) Reset() {
+	*x = App_WorkspaceInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_WorkspaceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_WorkspaceInfo) ProtoMessage() {}
+
+func (x *App_WorkspaceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_WorkspaceInfo.ProtoReflect.Descriptor instead.
+func (*App_WorkspaceInfo) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 4}
+}
+
+func (x *App_WorkspaceInfo) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+type App_File struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	AcquireAddr string `protobuf:"bytes,2,opt,name=AcquireAddr,proto3" json:"AcquireAddr,omitempty"`
+	Shell       string `protobuf:"bytes,3,opt,name=Shell,proto3" json:"Shell,omitempty"`
+	// sensitive file is never written to disk of k8s, it is delivered by secret and shell is not supported
+	Sensitive bool `protobuf:"varint,4,opt,name=Sensitive,proto3" json:"Sensitive,omitempty"`
+	// sha256 of file in hex, verified after file is acquired if set
+	CheckSum string `protobuf:"bytes,5,opt,name=CheckSum,proto3" json:"CheckSum,omitempty"`
+	// dir relative to workdir which the tar.gz file is extracted into by agent if set, so
+	// sensitive archive can be delivered by secret on k8s without shell
+	ExtractTo string `protobuf:"bytes,6,opt,name=ExtractTo,proto3" json:"ExtractTo,omitempty"`
+	// leading components stripped from path of entries when extracting, like tar
+	StripComponents int32 `protobuf:"varint,7,opt,name=StripComponents,proto3" json:"StripComponents,omitempty"`
+}
+
+func (x *App_File) Reset() {
+	*x = App_File{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_File) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_File) ProtoMessage() {}
+
+func (x *App_File) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_File.ProtoReflect.Descriptor instead.
+func (*App_File) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 5}
+}
+
+func (x *App_File) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *App_File) GetAcquireAddr() string {
+	if x != nil {
+		return x.AcquireAddr
+	}
+	return ""
+}
+
+func (x *App_File) GetShell() string {
+	if x != nil {
+		return x.Shell
+	}
+	return ""
+}
+
+func (x *App_File) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
+}
+
+func (x *App_File) GetCheckSum() string {
+	if x != nil {
+		return x.CheckSum
+	}
+	return ""
+}
+
+func (x *App_File) GetExtractTo() string {
+	if x != nil {
+		return x.ExtractTo
+	}
+	return ""
+}
+
+func (x *App_File) GetStripComponents() int32 {
+	if x != nil {
+		return x.StripComponents
+	}
+	return 0
+}
+
+type App_Limit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// unit 'm'
+	CPU int32 `protobuf:"varint,1,opt,name=CPU,proto3" json:"CPU,omitempty"`
+	// unit 'MB'
+	Memory int32 `protobuf:"varint,2,opt,name=Memory,proto3" json:"Memory,omitempty"`
+}
+
+func (x *App_Limit) Reset() {
+	*x = App_Limit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_Limit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_Limit) ProtoMessage() {}
+
+func (x *App_Limit) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_Limit.ProtoReflect.Descriptor instead.
+func (*App_Limit) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 6}
+}
+
+func (x *App_Limit) GetCPU() int32 {
+	if x != nil {
+		return x.CPU
+	}
+	return 0
+}
+
+func (x *App_Limit) GetMemory() int32 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+type App_Health struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// url to judge app living
+	Liveness *App_Health_Basic `protobuf:"bytes,1,opt,name=Liveness,proto3" json:"Liveness,omitempty"`
+	// url to judge app working
+	Readness *App_Health_Basic `protobuf:"bytes,2,opt,name=Readness,proto3" json:"Readness,omitempty"`
+}
+
+func (x *App_Health) Reset() {
+	*x = App_Health{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_Health) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_Health) ProtoMessage() {}
+
+func (x *App_Health) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_Health.ProtoReflect.Descriptor instead.
+func (*App_Health) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 7}
+}
+
+func (x *App_Health) GetLiveness() *App_Health_Basic {
+	if x != nil {
+		return x.Liveness
+	}
+	return nil
+}
+
+func (x *App_Health) GetReadness() *App_Health_Basic {
+	if x != nil {
+		return x.Readness
+	}
+	return nil
+}
+
+type App_Log struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Real-time log file name. eg: xxx/log.log
+	RealTimeFile string `protobuf:"bytes,1,opt,name=RealTimeFile,proto3" json:"RealTimeFile,omitempty"`
+	// path of compress log file
+	FilePath string `protobuf:"bytes,2,opt,name=FilePath,proto3" json:"FilePath,omitempty"`
+}
+
+func (x *App_Log) Reset() {
+	*x = App_Log{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_Log) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_Log) ProtoMessage() {}
+
+func (x *App_Log) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_Log.ProtoReflect.Descriptor instead.
+func (*App_Log) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 8}
+}
+
+func (x *App_Log) GetRealTimeFile() string {
+	if x != nil {
+		return x.RealTimeFile
+	}
+	return ""
+}
+
+func (x *App_Log) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+type App_Tag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+}
+
+func (x *App_Tag) Reset() {
+	*x = App_Tag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_Tag) ProtoMessage() {}
+
+func (x *App_Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_Tag.ProtoReflect.Descriptor instead.
+func (*App_Tag) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 9}
+}
+
+func (x *App_Tag) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *App_Tag) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type App_Restart struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PolicyType App_Restart_Policy `protobuf:"varint,1,opt,name=PolicyType,proto3,enum=worker0.App_Restart_Policy" json:"PolicyType,omitempty"`
+	// seconds to wait before the first restart, doubled for every continuous restart, 0 means 1 second
+	Backoff int32 `protobuf:"varint,2,opt,name=Backoff,proto3" json:"Backoff,omitempty"`
+}
+
+func (x *App_Restart) Reset() {
+	*x = App_Restart{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_Restart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_Restart) ProtoMessage() {}
+
+func (x *App_Restart) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_Restart.ProtoReflect.Descriptor instead.
+func (*App_Restart) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 10}
+}
+
+func (x *App_Restart) GetPolicyType() App_Restart_Policy {
+	if x != nil {
+		return x.PolicyType
+	}
+	return App_Restart_Always
+}
+
+func (x *App_Restart) GetBackoff() int32 {
+	if x != nil {
+		return x.Backoff
+	}
+	return 0
+}
+
+// volume named 'workspace' configures claim of workspace, whose mount path is workdir of app
+type App_Volume struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name of volume, FileMount can mount file in volume by this name
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	// quantity of storage, eg: 10Gi
+	Size           string                `protobuf:"bytes,2,opt,name=Size,proto3" json:"Size,omitempty"`
+	AccessModeType App_Volume_AccessMode `protobuf:"varint,3,opt,name=AccessModeType,proto3,enum=worker0.App_Volume_AccessMode" json:"AccessModeType,omitempty"`
+	// storage class of claim, empty means storage class of agent
+	StorageClass string `protobuf:"bytes,4,opt,name=StorageClass,proto3" json:"StorageClass,omitempty"`
+	// absolute path in container
+	MountPath string `protobuf:"bytes,5,opt,name=MountPath,proto3" json:"MountPath,omitempty"`
+}
+
+func (x *App_Volume) Reset() {
+	*x = App_Volume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_Volume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_Volume) ProtoMessage() {}
+
+func (x *App_Volume) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_Volume.ProtoReflect.Descriptor instead.
+func (*App_Volume) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 11}
+}
+
+func (x *App_Volume) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *App_Volume) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *App_Volume) GetAccessModeType() App_Volume_AccessMode {
+	if x != nil {
+		return x.AccessModeType
+	}
+	return App_Volume_ReadWriteOnce
+}
+
+func (x *App_Volume) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *App_Volume) GetMountPath() string {
+	if x != nil {
+		return x.MountPath
+	}
+	return ""
+}
+
+type App_Network_PortInf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Port         int32                        `protobuf:"varint,1,opt,name=Port,proto3" json:"Port,omitempty"`
+	Name         string                       `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	ProtocolType App_Network_PortInf_Protocol `protobuf:"varint,3,opt,name=ProtocolType,proto3,enum=worker0.App_Network_PortInf_Protocol" json:"ProtocolType,omitempty"`
+}
+
+func (x *App_Network_PortInf) Reset() {
+	*x = App_Network_PortInf{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_Network_PortInf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_Network_PortInf) ProtoMessage() {}
+
+func (x *App_Network_PortInf) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_Network_PortInf.ProtoReflect.Descriptor instead.
+func (*App_Network_PortInf) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 3, 0}
+}
+
+func (x *App_Network_PortInf) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *App_Network_PortInf) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *App_Network_PortInf) GetProtocolType() App_Network_PortInf_Protocol {
+	if x != nil {
+		return x.ProtocolType
+	}
+	return App_Network_PortInf_TCP
+}
+
+type App_Network_RouteInf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RouteType App_Network_RouteInf_Route `protobuf:"varint,1,opt,name=RouteType,proto3,enum=worker0.App_Network_RouteInf_Route" json:"RouteType,omitempty"`
+	Router    string                     `protobuf:"bytes,2,opt,name=Router,proto3" json:"Router,omitempty"`
+}
+
+func (x *App_Network_RouteInf) Reset() {
+	*x = App_Network_RouteInf{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_Network_RouteInf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_Network_RouteInf) ProtoMessage() {}
+
+func (x *App_Network_RouteInf) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_Network_RouteInf.ProtoReflect.Descriptor instead.
+func (*App_Network_RouteInf) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 3, 1}
+}
+
+func (x *App_Network_RouteInf) GetRouteType() App_Network_RouteInf_Route {
+	if x != nil {
+		return x.RouteType
+	}
+	return App_Network_RouteInf_IN
+}
+
+func (x *App_Network_RouteInf) GetRouter() string {
+	if x != nil {
+		return x.Router
+	}
+	return ""
+}
+
+type App_Health_Basic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MethodType App_Health_Basic_Method `protobuf:"varint,1,opt,name=MethodType,proto3,enum=worker0.App_Health_Basic_Method" json:"MethodType,omitempty"`
+	// path to request
+	Path string `protobuf:"bytes,2,opt,name=Path,proto3" json:"Path,omitempty"`
+	// same as above
+	Port      int32                 `protobuf:"varint,3,opt,name=Port,proto3" json:"Port,omitempty"`
+	ProbeType App_Health_Basic_Type `protobuf:"varint,4,opt,name=ProbeType,proto3,enum=worker0.App_Health_Basic_Type" json:"ProbeType,omitempty"`
+	// command of EXEC probe, executed in workspace of app, success if exit code is 0
+	Command []string `protobuf:"bytes,5,rep,name=Command,proto3" json:"Command,omitempty"`
+	// service of GRPC probe, empty means health of the whole server
+	GRPCService string `protobuf:"bytes,6,opt,name=GRPCService,proto3" json:"GRPCService,omitempty"`
+	// timings of probe in seconds, 0 means default of worker
+	InitialDelaySeconds int32 `protobuf:"varint,7,opt,name=InitialDelaySeconds,proto3" json:"InitialDelaySeconds,omitempty"`
+	PeriodSeconds       int32 `protobuf:"varint,8,opt,name=PeriodSeconds,proto3" json:"PeriodSeconds,omitempty"`
+	TimeoutSeconds      int32 `protobuf:"varint,9,opt,name=TimeoutSeconds,proto3" json:"TimeoutSeconds,omitempty"`
+	// failures in a row before probe is considered failed
+	FailureThreshold int32 `protobuf:"varint,10,opt,name=FailureThreshold,proto3" json:"FailureThreshold,omitempty"`
+	// successes in a row before probe is considered passed after failure, must be 1 for liveness
+	SuccessThreshold int32 `protobuf:"varint,11,opt,name=SuccessThreshold,proto3" json:"SuccessThreshold,omitempty"`
+}
+
+func (x *App_Health_Basic) Reset() {
+	*x = App_Health_Basic{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *App_Health_Basic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*App_Health_Basic) ProtoMessage() {}
+
+func (x *App_Health_Basic) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use App_Health_Basic.ProtoReflect.Descriptor instead.
+func (*App_Health_Basic) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{2, 7, 0}
+}
+
+func (x *App_Health_Basic) GetMethodType() App_Health_Basic_Method {
+	if x != nil {
+		return x.MethodType
+	}
+	return App_Health_Basic_GET
+}
+
+func (x *App_Health_Basic) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *App_Health_Basic) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *App_Health_Basic) GetProbeType() App_Health_Basic_Type {
+	if x != nil {
+		return x.ProbeType
+	}
+	return App_Health_Basic_HTTP
+}
+
+func (x *App_Health_Basic) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *App_Health_Basic) GetGRPCService() string {
+	if x != nil {
+		return x.GRPCService
+	}
+	return ""
+}
+
+func (x *App_Health_Basic) GetInitialDelaySeconds() int32 {
+	if x != nil {
+		return x.InitialDelaySeconds
+	}
+	return 0
+}
+
+func (x *App_Health_Basic) GetPeriodSeconds() int32 {
+	if x != nil {
+		return x.PeriodSeconds
+	}
+	return 0
+}
+
+func (x *App_Health_Basic) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *App_Health_Basic) GetFailureThreshold() int32 {
+	if x != nil {
+		return x.FailureThreshold
+	}
+	return 0
+}
+
+func (x *App_Health_Basic) GetSuccessThreshold() int32 {
+	if x != nil {
+		return x.SuccessThreshold
+	}
+	return 0
+}
+
+type RenderAppRes_Manifest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// kind of object, eg: Deployment
+	Kind      string `protobuf:"bytes,1,opt,name=Kind,proto3" json:"Kind,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Namespace string `protobuf:"bytes,3,opt,name=Namespace,proto3" json:"Namespace,omitempty"`
+	// object in yaml, data of secret is redacted
+	Yaml string `protobuf:"bytes,4,opt,name=Yaml,proto3" json:"Yaml,omitempty"`
+}
+
+func (x *RenderAppRes_Manifest) Reset() {
+	*x = RenderAppRes_Manifest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenderAppRes_Manifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderAppRes_Manifest) ProtoMessage() {}
+
+func (x *RenderAppRes_Manifest) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderAppRes_Manifest.ProtoReflect.Descriptor instead.
+func (*RenderAppRes_Manifest) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *RenderAppRes_Manifest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RenderAppRes_Manifest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RenderAppRes_Manifest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *RenderAppRes_Manifest) GetYaml() string {
+	if x != nil {
+		return x.Yaml
+	}
+	return ""
+}
+
+type ExecInput_Size struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Width  uint32 `protobuf:"varint,1,opt,name=Width,proto3" json:"Width,omitempty"`
+	Height uint32 `protobuf:"varint,2,opt,name=Height,proto3" json:"Height,omitempty"`
+}
+
+func (x *ExecInput_Size) Reset() {
+	*x = ExecInput_Size{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker0_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecInput_Size) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecInput_Size) ProtoMessage() {}
+
+func (x *ExecInput_Size) ProtoReflect() protoreflect.Message {
+	mi := &file_worker0_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecInput_Size.ProtoReflect.Descriptor instead.
+func (*ExecInput_Size) Descriptor() ([]byte, []int) {
+	return file_worker0_proto_rawDescGZIP(), []int{14, 0}
+}
+
+func (x *ExecInput_Size) GetWidth() uint32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ExecInput_Size) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+var File_worker0_proto protoreflect.FileDescriptor
+
+var file_worker0_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x22, 0x74, 0x0a, 0x09, 0x4e, 0x65, 0x77, 0x41,
+	0x70, 0x70, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0c, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x52, 0x0c, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x22, 0x92,
+	0x02, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x12,
+	0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a,
+	0x04, 0x45, 0x6e, 0x76, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72,
+	0x52, 0x04, 0x45, 0x6e, 0x76, 0x73, 0x12, 0x36, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f,
+	0x75, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x46,
+	0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x53, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x52, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x22, 0x2b, 0x0a, 0x08, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x67, 0x79, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x65, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x10, 0x00,
+	0x12, 0x11, 0x0a, 0x0d, 0x52, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x10, 0x01, 0x22, 0xc2, 0x19, 0x0a, 0x03, 0x41, 0x70, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x55,
+	0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x55, 0x55, 0x49, 0x44, 0x12,
+	0x2e, 0x0a, 0x05, 0x4d, 0x61, 0x69, 0x6e, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4d, 0x61, 0x69,
+	0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x05, 0x4d, 0x61, 0x69, 0x6e, 0x50, 0x12,
+	0x36, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70,
+	0x70, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0a, 0x46, 0x69, 0x6c,
+	0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x3d, 0x0a, 0x0f, 0x45, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x56, 0x61, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x45,
+	0x6e, 0x76, 0x56, 0x61, 0x72, 0x52, 0x0f, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65,
+	0x6e, 0x74, 0x56, 0x61, 0x72, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x08,
 	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x57, 0x6f, 0x72, 0x6b,
 	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x6f,
 	0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70,
@@ -1254,132 +3320,410 @@ var file_worker0_proto_rawDesc = []byte{
 	0x67, 0x52, 0x07, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x24, 0x0a, 0x04, 0x54, 0x61,
 	0x67, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
 	0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x04, 0x54, 0x61, 0x67, 0x73,
-	0x1a, 0xe1, 0x01, 0x0a, 0x0b, 0x4d, 0x61, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
-	0x12, 0x1a, 0x0a, 0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x12, 0x12, 0x0a, 0x04,
-	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x04, 0x54, 0x79,
-	0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
-	0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4d, 0x61, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65,
-	0x73, 0x73, 0x2e, 0x50, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x57, 0x6f, 0x72, 0x6b, 0x64, 0x69, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x57, 0x6f, 0x72, 0x6b, 0x64, 0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x72,
-	0x74, 0x43, 0x4d, 0x44, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x53, 0x74, 0x61, 0x72,
-	0x74, 0x43, 0x4d, 0x44, 0x22, 0x1e, 0x0a, 0x05, 0x50, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a,
-	0x06, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x49, 0x6d, 0x61,
-	0x67, 0x65, 0x10, 0x01, 0x1a, 0x51, 0x0a, 0x09, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x6f,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x6f, 0x12,
-	0x16, 0x0a, 0x06, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x1a, 0x30, 0x0a, 0x06, 0x45, 0x6e, 0x76, 0x56, 0x61,
-	0x72, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x9e, 0x03, 0x0a, 0x07, 0x4e, 0x65,
-	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x38, 0x0a, 0x08, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66,
-	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
-	0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x50, 0x6f,
-	0x72, 0x74, 0x49, 0x6e, 0x66, 0x52, 0x08, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12,
-	0x3b, 0x0a, 0x09, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70,
-	0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x49, 0x6e,
-	0x66, 0x52, 0x09, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x9a, 0x01, 0x0a,
-	0x07, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x49, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30,
-	0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x50, 0x6f, 0x72,
-	0x74, 0x49, 0x6e, 0x66, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x0c, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x22, 0x1c, 0x0a, 0x08, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x43, 0x50, 0x10, 0x00,
-	0x12, 0x07, 0x0a, 0x03, 0x55, 0x44, 0x50, 0x10, 0x01, 0x1a, 0x7f, 0x0a, 0x08, 0x52, 0x6f, 0x75,
-	0x74, 0x65, 0x49, 0x6e, 0x66, 0x12, 0x41, 0x0a, 0x09, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x79,
-	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
-	0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x52,
-	0x6f, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x09, 0x52,
-	0x6f, 0x75, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x6f, 0x75, 0x74,
-	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72,
-	0x22, 0x18, 0x0a, 0x05, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x06, 0x0a, 0x02, 0x49, 0x4e, 0x10,
-	0x00, 0x12, 0x07, 0x0a, 0x03, 0x4f, 0x55, 0x54, 0x10, 0x01, 0x1a, 0x2d, 0x0a, 0x0d, 0x57, 0x6f,
-	0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x57,
-	0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x1a, 0x52, 0x0a, 0x04, 0x46, 0x69, 0x6c,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x41, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65,
-	0x41, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x41, 0x63, 0x71, 0x75,
-	0x69, 0x72, 0x65, 0x41, 0x64, 0x64, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x68, 0x65, 0x6c, 0x6c,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x1a, 0x31, 0x0a,
-	0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x43, 0x50, 0x55, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x03, 0x43, 0x50, 0x55, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x65, 0x6d, 0x6f,
-	0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
-	0x1a, 0x87, 0x02, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x35, 0x0a, 0x08, 0x4c,
-	0x69, 0x76, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
-	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x48, 0x65, 0x61, 0x6c,
-	0x74, 0x68, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x52, 0x08, 0x4c, 0x69, 0x76, 0x65, 0x6e, 0x65,
-	0x73, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x64, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41,
-	0x70, 0x70, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x52,
-	0x08, 0x52, 0x65, 0x61, 0x64, 0x6e, 0x65, 0x73, 0x73, 0x1a, 0x8e, 0x01, 0x0a, 0x05, 0x42, 0x61,
-	0x73, 0x69, 0x63, 0x12, 0x40, 0x0a, 0x0a, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x54, 0x79, 0x70,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
-	0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x42, 0x61, 0x73,
-	0x69, 0x63, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x0a, 0x4d, 0x65, 0x74, 0x68, 0x6f,
-	0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x22, 0x1b, 0x0a,
-	0x06, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x54, 0x10, 0x00,
-	0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x01, 0x1a, 0x45, 0x0a, 0x03, 0x4c, 0x6f,
-	0x67, 0x12, 0x22, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x46, 0x69, 0x6c,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x52, 0x65, 0x61, 0x6c, 0x54, 0x69, 0x6d,
-	0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74,
-	0x68, 0x1a, 0x2d, 0x0a, 0x03, 0x54, 0x61, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x32, 0xf1, 0x04, 0x0a, 0x07, 0x57, 0x6f,
-	0x72, 0x6b, 0x65, 0x72, 0x30, 0x12, 0x2c, 0x0a, 0x06, 0x4e, 0x65, 0x77, 0x41, 0x70, 0x70, 0x12,
-	0x12, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x4e, 0x65, 0x77, 0x41, 0x70, 0x70,
-	0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70,
-	0x70, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x70, 0x70, 0x12,
-	0x0c, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x1a, 0x0e, 0x2e,
-	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12,
-	0x29, 0x0a, 0x07, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x70, 0x70, 0x12, 0x0c, 0x2e, 0x77, 0x6f, 0x72,
-	0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x1a, 0x0e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
-	0x72, 0x30, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x0a, 0x44, 0x65,
-	0x73, 0x74, 0x72, 0x6f, 0x79, 0x41, 0x70, 0x70, 0x12, 0x0c, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x12, 0x36, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e,
+	0x41, 0x70, 0x70, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x0b, 0x52, 0x65, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x4b, 0x65, 0x65, 0x70,
+	0x44, 0x61, 0x74, 0x61, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x4b, 0x65, 0x65, 0x70,
+	0x44, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x0c, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x0c, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x2d, 0x0a, 0x07, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x07, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x73, 0x1a, 0xe1, 0x01, 0x0a, 0x0b, 0x4d, 0x61, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x12, 0x12,
+	0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x04,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4d, 0x61, 0x69, 0x6e, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x2e, 0x50, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x57, 0x6f, 0x72, 0x6b, 0x64, 0x69, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x57, 0x6f, 0x72, 0x6b, 0x64, 0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x43, 0x4d, 0x44, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x43, 0x4d, 0x44, 0x22, 0x1e, 0x0a, 0x05, 0x50, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x0a, 0x0a, 0x06, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x10, 0x01, 0x1a, 0x51, 0x0a, 0x09, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x6f, 0x75, 0x6e, 0x74,
+	0x54, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x54,
+	0x6f, 0x12, 0x16, 0x0a, 0x06, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x1a, 0x30, 0x0a, 0x06, 0x45, 0x6e, 0x76,
+	0x56, 0x61, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0xcd, 0x04, 0x0a, 0x07,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x38, 0x0a, 0x08, 0x50, 0x6f, 0x72, 0x74, 0x49,
+	0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e,
+	0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x52, 0x08, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x3b, 0x0a, 0x09, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41,
+	0x70, 0x70, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x49, 0x6e, 0x66, 0x52, 0x09, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x3b,
+	0x0a, 0x0a, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70,
+	0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x52,
+	0x0a, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x44,
+	0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x44, 0x72, 0x79,
+	0x52, 0x75, 0x6e, 0x1a, 0x9a, 0x01, 0x0a, 0x07, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x12,
+	0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50,
+	0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x49, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66, 0x2e, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x0c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79,
+	0x70, 0x65, 0x22, 0x1c, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x07,
+	0x0a, 0x03, 0x54, 0x43, 0x50, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x44, 0x50, 0x10, 0x01,
+	0x1a, 0x7f, 0x0a, 0x08, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x12, 0x41, 0x0a, 0x09,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x23, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x2e, 0x52,
+	0x6f, 0x75, 0x74, 0x65, 0x52, 0x09, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x22, 0x18, 0x0a, 0x05, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x12, 0x06, 0x0a, 0x02, 0x49, 0x4e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4f, 0x55, 0x54, 0x10,
+	0x01, 0x22, 0x58, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x4e,
+	0x6f, 0x64, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x49, 0x50, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x4c, 0x6f, 0x61, 0x64,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x6e,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x4c, 0x53, 0x50, 0x61,
+	0x73, 0x73, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x10, 0x04, 0x1a, 0x2d, 0x0a, 0x0d, 0x57,
+	0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09,
+	0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x1a, 0xd4, 0x01, 0x0a, 0x04, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x41, 0x63, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x41, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x41, 0x63,
+	0x71, 0x75, 0x69, 0x72, 0x65, 0x41, 0x64, 0x64, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x68, 0x65,
+	0x6c, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x12,
+	0x1c, 0x0a, 0x09, 0x53, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x53, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x45, 0x78, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x54, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x45, 0x78,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x54, 0x6f, 0x12, 0x28, 0x0a, 0x0f, 0x53, 0x74, 0x72, 0x69, 0x70,
+	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0f, 0x53, 0x74, 0x72, 0x69, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x73, 0x1a, 0x31, 0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x43, 0x50,
+	0x55, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x43, 0x50, 0x55, 0x12, 0x16, 0x0a, 0x06,
+	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x1a, 0x88, 0x05, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12,
+	0x35, 0x0a, 0x08, 0x4c, 0x69, 0x76, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x52, 0x08, 0x4c, 0x69,
+	0x76, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x64, 0x6e, 0x65,
+	0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x42, 0x61,
+	0x73, 0x69, 0x63, 0x52, 0x08, 0x52, 0x65, 0x61, 0x64, 0x6e, 0x65, 0x73, 0x73, 0x1a, 0x8f, 0x04,
+	0x0a, 0x05, 0x42, 0x61, 0x73, 0x69, 0x63, 0x12, 0x40, 0x0a, 0x0a, 0x4d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x0a, 0x4d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x74,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a,
+	0x04, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x6f, 0x72,
+	0x74, 0x12, 0x3c, 0x0a, 0x09, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41,
+	0x70, 0x70, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x47, 0x52, 0x50,
+	0x43, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x47, 0x52, 0x50, 0x43, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x49,
+	0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61,
+	0x6c, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x24, 0x0a,
+	0x0d, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x53, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x10, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x22, 0x1b, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x07, 0x0a,
+	0x03, 0x47, 0x45, 0x54, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x01,
+	0x22, 0x2d, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50,
+	0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x43, 0x50, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x45,
+	0x58, 0x45, 0x43, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x52, 0x50, 0x43, 0x10, 0x03, 0x1a,
+	0x45, 0x0a, 0x03, 0x4c, 0x6f, 0x67, 0x12, 0x22, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x6c, 0x54, 0x69,
+	0x6d, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x52, 0x65,
+	0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x46, 0x69,
+	0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x46, 0x69,
+	0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x1a, 0x2d, 0x0a, 0x03, 0x54, 0x61, 0x67, 0x12, 0x10, 0x0a,
+	0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x90, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x12, 0x3b, 0x0a, 0x0a, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e,
+	0x41, 0x70, 0x70, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x2e, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x52, 0x0a, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x22, 0x2e, 0x0a, 0x06, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x6c, 0x77, 0x61, 0x79, 0x73, 0x10, 0x00, 0x12, 0x0d,
+	0x0a, 0x09, 0x4f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x10, 0x01, 0x12, 0x09, 0x0a,
+	0x05, 0x4e, 0x65, 0x76, 0x65, 0x72, 0x10, 0x02, 0x1a, 0x80, 0x02, 0x0a, 0x06, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x46, 0x0a, 0x0e, 0x41,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70,
+	0x70, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4d,
+	0x6f, 0x64, 0x65, 0x52, 0x0e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6c,
+	0x61, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x4d, 0x6f, 0x75, 0x6e, 0x74,
+	0x50, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4d, 0x6f, 0x75, 0x6e,
+	0x74, 0x50, 0x61, 0x74, 0x68, 0x22, 0x44, 0x0a, 0x0a, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4d,
+	0x6f, 0x64, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x65, 0x61, 0x64, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x4f, 0x6e, 0x63, 0x65, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x65, 0x61, 0x64, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x4d, 0x61, 0x6e, 0x79, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x65, 0x61,
+	0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x4d, 0x61, 0x6e, 0x79, 0x10, 0x02, 0x22, 0x2b, 0x0a, 0x08, 0x57,
+	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x66, 0x75, 0x6c, 0x53, 0x65, 0x74, 0x10, 0x01, 0x22, 0x26, 0x0a, 0x08, 0x53, 0x63, 0x61, 0x6c,
+	0x65, 0x52, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73,
+	0x22, 0xed, 0x01, 0x0a, 0x07, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04,
+	0x50, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x6f, 0x72, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x49, 0x0a, 0x0c,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x25, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70,
+	0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x66,
+	0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x0c, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x45, 0x78, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x05, 0x52, 0x10, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x6f,
+	0x72, 0x74, 0x73, 0x12, 0x3b, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x45, 0x78,
+	0x70, 0x6f, 0x73, 0x65, 0x52, 0x0a, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x22, 0x71, 0x0a, 0x07, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x41,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x48, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0xaa, 0x03, 0x0a, 0x09, 0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1e, 0x0a, 0x03, 0x41, 0x70, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x52, 0x03, 0x41, 0x70,
+	0x70, 0x12, 0x36, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41,
+	0x70, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x09,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x50, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x50, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x50,
+	0x6f, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x50, 0x6f, 0x64, 0x73, 0x12,
+	0x22, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x12, 0x18, 0x0a,
+	0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x45, 0x78, 0x69, 0x74, 0x43,
+	0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x45, 0x78, 0x69, 0x74, 0x43,
+	0x6f, 0x64, 0x65, 0x12, 0x38, 0x0a, 0x0d, 0x45, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x0d,
+	0x45, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x4f, 0x4f, 0x4d, 0x4b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x4f, 0x4f, 0x4d, 0x4b, 0x69, 0x6c, 0x6c, 0x73, 0x22, 0x55, 0x0a, 0x05, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12,
+	0x0b, 0x0a, 0x07, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x75,
+	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x74, 0x6f, 0x70, 0x70,
+	0x65, 0x64, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x05,
+	0x22, 0xb2, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x41, 0x70, 0x70, 0x52, 0x65,
+	0x73, 0x12, 0x3c, 0x0a, 0x09, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x52,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x2e, 0x4d, 0x61, 0x6e, 0x69,
+	0x66, 0x65, 0x73, 0x74, 0x52, 0x09, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73, 0x1a,
+	0x64, 0x0a, 0x08, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4b,
+	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x59, 0x61, 0x6d, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x59, 0x61, 0x6d, 0x6c, 0x22, 0x33, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x70, 0x70,
+	0x73, 0x52, 0x65, 0x71, 0x12, 0x24, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70,
+	0x2e, 0x54, 0x61, 0x67, 0x52, 0x04, 0x54, 0x61, 0x67, 0x73, 0x22, 0x35, 0x0a, 0x0b, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x04, 0x41, 0x70, 0x70,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x30, 0x2e, 0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x41, 0x70, 0x70,
+	0x73, 0x22, 0x52, 0x0a, 0x06, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x46,
+	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x46, 0x6f, 0x6c,
+	0x6c, 0x6f, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x54, 0x61, 0x69, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x53, 0x69, 0x6e, 0x63, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x53, 0x69, 0x6e, 0x63,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x35, 0x0a, 0x07, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x4c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x4c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0xa6, 0x01, 0x0a,
+	0x07, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x12, 0x2e, 0x0a, 0x04, 0x45, 0x6e, 0x76, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x52,
+	0x65, 0x71, 0x2e, 0x45, 0x6e, 0x76, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x45, 0x6e,
+	0x76, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x1a, 0x37, 0x0a, 0x09,
+	0x45, 0x6e, 0x76, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x55, 0x0a, 0x07, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x64, 0x65,
+	0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72,
+	0x12, 0x1a, 0x0a, 0x08, 0x45, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x45, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x22, 0xb0, 0x01, 0x0a,
+	0x09, 0x45, 0x78, 0x65, 0x63, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x30, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x52, 0x05, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x2f, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x30, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x53, 0x69, 0x7a,
+	0x65, 0x52, 0x06, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x1a, 0x34, 0x0a, 0x04, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x57, 0x69, 0x64, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22,
+	0x72, 0x0a, 0x0a, 0x45, 0x78, 0x65, 0x63, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x53,
+	0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x45, 0x78, 0x69, 0x74, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x45, 0x78, 0x69, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x45, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x45, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x3b, 0x0a, 0x07,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x52,
+	0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x52, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x65, 0x22, 0x76, 0x0a, 0x08, 0x46, 0x69, 0x6c,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x6f, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x4d, 0x6f, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x49,
+	0x73, 0x44, 0x69, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x49, 0x73, 0x44, 0x69,
+	0x72, 0x22, 0x37, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x12, 0x27, 0x0a, 0x05, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x05, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x1f, 0x0a, 0x09, 0x46, 0x69,
+	0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x22, 0x4a, 0x0a, 0x0c, 0x57,
+	0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x50,
+	0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x12, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x4d,
+	0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x32, 0x89, 0x0c, 0x0a, 0x07, 0x57, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x30, 0x12, 0x2c, 0x0a, 0x06, 0x4e, 0x65, 0x77, 0x41, 0x70, 0x70, 0x12, 0x12, 0x2e,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x4e, 0x65, 0x77, 0x41, 0x70, 0x70, 0x52, 0x65,
+	0x71, 0x1a, 0x0c, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x22,
+	0x00, 0x12, 0x2a, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x70, 0x70, 0x12, 0x0c, 0x2e,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x1a, 0x0e, 0x2e, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x29, 0x0a,
+	0x07, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x70, 0x70, 0x12, 0x0c, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
 	0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x1a, 0x0e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x05, 0x54, 0x61, 0x67, 0x45,
-	0x78, 0x12, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e,
-	0x54, 0x61, 0x67, 0x1a, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70,
-	0x70, 0x2e, 0x54, 0x61, 0x67, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x65, 0x4d,
-	0x6f, 0x75, 0x6e, 0x74, 0x45, 0x78, 0x12, 0x16, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30,
-	0x2e, 0x41, 0x70, 0x70, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x16,
-	0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x46, 0x69, 0x6c,
-	0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x05, 0x45, 0x6e, 0x76, 0x45,
-	0x78, 0x12, 0x13, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e,
-	0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x1a, 0x13, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30,
-	0x2e, 0x41, 0x70, 0x70, 0x2e, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x22, 0x00, 0x12, 0x39, 0x0a,
-	0x09, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x45, 0x78, 0x12, 0x14, 0x2e, 0x77, 0x6f, 0x72,
-	0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
-	0x1a, 0x14, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4e,
-	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0d, 0x46, 0x69, 0x6c, 0x65,
-	0x50, 0x72, 0x65, 0x6d, 0x69, 0x73, 0x65, 0x45, 0x78, 0x12, 0x11, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
-	0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x1a, 0x11, 0x2e, 0x77,
-	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x22,
-	0x00, 0x12, 0x33, 0x0a, 0x07, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x12, 0x12, 0x2e, 0x77,
-	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74,
-	0x1a, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4c,
-	0x69, 0x6d, 0x69, 0x74, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x08, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x0a, 0x44, 0x65, 0x73, 0x74,
+	0x72, 0x6f, 0x79, 0x41, 0x70, 0x70, 0x12, 0x0c, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30,
+	0x2e, 0x41, 0x70, 0x70, 0x1a, 0x0e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x41, 0x70, 0x70, 0x12, 0x15, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x08, 0x53, 0x63,
+	0x61, 0x6c, 0x65, 0x41, 0x70, 0x70, 0x12, 0x11, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30,
+	0x2e, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x05, 0x54, 0x61, 0x67,
+	0x45, 0x78, 0x12, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70,
+	0x2e, 0x54, 0x61, 0x67, 0x1a, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41,
+	0x70, 0x70, 0x2e, 0x54, 0x61, 0x67, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x65,
+	0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x78, 0x12, 0x16, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x1a,
+	0x16, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x46, 0x69,
+	0x6c, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x05, 0x45, 0x6e, 0x76,
 	0x45, 0x78, 0x12, 0x13, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70,
-	0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x1a, 0x13, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
-	0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x22, 0x00, 0x12, 0x2d,
-	0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x45, 0x78, 0x12, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
-	0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4c, 0x6f, 0x67, 0x1a, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
-	0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4c, 0x6f, 0x67, 0x22, 0x00, 0x42, 0x0b, 0x5a,
-	0x09, 0x2e, 0x2f, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x2e, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x1a, 0x13, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x22, 0x00, 0x12, 0x39,
+	0x0a, 0x09, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x45, 0x78, 0x12, 0x14, 0x2e, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x1a, 0x14, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0d, 0x46, 0x69, 0x6c,
+	0x65, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x73, 0x65, 0x45, 0x78, 0x12, 0x11, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x1a, 0x11, 0x2e,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x46, 0x69, 0x6c, 0x65,
+	0x22, 0x00, 0x12, 0x33, 0x0a, 0x07, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x12, 0x12, 0x2e,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x1a, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x08, 0x48, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x45, 0x78, 0x12, 0x13, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70,
+	0x70, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x1a, 0x13, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x22, 0x00, 0x12,
+	0x2d, 0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x45, 0x78, 0x12, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4c, 0x6f, 0x67, 0x1a, 0x10, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x4c, 0x6f, 0x67, 0x22, 0x00, 0x12, 0x39,
+	0x0a, 0x09, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x45, 0x78, 0x12, 0x14, 0x2e, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x1a, 0x14, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e,
+	0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x08, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x45, 0x78, 0x12, 0x13, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e,
+	0x41, 0x70, 0x70, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x1a, 0x13, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70, 0x70, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x22,
+	0x00, 0x12, 0x31, 0x0a, 0x09, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x10,
+	0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71,
+	0x1a, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x73, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x50,
+	0x6f, 0x72, 0x74, 0x12, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x50, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e,
+	0x50, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x06, 0x47, 0x65, 0x74,
+	0x41, 0x70, 0x70, 0x12, 0x0e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x41, 0x70,
+	0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x08, 0x4c, 0x69, 0x73,
+	0x74, 0x41, 0x70, 0x70, 0x73, 0x12, 0x14, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x70, 0x70, 0x73, 0x52, 0x65,
+	0x73, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x09, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x41, 0x70, 0x70,
+	0x12, 0x0e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x15, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x52, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x0a, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x0f, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x30, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x30, 0x2e, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x31,
+	0x0a, 0x09, 0x45, 0x78, 0x65, 0x63, 0x49, 0x6e, 0x41, 0x70, 0x70, 0x12, 0x10, 0x2e, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x22,
+	0x00, 0x12, 0x3d, 0x0a, 0x0c, 0x45, 0x78, 0x65, 0x63, 0x49, 0x6e, 0x41, 0x70, 0x70, 0x54, 0x54,
+	0x59, 0x12, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x45, 0x78, 0x65, 0x63,
+	0x49, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0x13, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e,
+	0x45, 0x78, 0x65, 0x63, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01,
+	0x12, 0x36, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x10, 0x2e,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x1a,
+	0x15, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x69,
+	0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x64,
+	0x46, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x39,
+	0x0a, 0x09, 0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x15, 0x2e, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x71, 0x1a, 0x11, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x28, 0x01, 0x12, 0x30, 0x0a, 0x0a, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x30, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x30, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x0a, 0x41,
+	0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x44, 0x69, 0x72, 0x12, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x30, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x30, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22,
+	0x00, 0x30, 0x01, 0x42, 0x0b, 0x5a, 0x09, 0x2e, 0x2f, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x30,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1394,78 +3738,166 @@ func file_worker0_proto_rawDescGZIP() []byte {
 	return file_worker0_proto_rawDescData
 }
 
-var file_worker0_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_worker0_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_worker0_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_worker0_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_worker0_proto_goTypes = []interface{}{
-	(App_MainProcess_PType)(0),        // 0: worker0.App.MainProcess.PType
-	(App_Network_PortInf_Protocol)(0), // 1: worker0.App.Network.PortInf.Protocol
-	(App_Network_RouteInf_Route)(0),   // 2: worker0.App.Network.RouteInf.Route
-	(App_Health_Basic_Method)(0),      // 3: worker0.App.Health.Basic.Method
-	(*NewAppReq)(nil),                 // 4: worker0.NewAppReq
-	(*App)(nil),                       // 5: worker0.App
-	(*Empty)(nil),                     // 6: worker0.Empty
-	(*App_MainProcess)(nil),           // 7: worker0.App.MainProcess
-	(*App_FileMount)(nil),             // 8: worker0.App.FileMount
-	(*App_EnvVar)(nil),                // 9: worker0.App.EnvVar
-	(*App_Network)(nil),               // 10: worker0.App.Network
-	(*App_WorkspaceInfo)(nil),         // 11: worker0.App.WorkspaceInfo
-	(*App_File)(nil),                  // 12: worker0.App.File
-	(*App_Limit)(nil),                 // 13: worker0.App.Limit
-	(*App_Health)(nil),                // 14: worker0.App.Health
-	(*App_Log)(nil),                   // 15: worker0.App.Log
-	(*App_Tag)(nil),                   // 16: worker0.App.Tag
-	(*App_Network_PortInf)(nil),       // 17: worker0.App.Network.PortInf
-	(*App_Network_RouteInf)(nil),      // 18: worker0.App.Network.RouteInf
-	(*App_Health_Basic)(nil),          // 19: worker0.App.Health.Basic
+	(UpdateAppReq_Strategy)(0),        // 0: worker0.UpdateAppReq.Strategy
+	(App_Workload)(0),                 // 1: worker0.App.Workload
+	(App_MainProcess_PType)(0),        // 2: worker0.App.MainProcess.PType
+	(App_Network_Expose)(0),           // 3: worker0.App.Network.Expose
+	(App_Network_PortInf_Protocol)(0), // 4: worker0.App.Network.PortInf.Protocol
+	(App_Network_RouteInf_Route)(0),   // 5: worker0.App.Network.RouteInf.Route
+	(App_Health_Basic_Method)(0),      // 6: worker0.App.Health.Basic.Method
+	(App_Health_Basic_Type)(0),        // 7: worker0.App.Health.Basic.Type
+	(App_Restart_Policy)(0),           // 8: worker0.App.Restart.Policy
+	(App_Volume_AccessMode)(0),        // 9: worker0.App.Volume.AccessMode
+	(AppStatus_State)(0),              // 10: worker0.AppStatus.State
+	(*NewAppReq)(nil),                 // 11: worker0.NewAppReq
+	(*UpdateAppReq)(nil),              // 12: worker0.UpdateAppReq
+	(*App)(nil),                       // 13: worker0.App
+	(*ScaleReq)(nil),                  // 14: worker0.ScaleReq
+	(*PortReq)(nil),                   // 15: worker0.PortReq
+	(*PortRes)(nil),                   // 16: worker0.PortRes
+	(*AppStatus)(nil),                 // 17: worker0.AppStatus
+	(*RenderAppRes)(nil),              // 18: worker0.RenderAppRes
+	(*ListAppsReq)(nil),               // 19: worker0.ListAppsReq
+	(*ListAppsRes)(nil),               // 20: worker0.ListAppsRes
+	(*LogReq)(nil),                    // 21: worker0.LogReq
+	(*LogLine)(nil),                   // 22: worker0.LogLine
+	(*ExecReq)(nil),                   // 23: worker0.ExecReq
+	(*ExecRes)(nil),                   // 24: worker0.ExecRes
+	(*ExecInput)(nil),                 // 25: worker0.ExecInput
+	(*ExecOutput)(nil),                // 26: worker0.ExecOutput
+	(*Empty)(nil),                     // 27: worker0.Empty
+	(*FileReq)(nil),                   // 28: worker0.FileReq
+	(*FileInfo)(nil),                  // 29: worker0.FileInfo
+	(*ListFilesRes)(nil),              // 30: worker0.ListFilesRes
+	(*FileChunk)(nil),                 // 31: worker0.FileChunk
+	(*WriteFileReq)(nil),              // 32: worker0.WriteFileReq
+	(*App_MainProcess)(nil),           // 33: worker0.App.MainProcess
+	(*App_FileMount)(nil),             // 34: worker0.App.FileMount
+	(*App_EnvVar)(nil),                // 35: worker0.App.EnvVar
+	(*App_Network)(nil),               // 36: worker0.App.Network
+	(*App_WorkspaceInfo)(nil),         // 37: worker0.App.WorkspaceInfo
+	(*App_File)(nil),                  // 38: worker0.App.File
+	(*App_Limit)(nil),                 // 39: worker0.App.Limit
+	(*App_Health)(nil),                // 40: worker0.App.Health
+	(*App_Log)(nil),                   // 41: worker0.App.Log
+	(*App_Tag)(nil),                   // 42: worker0.App.Tag
+	(*App_Restart)(nil),               // 43: worker0.App.Restart
+	(*App_Volume)(nil),                // 44: worker0.App.Volume
+	(*App_Network_PortInf)(nil),       // 45: worker0.App.Network.PortInf
+	(*App_Network_RouteInf)(nil),      // 46: worker0.App.Network.RouteInf
+	(*App_Health_Basic)(nil),          // 47: worker0.App.Health.Basic
+	(*RenderAppRes_Manifest)(nil),     // 48: worker0.RenderAppRes.Manifest
+	nil,                               // 49: worker0.ExecReq.EnvsEntry
+	(*ExecInput_Size)(nil),            // 50: worker0.ExecInput.Size
 }
 var file_worker0_proto_depIdxs = []int32{
-	7,  // 0: worker0.App.MainP:type_name -> worker0.App.MainProcess
-	8,  // 1: worker0.App.FileMounts:type_name -> worker0.App.FileMount
-	9,  // 2: worker0.App.EnvironmentVars:type_name -> worker0.App.EnvVar
-	10, // 3: worker0.App.Networks:type_name -> worker0.App.Network
-	11, // 4: worker0.App.Workspace:type_name -> worker0.App.WorkspaceInfo
-	12, // 5: worker0.App.FilePremise:type_name -> worker0.App.File
-	13, // 6: worker0.App.LimitInfo:type_name -> worker0.App.Limit
-	14, // 7: worker0.App.HealthInfo:type_name -> worker0.App.Health
-	15, // 8: worker0.App.LogInfo:type_name -> worker0.App.Log
-	16, // 9: worker0.App.Tags:type_name -> worker0.App.Tag
-	0,  // 10: worker0.App.MainProcess.Type:type_name -> worker0.App.MainProcess.PType
-	17, // 11: worker0.App.Network.PortInfo:type_name -> worker0.App.Network.PortInf
-	18, // 12: worker0.App.Network.RouteInfo:type_name -> worker0.App.Network.RouteInf
-	19, // 13: worker0.App.Health.Liveness:type_name -> worker0.App.Health.Basic
-	19, // 14: worker0.App.Health.Readness:type_name -> worker0.App.Health.Basic
-	1,  // 15: worker0.App.Network.PortInf.ProtocolType:type_name -> worker0.App.Network.PortInf.Protocol
-	2,  // 16: worker0.App.Network.RouteInf.RouteType:type_name -> worker0.App.Network.RouteInf.Route
-	3,  // 17: worker0.App.Health.Basic.MethodType:type_name -> worker0.App.Health.Basic.Method
-	4,  // 18: worker0.Worker0.NewApp:input_type -> worker0.NewAppReq
-	5,  // 19: worker0.Worker0.StartApp:input_type -> worker0.App
-	5,  // 20: worker0.Worker0.StopApp:input_type -> worker0.App
-	5,  // 21: worker0.Worker0.DestroyApp:input_type -> worker0.App
-	16, // 22: worker0.Worker0.TagEx:input_type -> worker0.App.Tag
-	8,  // 23: worker0.Worker0.FileMountEx:input_type -> worker0.App.FileMount
-	9,  // 24: worker0.Worker0.EnvEx:input_type -> worker0.App.EnvVar
-	10, // 25: worker0.Worker0.NetworkEx:input_type -> worker0.App.Network
-	12, // 26: worker0.Worker0.FilePremiseEx:input_type -> worker0.App.File
-	13, // 27: worker0.Worker0.LimitEx:input_type -> worker0.App.Limit
-	14, // 28: worker0.Worker0.HealthEx:input_type -> worker0.App.Health
-	15, // 29: worker0.Worker0.LogEx:input_type -> worker0.App.Log
-	5,  // 30: worker0.Worker0.NewApp:output_type -> worker0.App
-	6,  // 31: worker0.Worker0.StartApp:output_type -> worker0.Empty
-	6,  // 32: worker0.Worker0.StopApp:output_type -> worker0.Empty
-	6,  // 33: worker0.Worker0.DestroyApp:output_type -> worker0.Empty
-	16, // 34: worker0.Worker0.TagEx:output_type -> worker0.App.Tag
-	8,  // 35: worker0.Worker0.FileMountEx:output_type -> worker0.App.FileMount
-	9,  // 36: worker0.Worker0.EnvEx:output_type -> worker0.App.EnvVar
-	10, // 37: worker0.Worker0.NetworkEx:output_type -> worker0.App.Network
-	12, // 38: worker0.Worker0.FilePremiseEx:output_type -> worker0.App.File
-	13, // 39: worker0.Worker0.LimitEx:output_type -> worker0.App.Limit
-	14, // 40: worker0.Worker0.HealthEx:output_type -> worker0.App.Health
-	15, // 41: worker0.Worker0.LogEx:output_type -> worker0.App.Log
-	30, // [30:42] is the sub-list for method output_type
-	18, // [18:30] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	1,  // 0: worker0.NewAppReq.WorkloadType:type_name -> worker0.App.Workload
+	35, // 1: worker0.UpdateAppReq.Envs:type_name -> worker0.App.EnvVar
+	34, // 2: worker0.UpdateAppReq.FileMounts:type_name -> worker0.App.FileMount
+	0,  // 3: worker0.UpdateAppReq.UpdateStrategy:type_name -> worker0.UpdateAppReq.Strategy
+	33, // 4: worker0.App.MainP:type_name -> worker0.App.MainProcess
+	34, // 5: worker0.App.FileMounts:type_name -> worker0.App.FileMount
+	35, // 6: worker0.App.EnvironmentVars:type_name -> worker0.App.EnvVar
+	36, // 7: worker0.App.Networks:type_name -> worker0.App.Network
+	37, // 8: worker0.App.Workspace:type_name -> worker0.App.WorkspaceInfo
+	38, // 9: worker0.App.FilePremise:type_name -> worker0.App.File
+	39, // 10: worker0.App.LimitInfo:type_name -> worker0.App.Limit
+	40, // 11: worker0.App.HealthInfo:type_name -> worker0.App.Health
+	41, // 12: worker0.App.LogInfo:type_name -> worker0.App.Log
+	42, // 13: worker0.App.Tags:type_name -> worker0.App.Tag
+	43, // 14: worker0.App.RestartInfo:type_name -> worker0.App.Restart
+	1,  // 15: worker0.App.WorkloadType:type_name -> worker0.App.Workload
+	44, // 16: worker0.App.Volumes:type_name -> worker0.App.Volume
+	4,  // 17: worker0.PortReq.ProtocolType:type_name -> worker0.App.Network.PortInf.Protocol
+	3,  // 18: worker0.PortReq.ExposeType:type_name -> worker0.App.Network.Expose
+	13, // 19: worker0.AppStatus.App:type_name -> worker0.App
+	10, // 20: worker0.AppStatus.StateType:type_name -> worker0.AppStatus.State
+	39, // 21: worker0.AppStatus.EnforcedLimit:type_name -> worker0.App.Limit
+	48, // 22: worker0.RenderAppRes.Manifests:type_name -> worker0.RenderAppRes.Manifest
+	42, // 23: worker0.ListAppsReq.Tags:type_name -> worker0.App.Tag
+	17, // 24: worker0.ListAppsRes.Apps:type_name -> worker0.AppStatus
+	49, // 25: worker0.ExecReq.Envs:type_name -> worker0.ExecReq.EnvsEntry
+	23, // 26: worker0.ExecInput.Start:type_name -> worker0.ExecReq
+	50, // 27: worker0.ExecInput.Resize:type_name -> worker0.ExecInput.Size
+	29, // 28: worker0.ListFilesRes.Files:type_name -> worker0.FileInfo
+	2,  // 29: worker0.App.MainProcess.Type:type_name -> worker0.App.MainProcess.PType
+	45, // 30: worker0.App.Network.PortInfo:type_name -> worker0.App.Network.PortInf
+	46, // 31: worker0.App.Network.RouteInfo:type_name -> worker0.App.Network.RouteInf
+	3,  // 32: worker0.App.Network.ExposeType:type_name -> worker0.App.Network.Expose
+	47, // 33: worker0.App.Health.Liveness:type_name -> worker0.App.Health.Basic
+	47, // 34: worker0.App.Health.Readness:type_name -> worker0.App.Health.Basic
+	8,  // 35: worker0.App.Restart.PolicyType:type_name -> worker0.App.Restart.Policy
+	9,  // 36: worker0.App.Volume.AccessModeType:type_name -> worker0.App.Volume.AccessMode
+	4,  // 37: worker0.App.Network.PortInf.ProtocolType:type_name -> worker0.App.Network.PortInf.Protocol
+	5,  // 38: worker0.App.Network.RouteInf.RouteType:type_name -> worker0.App.Network.RouteInf.Route
+	6,  // 39: worker0.App.Health.Basic.MethodType:type_name -> worker0.App.Health.Basic.Method
+	7,  // 40: worker0.App.Health.Basic.ProbeType:type_name -> worker0.App.Health.Basic.Type
+	11, // 41: worker0.Worker0.NewApp:input_type -> worker0.NewAppReq
+	13, // 42: worker0.Worker0.StartApp:input_type -> worker0.App
+	13, // 43: worker0.Worker0.StopApp:input_type -> worker0.App
+	13, // 44: worker0.Worker0.DestroyApp:input_type -> worker0.App
+	12, // 45: worker0.Worker0.UpdateApp:input_type -> worker0.UpdateAppReq
+	14, // 46: worker0.Worker0.ScaleApp:input_type -> worker0.ScaleReq
+	42, // 47: worker0.Worker0.TagEx:input_type -> worker0.App.Tag
+	34, // 48: worker0.Worker0.FileMountEx:input_type -> worker0.App.FileMount
+	35, // 49: worker0.Worker0.EnvEx:input_type -> worker0.App.EnvVar
+	36, // 50: worker0.Worker0.NetworkEx:input_type -> worker0.App.Network
+	38, // 51: worker0.Worker0.FilePremiseEx:input_type -> worker0.App.File
+	39, // 52: worker0.Worker0.LimitEx:input_type -> worker0.App.Limit
+	40, // 53: worker0.Worker0.HealthEx:input_type -> worker0.App.Health
+	41, // 54: worker0.Worker0.LogEx:input_type -> worker0.App.Log
+	43, // 55: worker0.Worker0.RestartEx:input_type -> worker0.App.Restart
+	44, // 56: worker0.Worker0.VolumeEx:input_type -> worker0.App.Volume
+	15, // 57: worker0.Worker0.CheckPort:input_type -> worker0.PortReq
+	15, // 58: worker0.Worker0.ReservePort:input_type -> worker0.PortReq
+	27, // 59: worker0.Worker0.GetApp:input_type -> worker0.Empty
+	19, // 60: worker0.Worker0.ListApps:input_type -> worker0.ListAppsReq
+	27, // 61: worker0.Worker0.RenderApp:input_type -> worker0.Empty
+	21, // 62: worker0.Worker0.StreamLogs:input_type -> worker0.LogReq
+	23, // 63: worker0.Worker0.ExecInApp:input_type -> worker0.ExecReq
+	25, // 64: worker0.Worker0.ExecInAppTTY:input_type -> worker0.ExecInput
+	28, // 65: worker0.Worker0.ListFiles:input_type -> worker0.FileReq
+	28, // 66: worker0.Worker0.ReadFile:input_type -> worker0.FileReq
+	32, // 67: worker0.Worker0.WriteFile:input_type -> worker0.WriteFileReq
+	28, // 68: worker0.Worker0.DeleteFile:input_type -> worker0.FileReq
+	28, // 69: worker0.Worker0.ArchiveDir:input_type -> worker0.FileReq
+	13, // 70: worker0.Worker0.NewApp:output_type -> worker0.App
+	27, // 71: worker0.Worker0.StartApp:output_type -> worker0.Empty
+	27, // 72: worker0.Worker0.StopApp:output_type -> worker0.Empty
+	27, // 73: worker0.Worker0.DestroyApp:output_type -> worker0.Empty
+	13, // 74: worker0.Worker0.UpdateApp:output_type -> worker0.App
+	13, // 75: worker0.Worker0.ScaleApp:output_type -> worker0.App
+	42, // 76: worker0.Worker0.TagEx:output_type -> worker0.App.Tag
+	34, // 77: worker0.Worker0.FileMountEx:output_type -> worker0.App.FileMount
+	35, // 78: worker0.Worker0.EnvEx:output_type -> worker0.App.EnvVar
+	36, // 79: worker0.Worker0.NetworkEx:output_type -> worker0.App.Network
+	38, // 80: worker0.Worker0.FilePremiseEx:output_type -> worker0.App.File
+	39, // 81: worker0.Worker0.LimitEx:output_type -> worker0.App.Limit
+	40, // 82: worker0.Worker0.HealthEx:output_type -> worker0.App.Health
+	41, // 83: worker0.Worker0.LogEx:output_type -> worker0.App.Log
+	43, // 84: worker0.Worker0.RestartEx:output_type -> worker0.App.Restart
+	44, // 85: worker0.Worker0.VolumeEx:output_type -> worker0.App.Volume
+	16, // 86: worker0.Worker0.CheckPort:output_type -> worker0.PortRes
+	16, // 87: worker0.Worker0.ReservePort:output_type -> worker0.PortRes
+	17, // 88: worker0.Worker0.GetApp:output_type -> worker0.AppStatus
+	20, // 89: worker0.Worker0.ListApps:output_type -> worker0.ListAppsRes
+	18, // 90: worker0.Worker0.RenderApp:output_type -> worker0.RenderAppRes
+	22, // 91: worker0.Worker0.StreamLogs:output_type -> worker0.LogLine
+	24, // 92: worker0.Worker0.ExecInApp:output_type -> worker0.ExecRes
+	26, // 93: worker0.Worker0.ExecInAppTTY:output_type -> worker0.ExecOutput
+	30, // 94: worker0.Worker0.ListFiles:output_type -> worker0.ListFilesRes
+	31, // 95: worker0.Worker0.ReadFile:output_type -> worker0.FileChunk
+	29, // 96: worker0.Worker0.WriteFile:output_type -> worker0.FileInfo
+	27, // 97: worker0.Worker0.DeleteFile:output_type -> worker0.Empty
+	31, // 98: worker0.Worker0.ArchiveDir:output_type -> worker0.FileChunk
+	70, // [70:99] is the sub-list for method output_type
+	41, // [41:70] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_worker0_proto_init() }
@@ -1486,8 +3918,224 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*App); i {
+		file_worker0_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateAppReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*App); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScaleReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenderAppRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListAppsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListAppsRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListFilesRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1498,8 +4146,8 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Empty); i {
+		file_worker0_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileChunk); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1510,7 +4158,19 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_worker0_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteFileReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*App_MainProcess); i {
 			case 0:
 				return &v.state
@@ -1522,7 +4182,7 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_worker0_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*App_FileMount); i {
 			case 0:
 				return &v.state
@@ -1534,7 +4194,7 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_worker0_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*App_EnvVar); i {
 			case 0:
 				return &v.state
@@ -1546,7 +4206,7 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_worker0_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*App_Network); i {
 			case 0:
 				return &v.state
@@ -1558,7 +4218,7 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_worker0_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*App_WorkspaceInfo); i {
 			case 0:
 				return &v.state
@@ -1570,7 +4230,7 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_worker0_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*App_File); i {
 			case 0:
 				return &v.state
@@ -1582,7 +4242,7 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_worker0_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*App_Limit); i {
 			case 0:
 				return &v.state
@@ -1594,7 +4254,7 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_worker0_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*App_Health); i {
 			case 0:
 				return &v.state
@@ -1606,7 +4266,7 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_worker0_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*App_Log); i {
 			case 0:
 				return &v.state
@@ -1618,7 +4278,7 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_worker0_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*App_Tag); i {
 			case 0:
 				return &v.state
@@ -1630,7 +4290,31 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_worker0_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*App_Restart); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*App_Volume); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*App_Network_PortInf); i {
 			case 0:
 				return &v.state
@@ -1642,7 +4326,7 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_worker0_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*App_Network_RouteInf); i {
 			case 0:
 				return &v.state
@@ -1654,7 +4338,7 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
-		file_worker0_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_worker0_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*App_Health_Basic); i {
 			case 0:
 				return &v.state
@@ -1666,14 +4350,38 @@ func file_worker0_proto_init() {
 				return nil
 			}
 		}
+		file_worker0_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenderAppRes_Manifest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_worker0_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecInput_Size); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_worker0_proto_rawDesc,
-			NumEnums:      4,
-			NumMessages:   16,
+			NumEnums:      11,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1704,6 +4412,10 @@ type Worker0Client interface {
 	StartApp(ctx context.Context, in *App, opts ...grpc.CallOption) (*Empty, error)
 	StopApp(ctx context.Context, in *App, opts ...grpc.CallOption) (*Empty, error)
 	DestroyApp(ctx context.Context, in *App, opts ...grpc.CallOption) (*Empty, error)
+	// apply changed spec to app in metadata, app is redeployed if it has been started
+	UpdateApp(ctx context.Context, in *UpdateAppReq, opts ...grpc.CallOption) (*App, error)
+	// scale app in metadata to desired replicas, applied to workload if it has been started, k8s only
+	ScaleApp(ctx context.Context, in *ScaleReq, opts ...grpc.CallOption) (*App, error)
 	// --- construct App ---
 	TagEx(ctx context.Context, in *App_Tag, opts ...grpc.CallOption) (*App_Tag, error)
 	FileMountEx(ctx context.Context, in *App_FileMount, opts ...grpc.CallOption) (*App_FileMount, error)
@@ -1713,6 +4425,42 @@ type Worker0Client interface {
 	LimitEx(ctx context.Context, in *App_Limit, opts ...grpc.CallOption) (*App_Limit, error)
 	HealthEx(ctx context.Context, in *App_Health, opts ...grpc.CallOption) (*App_Health, error)
 	LogEx(ctx context.Context, in *App_Log, opts ...grpc.CallOption) (*App_Log, error)
+	// restart policy of main process, k8s only supports Always
+	RestartEx(ctx context.Context, in *App_Restart, opts ...grpc.CallOption) (*App_Restart, error)
+	// volume claimed by app, k8s only. claim is expanded if app has been started and size increases
+	VolumeEx(ctx context.Context, in *App_Volume, opts ...grpc.CallOption) (*App_Volume, error)
+	// --- port ---
+	// check whether the port is available on the machine
+	CheckPort(ctx context.Context, in *PortReq, opts ...grpc.CallOption) (*PortRes, error)
+	// reserve port for app, host port will be picked if not set, NetworkEx will use the reserved host port
+	ReservePort(ctx context.Context, in *PortReq, opts ...grpc.CallOption) (*PortRes, error)
+	// --- inspect ---
+	// get app in metadata with its runtime status
+	GetApp(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AppStatus, error)
+	// list apps on the agent with runtime status, only apps which have all the tags are returned
+	ListApps(ctx context.Context, in *ListAppsReq, opts ...grpc.CallOption) (*ListAppsRes, error)
+	// render manifests of app in metadata without touching the cluster, k8s only
+	RenderApp(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RenderAppRes, error)
+	// --- log ---
+	// stream log lines of app in metadata, pod logs for k8s, real time file for virtualbox
+	StreamLogs(ctx context.Context, in *LogReq, opts ...grpc.CallOption) (Worker0_StreamLogsClient, error)
+	// --- exec ---
+	// run command in app in metadata and wait for its result
+	ExecInApp(ctx context.Context, in *ExecReq, opts ...grpc.CallOption) (*ExecRes, error)
+	// run command in app with tty, the first input must carry Start, the following ones carry stdin or resize
+	ExecInAppTTY(ctx context.Context, opts ...grpc.CallOption) (Worker0_ExecInAppTTYClient, error)
+	// --- file ---
+	// paths of file rpc are relative to workspace of app in metadata, and can not escape it
+	// list files under the dir, recursive list all the descendants
+	ListFiles(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (*ListFilesRes, error)
+	// read content of file in chunks
+	ReadFile(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (Worker0_ReadFileClient, error)
+	// write file in chunks, the first chunk must carry path, parent dirs are created if not exist
+	WriteFile(ctx context.Context, opts ...grpc.CallOption) (Worker0_WriteFileClient, error)
+	// delete file, recursive is required to delete dir
+	DeleteFile(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (*Empty, error)
+	// archive dir as tar.gz in chunks
+	ArchiveDir(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (Worker0_ArchiveDirClient, error)
 }
 
 type worker0Client struct {
@@ -1759,6 +4507,24 @@ func (c *worker0Client) DestroyApp(ctx context.Context, in *App, opts ...grpc.Ca
 	return out, nil
 }
 
+func (c *worker0Client) UpdateApp(ctx context.Context, in *UpdateAppReq, opts ...grpc.CallOption) (*App, error) {
+	out := new(App)
+	err := c.cc.Invoke(ctx, "/worker0.Worker0/UpdateApp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worker0Client) ScaleApp(ctx context.Context, in *ScaleReq, opts ...grpc.CallOption) (*App, error) {
+	out := new(App)
+	err := c.cc.Invoke(ctx, "/worker0.Worker0/ScaleApp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *worker0Client) TagEx(ctx context.Context, in *App_Tag, opts ...grpc.CallOption) (*App_Tag, error) {
 	out := new(App_Tag)
 	err := c.cc.Invoke(ctx, "/worker0.Worker0/TagEx", in, out, opts...)
@@ -1831,12 +4597,267 @@ func (c *worker0Client) LogEx(ctx context.Context, in *App_Log, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *worker0Client) RestartEx(ctx context.Context, in *App_Restart, opts ...grpc.CallOption) (*App_Restart, error) {
+	out := new(App_Restart)
+	err := c.cc.Invoke(ctx, "/worker0.Worker0/RestartEx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worker0Client) VolumeEx(ctx context.Context, in *App_Volume, opts ...grpc.CallOption) (*App_Volume, error) {
+	out := new(App_Volume)
+	err := c.cc.Invoke(ctx, "/worker0.Worker0/VolumeEx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worker0Client) CheckPort(ctx context.Context, in *PortReq, opts ...grpc.CallOption) (*PortRes, error) {
+	out := new(PortRes)
+	err := c.cc.Invoke(ctx, "/worker0.Worker0/CheckPort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worker0Client) ReservePort(ctx context.Context, in *PortReq, opts ...grpc.CallOption) (*PortRes, error) {
+	out := new(PortRes)
+	err := c.cc.Invoke(ctx, "/worker0.Worker0/ReservePort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worker0Client) GetApp(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AppStatus, error) {
+	out := new(AppStatus)
+	err := c.cc.Invoke(ctx, "/worker0.Worker0/GetApp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worker0Client) ListApps(ctx context.Context, in *ListAppsReq, opts ...grpc.CallOption) (*ListAppsRes, error) {
+	out := new(ListAppsRes)
+	err := c.cc.Invoke(ctx, "/worker0.Worker0/ListApps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worker0Client) RenderApp(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RenderAppRes, error) {
+	out := new(RenderAppRes)
+	err := c.cc.Invoke(ctx, "/worker0.Worker0/RenderApp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worker0Client) StreamLogs(ctx context.Context, in *LogReq, opts ...grpc.CallOption) (Worker0_StreamLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Worker0_serviceDesc.Streams[0], "/worker0.Worker0/StreamLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &worker0StreamLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Worker0_StreamLogsClient interface {
+	Recv() (*LogLine, error)
+	grpc.ClientStream
+}
+
+type worker0StreamLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *worker0StreamLogsClient) Recv() (*LogLine, error) {
+	m := new(LogLine)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *worker0Client) ExecInApp(ctx context.Context, in *ExecReq, opts ...grpc.CallOption) (*ExecRes, error) {
+	out := new(ExecRes)
+	err := c.cc.Invoke(ctx, "/worker0.Worker0/ExecInApp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worker0Client) ExecInAppTTY(ctx context.Context, opts ...grpc.CallOption) (Worker0_ExecInAppTTYClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Worker0_serviceDesc.Streams[1], "/worker0.Worker0/ExecInAppTTY", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &worker0ExecInAppTTYClient{stream}
+	return x, nil
+}
+
+type Worker0_ExecInAppTTYClient interface {
+	Send(*ExecInput) error
+	Recv() (*ExecOutput, error)
+	grpc.ClientStream
+}
+
+type worker0ExecInAppTTYClient struct {
+	grpc.ClientStream
+}
+
+func (x *worker0ExecInAppTTYClient) Send(m *ExecInput) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *worker0ExecInAppTTYClient) Recv() (*ExecOutput, error) {
+	m := new(ExecOutput)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *worker0Client) ListFiles(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (*ListFilesRes, error) {
+	out := new(ListFilesRes)
+	err := c.cc.Invoke(ctx, "/worker0.Worker0/ListFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worker0Client) ReadFile(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (Worker0_ReadFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Worker0_serviceDesc.Streams[2], "/worker0.Worker0/ReadFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &worker0ReadFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Worker0_ReadFileClient interface {
+	Recv() (*FileChunk, error)
+	grpc.ClientStream
+}
+
+type worker0ReadFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *worker0ReadFileClient) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *worker0Client) WriteFile(ctx context.Context, opts ...grpc.CallOption) (Worker0_WriteFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Worker0_serviceDesc.Streams[3], "/worker0.Worker0/WriteFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &worker0WriteFileClient{stream}
+	return x, nil
+}
+
+type Worker0_WriteFileClient interface {
+	Send(*WriteFileReq) error
+	CloseAndRecv() (*FileInfo, error)
+	grpc.ClientStream
+}
+
+type worker0WriteFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *worker0WriteFileClient) Send(m *WriteFileReq) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *worker0WriteFileClient) CloseAndRecv() (*FileInfo, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(FileInfo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *worker0Client) DeleteFile(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/worker0.Worker0/DeleteFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *worker0Client) ArchiveDir(ctx context.Context, in *FileReq, opts ...grpc.CallOption) (Worker0_ArchiveDirClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Worker0_serviceDesc.Streams[4], "/worker0.Worker0/ArchiveDir", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &worker0ArchiveDirClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Worker0_ArchiveDirClient interface {
+	Recv() (*FileChunk, error)
+	grpc.ClientStream
+}
+
+type worker0ArchiveDirClient struct {
+	grpc.ClientStream
+}
+
+func (x *worker0ArchiveDirClient) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Worker0Server is the server API for Worker0 service.
 type Worker0Server interface {
 	NewApp(context.Context, *NewAppReq) (*App, error)
 	StartApp(context.Context, *App) (*Empty, error)
 	StopApp(context.Context, *App) (*Empty, error)
 	DestroyApp(context.Context, *App) (*Empty, error)
+	// apply changed spec to app in metadata, app is redeployed if it has been started
+	UpdateApp(context.Context, *UpdateAppReq) (*App, error)
+	// scale app in metadata to desired replicas, applied to workload if it has been started, k8s only
+	ScaleApp(context.Context, *ScaleReq) (*App, error)
 	// --- construct App ---
 	TagEx(context.Context, *App_Tag) (*App_Tag, error)
 	FileMountEx(context.Context, *App_FileMount) (*App_FileMount, error)
@@ -1846,6 +4867,42 @@ type Worker0Server interface {
 	LimitEx(context.Context, *App_Limit) (*App_Limit, error)
 	HealthEx(context.Context, *App_Health) (*App_Health, error)
 	LogEx(context.Context, *App_Log) (*App_Log, error)
+	// restart policy of main process, k8s only supports Always
+	RestartEx(context.Context, *App_Restart) (*App_Restart, error)
+	// volume claimed by app, k8s only. claim is expanded if app has been started and size increases
+	VolumeEx(context.Context, *App_Volume) (*App_Volume, error)
+	// --- port ---
+	// check whether the port is available on the machine
+	CheckPort(context.Context, *PortReq) (*PortRes, error)
+	// reserve port for app, host port will be picked if not set, NetworkEx will use the reserved host port
+	ReservePort(context.Context, *PortReq) (*PortRes, error)
+	// --- inspect ---
+	// get app in metadata with its runtime status
+	GetApp(context.Context, *Empty) (*AppStatus, error)
+	// list apps on the agent with runtime status, only apps which have all the tags are returned
+	ListApps(context.Context, *ListAppsReq) (*ListAppsRes, error)
+	// render manifests of app in metadata without touching the cluster, k8s only
+	RenderApp(context.Context, *Empty) (*RenderAppRes, error)
+	// --- log ---
+	// stream log lines of app in metadata, pod logs for k8s, real time file for virtualbox
+	StreamLogs(*LogReq, Worker0_StreamLogsServer) error
+	// --- exec ---
+	// run command in app in metadata and wait for its result
+	ExecInApp(context.Context, *ExecReq) (*ExecRes, error)
+	// run command in app with tty, the first input must carry Start, the following ones carry stdin or resize
+	ExecInAppTTY(Worker0_ExecInAppTTYServer) error
+	// --- file ---
+	// paths of file rpc are relative to workspace of app in metadata, and can not escape it
+	// list files under the dir, recursive list all the descendants
+	ListFiles(context.Context, *FileReq) (*ListFilesRes, error)
+	// read content of file in chunks
+	ReadFile(*FileReq, Worker0_ReadFileServer) error
+	// write file in chunks, the first chunk must carry path, parent dirs are created if not exist
+	WriteFile(Worker0_WriteFileServer) error
+	// delete file, recursive is required to delete dir
+	DeleteFile(context.Context, *FileReq) (*Empty, error)
+	// archive dir as tar.gz in chunks
+	ArchiveDir(*FileReq, Worker0_ArchiveDirServer) error
 }
 
 // UnimplementedWorker0Server can be embedded to have forward compatible implementations.
@@ -1864,29 +4921,80 @@ func (*UnimplementedWorker0Server) StopApp(context.Context, *App) (*Empty, error
 func (*UnimplementedWorker0Server) DestroyApp(context.Context, *App) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyApp not implemented")
 }
+func (*UnimplementedWorker0Server) UpdateApp(context.Context, *UpdateAppReq) (*App, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateApp not implemented")
+}
+func (*UnimplementedWorker0Server) ScaleApp(context.Context, *ScaleReq) (*App, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScaleApp not implemented")
+}
 func (*UnimplementedWorker0Server) TagEx(context.Context, *App_Tag) (*App_Tag, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TagEx not implemented")
 }
-func (*UnimplementedWorker0Server) FileMountEx(context.Context, *App_FileMount) (*App_FileMount, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FileMountEx not implemented")
+func (*UnimplementedWorker0Server) FileMountEx(context.Context, *App_FileMount) (*App_FileMount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileMountEx not implemented")
+}
+func (*UnimplementedWorker0Server) EnvEx(context.Context, *App_EnvVar) (*App_EnvVar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnvEx not implemented")
+}
+func (*UnimplementedWorker0Server) NetworkEx(context.Context, *App_Network) (*App_Network, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NetworkEx not implemented")
+}
+func (*UnimplementedWorker0Server) FilePremiseEx(context.Context, *App_File) (*App_File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FilePremiseEx not implemented")
+}
+func (*UnimplementedWorker0Server) LimitEx(context.Context, *App_Limit) (*App_Limit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LimitEx not implemented")
+}
+func (*UnimplementedWorker0Server) HealthEx(context.Context, *App_Health) (*App_Health, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HealthEx not implemented")
+}
+func (*UnimplementedWorker0Server) LogEx(context.Context, *App_Log) (*App_Log, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogEx not implemented")
+}
+func (*UnimplementedWorker0Server) RestartEx(context.Context, *App_Restart) (*App_Restart, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartEx not implemented")
+}
+func (*UnimplementedWorker0Server) VolumeEx(context.Context, *App_Volume) (*App_Volume, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeEx not implemented")
+}
+func (*UnimplementedWorker0Server) CheckPort(context.Context, *PortReq) (*PortRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPort not implemented")
+}
+func (*UnimplementedWorker0Server) ReservePort(context.Context, *PortReq) (*PortRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReservePort not implemented")
+}
+func (*UnimplementedWorker0Server) GetApp(context.Context, *Empty) (*AppStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApp not implemented")
+}
+func (*UnimplementedWorker0Server) ListApps(context.Context, *ListAppsReq) (*ListAppsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApps not implemented")
+}
+func (*UnimplementedWorker0Server) RenderApp(context.Context, *Empty) (*RenderAppRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderApp not implemented")
+}
+func (*UnimplementedWorker0Server) StreamLogs(*LogReq, Worker0_StreamLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
+}
+func (*UnimplementedWorker0Server) ExecInApp(context.Context, *ExecReq) (*ExecRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecInApp not implemented")
 }
-func (*UnimplementedWorker0Server) EnvEx(context.Context, *App_EnvVar) (*App_EnvVar, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EnvEx not implemented")
+func (*UnimplementedWorker0Server) ExecInAppTTY(Worker0_ExecInAppTTYServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExecInAppTTY not implemented")
 }
-func (*UnimplementedWorker0Server) NetworkEx(context.Context, *App_Network) (*App_Network, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method NetworkEx not implemented")
+func (*UnimplementedWorker0Server) ListFiles(context.Context, *FileReq) (*ListFilesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFiles not implemented")
 }
-func (*UnimplementedWorker0Server) FilePremiseEx(context.Context, *App_File) (*App_File, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FilePremiseEx not implemented")
+func (*UnimplementedWorker0Server) ReadFile(*FileReq, Worker0_ReadFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadFile not implemented")
 }
-func (*UnimplementedWorker0Server) LimitEx(context.Context, *App_Limit) (*App_Limit, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LimitEx not implemented")
+func (*UnimplementedWorker0Server) WriteFile(Worker0_WriteFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method WriteFile not implemented")
 }
-func (*UnimplementedWorker0Server) HealthEx(context.Context, *App_Health) (*App_Health, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method HealthEx not implemented")
+func (*UnimplementedWorker0Server) DeleteFile(context.Context, *FileReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
 }
-func (*UnimplementedWorker0Server) LogEx(context.Context, *App_Log) (*App_Log, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LogEx not implemented")
+func (*UnimplementedWorker0Server) ArchiveDir(*FileReq, Worker0_ArchiveDirServer) error {
+	return status.Errorf(codes.Unimplemented, "method ArchiveDir not implemented")
 }
 
 func RegisterWorker0Server(s *grpc.Server, srv Worker0Server) {
@@ -1965,6 +5073,42 @@ func _Worker0_DestroyApp_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Worker0_UpdateApp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAppReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Worker0Server).UpdateApp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/worker0.Worker0/UpdateApp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Worker0Server).UpdateApp(ctx, req.(*UpdateAppReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker0_ScaleApp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScaleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Worker0Server).ScaleApp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/worker0.Worker0/ScaleApp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Worker0Server).ScaleApp(ctx, req.(*ScaleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Worker0_TagEx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(App_Tag)
 	if err := dec(in); err != nil {
@@ -2109,6 +5253,301 @@ func _Worker0_LogEx_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Worker0_RestartEx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(App_Restart)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Worker0Server).RestartEx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/worker0.Worker0/RestartEx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Worker0Server).RestartEx(ctx, req.(*App_Restart))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker0_VolumeEx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(App_Volume)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Worker0Server).VolumeEx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/worker0.Worker0/VolumeEx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Worker0Server).VolumeEx(ctx, req.(*App_Volume))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker0_CheckPort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Worker0Server).CheckPort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/worker0.Worker0/CheckPort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Worker0Server).CheckPort(ctx, req.(*PortReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker0_ReservePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Worker0Server).ReservePort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/worker0.Worker0/ReservePort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Worker0Server).ReservePort(ctx, req.(*PortReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker0_GetApp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Worker0Server).GetApp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/worker0.Worker0/GetApp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Worker0Server).GetApp(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker0_ListApps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAppsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Worker0Server).ListApps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/worker0.Worker0/ListApps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Worker0Server).ListApps(ctx, req.(*ListAppsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker0_RenderApp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Worker0Server).RenderApp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/worker0.Worker0/RenderApp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Worker0Server).RenderApp(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker0_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(Worker0Server).StreamLogs(m, &worker0StreamLogsServer{stream})
+}
+
+type Worker0_StreamLogsServer interface {
+	Send(*LogLine) error
+	grpc.ServerStream
+}
+
+type worker0StreamLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *worker0StreamLogsServer) Send(m *LogLine) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Worker0_ExecInApp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Worker0Server).ExecInApp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/worker0.Worker0/ExecInApp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Worker0Server).ExecInApp(ctx, req.(*ExecReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker0_ExecInAppTTY_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(Worker0Server).ExecInAppTTY(&worker0ExecInAppTTYServer{stream})
+}
+
+type Worker0_ExecInAppTTYServer interface {
+	Send(*ExecOutput) error
+	Recv() (*ExecInput, error)
+	grpc.ServerStream
+}
+
+type worker0ExecInAppTTYServer struct {
+	grpc.ServerStream
+}
+
+func (x *worker0ExecInAppTTYServer) Send(m *ExecOutput) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *worker0ExecInAppTTYServer) Recv() (*ExecInput, error) {
+	m := new(ExecInput)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Worker0_ListFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Worker0Server).ListFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/worker0.Worker0/ListFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Worker0Server).ListFiles(ctx, req.(*FileReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker0_ReadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FileReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(Worker0Server).ReadFile(m, &worker0ReadFileServer{stream})
+}
+
+type Worker0_ReadFileServer interface {
+	Send(*FileChunk) error
+	grpc.ServerStream
+}
+
+type worker0ReadFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *worker0ReadFileServer) Send(m *FileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Worker0_WriteFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(Worker0Server).WriteFile(&worker0WriteFileServer{stream})
+}
+
+type Worker0_WriteFileServer interface {
+	SendAndClose(*FileInfo) error
+	Recv() (*WriteFileReq, error)
+	grpc.ServerStream
+}
+
+type worker0WriteFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *worker0WriteFileServer) SendAndClose(m *FileInfo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *worker0WriteFileServer) Recv() (*WriteFileReq, error) {
+	m := new(WriteFileReq)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Worker0_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Worker0Server).DeleteFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/worker0.Worker0/DeleteFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Worker0Server).DeleteFile(ctx, req.(*FileReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker0_ArchiveDir_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FileReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(Worker0Server).ArchiveDir(m, &worker0ArchiveDirServer{stream})
+}
+
+type Worker0_ArchiveDirServer interface {
+	Send(*FileChunk) error
+	grpc.ServerStream
+}
+
+type worker0ArchiveDirServer struct {
+	grpc.ServerStream
+}
+
+func (x *worker0ArchiveDirServer) Send(m *FileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Worker0_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "worker0.Worker0",
 	HandlerType: (*Worker0Server)(nil),
@@ -2129,6 +5568,14 @@ var _Worker0_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DestroyApp",
 			Handler:    _Worker0_DestroyApp_Handler,
 		},
+		{
+			MethodName: "UpdateApp",
+			Handler:    _Worker0_UpdateApp_Handler,
+		},
+		{
+			MethodName: "ScaleApp",
+			Handler:    _Worker0_ScaleApp_Handler,
+		},
 		{
 			MethodName: "TagEx",
 			Handler:    _Worker0_TagEx_Handler,
@@ -2161,7 +5608,74 @@ var _Worker0_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LogEx",
 			Handler:    _Worker0_LogEx_Handler,
 		},
+		{
+			MethodName: "RestartEx",
+			Handler:    _Worker0_RestartEx_Handler,
+		},
+		{
+			MethodName: "VolumeEx",
+			Handler:    _Worker0_VolumeEx_Handler,
+		},
+		{
+			MethodName: "CheckPort",
+			Handler:    _Worker0_CheckPort_Handler,
+		},
+		{
+			MethodName: "ReservePort",
+			Handler:    _Worker0_ReservePort_Handler,
+		},
+		{
+			MethodName: "GetApp",
+			Handler:    _Worker0_GetApp_Handler,
+		},
+		{
+			MethodName: "ListApps",
+			Handler:    _Worker0_ListApps_Handler,
+		},
+		{
+			MethodName: "RenderApp",
+			Handler:    _Worker0_RenderApp_Handler,
+		},
+		{
+			MethodName: "ExecInApp",
+			Handler:    _Worker0_ExecInApp_Handler,
+		},
+		{
+			MethodName: "ListFiles",
+			Handler:    _Worker0_ListFiles_Handler,
+		},
+		{
+			MethodName: "DeleteFile",
+			Handler:    _Worker0_DeleteFile_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _Worker0_StreamLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExecInAppTTY",
+			Handler:       _Worker0_ExecInAppTTY_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ReadFile",
+			Handler:       _Worker0_ReadFile_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WriteFile",
+			Handler:       _Worker0_WriteFile_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ArchiveDir",
+			Handler:       _Worker0_ArchiveDir_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "worker0.proto",
 }